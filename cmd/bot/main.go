@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/Johnnycyan/cyan-birthdays/internal/bot"
 	"github.com/Johnnycyan/cyan-birthdays/internal/config"
@@ -13,6 +16,12 @@ import (
 )
 
 func main() {
+	// Handle "migrate up|down|status" without starting the bot
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		setupLogging(os.Getenv("LOG_LEVEL"))
+		os.Exit(runMigrate(os.Args[2:]))
+	}
+
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -20,20 +29,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Set up logging
-	var logLevel slog.Level
-	switch cfg.LogLevel {
-	case "debug":
-		logLevel = slog.LevelDebug
-	case "warn":
-		logLevel = slog.LevelWarn
-	case "error":
-		logLevel = slog.LevelError
-	default:
-		logLevel = slog.LevelInfo
-	}
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
-	slog.SetDefault(logger)
+	setupLogging(cfg.LogLevel)
 
 	// Connect to database
 	db, err := database.Connect(cfg.DatabaseURL)
@@ -61,6 +57,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Serve health checks, calendar feeds and the REST API when an HTTP address is configured, and
+	// metrics on their own address
+	var servers []*http.Server
+	if cfg.HTTPAddr != "" {
+		servers = append(servers, startHTTPServer(cfg.HTTPAddr, b.HTTPHandler()))
+	}
+	if cfg.MetricsAddr != "" {
+		servers = append(servers, startHTTPServer(cfg.MetricsAddr, b.MetricsHandler()))
+	}
+
 	slog.Info("Bot is running. Press Ctrl+C to exit.")
 
 	// Wait for interrupt signal
@@ -69,7 +75,47 @@ func main() {
 	<-ctx.Done()
 
 	slog.Info("Shutting down...")
+	for _, srv := range servers {
+		shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			slog.Error("Error shutting down HTTP server", "addr", srv.Addr, "error", err)
+		}
+		cancelShutdown()
+	}
 	if err := b.Stop(); err != nil {
 		slog.Error("Error during shutdown", "error", err)
 	}
 }
+
+// startHTTPServer serves handler on addr in the background
+func startHTTPServer(addr string, handler http.Handler) *http.Server {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		slog.Info("Starting HTTP server", "addr", addr)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("HTTP server failed", "error", err)
+		}
+	}()
+	return srv
+}
+
+// setupLogging installs the default slog logger at the configured level
+func setupLogging(level string) {
+	var logLevel slog.Level
+	switch level {
+	case "debug":
+		logLevel = slog.LevelDebug
+	case "warn":
+		logLevel = slog.LevelWarn
+	case "error":
+		logLevel = slog.LevelError
+	default:
+		logLevel = slog.LevelInfo
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: logLevel}))
+	slog.SetDefault(logger)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/config"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+)
+
+const migrateUsage = `Usage: cyan-birthdays migrate <command>
+
+Commands:
+  up            Apply all pending migrations
+  down [steps]  Roll back the last applied migration(s) (default: 1)
+  status        Show which migrations have been applied`
+
+// runMigrate handles the migrate subcommand and returns the process exit code
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	dbURL, err := config.LoadDatabaseURL()
+	if err != nil {
+		slog.Error("Failed to load configuration", "error", err)
+		return 1
+	}
+
+	db, err := database.Connect(dbURL)
+	if err != nil {
+		slog.Error("Failed to connect to database", "error", err)
+		return 1
+	}
+	defer db.Close()
+
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		applied, err := database.MigrateUp(ctx, db)
+		if err != nil {
+			slog.Error("Failed to apply migrations", "error", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("Database is up to date")
+		}
+		for _, m := range applied {
+			fmt.Printf("Applied %04d_%s\n", m.Version, m.Name)
+		}
+
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				fmt.Fprintln(os.Stderr, "steps must be a positive number")
+				return 2
+			}
+		}
+		rolledBack, err := database.MigrateDown(ctx, db, steps)
+		if err != nil {
+			slog.Error("Failed to roll back migrations", "error", err)
+			return 1
+		}
+		if len(rolledBack) == 0 {
+			fmt.Println("No migrations to roll back")
+		}
+		for _, m := range rolledBack {
+			fmt.Printf("Rolled back %04d_%s\n", m.Version, m.Name)
+		}
+
+	case "status":
+		statuses, err := database.GetMigrationStatus(ctx, db)
+		if err != nil {
+			slog.Error("Failed to get migration status", "error", err)
+			return 1
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+		for _, st := range statuses {
+			status, appliedAt := "pending", "-"
+			if st.Applied {
+				status = "applied"
+				appliedAt = st.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", st.Version, st.Name, status, appliedAt)
+		}
+		w.Flush()
+
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	return 0
+}
@@ -0,0 +1,264 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/jackc/pgx/v5"
+)
+
+// apiKeyPrefix starts every API key so leaked keys are easy to recognize
+const apiKeyPrefix = "cbk_"
+
+// Query parameter bounds for the REST API
+const (
+	apiDefaultUpcomingDays = 7
+	apiMaxUpcomingDays     = 365
+	apiDefaultHistoryLimit = 20
+	apiMaxHistoryLimit     = 100
+)
+
+// newAPIKey generates an API key and returns it with its public ID. The key has the form
+// cbk_<id>_<secret>; only its hash is stored.
+func newAPIKey() (key, keyID string) {
+	id := make([]byte, 4)
+	secret := make([]byte, 24)
+	rand.Read(id)
+	rand.Read(secret)
+	keyID = hex.EncodeToString(id)
+	return apiKeyPrefix + keyID + "_" + hex.EncodeToString(secret), keyID
+}
+
+// hashAPIKey returns the stored form of an API key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// bearerToken extracts the token from an "Authorization: Bearer <token>" header
+func bearerToken(header string) (string, bool) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+// apiHandlerFunc handles an authenticated API request for a guild
+type apiHandlerFunc func(w http.ResponseWriter, r *http.Request, guildID string)
+
+// requireAPIKey authenticates a request with one of the guild's API keys before calling next
+func (b *Bot) requireAPIKey(next apiHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		guildID := r.PathValue("guild")
+
+		token, ok := bearerToken(r.Header.Get("Authorization"))
+		if !ok {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="cyan-birthdays"`)
+			writeAPIError(w, http.StatusUnauthorized, "missing API key")
+			return
+		}
+
+		key, err := b.repo.GetAPIKeyByHash(r.Context(), hashAPIKey(token))
+		if err != nil {
+			slog.Error("Failed to look up API key", "error", err)
+			writeAPIError(w, http.StatusInternalServerError, "internal error")
+			return
+		}
+		// A key for another guild gets the same answer as an unknown one, so callers can't tell
+		// whether a key exists
+		if key == nil || key.GuildID != guildID {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="cyan-birthdays", error="invalid_token"`)
+			writeAPIError(w, http.StatusUnauthorized, "invalid API key")
+			return
+		}
+
+		if err := b.repo.TouchAPIKey(r.Context(), key.KeyID, b.clock.Now()); err != nil {
+			slog.Warn("Failed to record API key use", "key_id", key.KeyID, "error", err)
+		}
+		slog.Debug("API request", "guild_id", guildID, "key_id", key.KeyID, "path", r.URL.Path)
+
+		next(w, r, guildID)
+	}
+}
+
+// apiUpcomingBirthday is an upcoming birthday in API responses
+type apiUpcomingBirthday struct {
+	UserID      string    `json:"user_id"`
+	Month       int       `json:"month"`
+	Day         int       `json:"day"`
+	Year        *int      `json:"year"`
+	Age         *int      `json:"age"`
+	Date        string    `json:"date"`
+	DaysAway    int       `json:"days_away"`
+	AnnouncesAt time.Time `json:"announces_at"`
+}
+
+// apiUpcomingResponse is the body of GET /api/v1/guilds/{guild}/birthdays/upcoming
+type apiUpcomingResponse struct {
+	GuildID   string                `json:"guild_id"`
+	Days      int                   `json:"days"`
+	Birthdays []apiUpcomingBirthday `json:"birthdays"`
+}
+
+// newAPIUpcomingResponse converts upcoming birthdays to their API form
+func newAPIUpcomingResponse(guildID string, days int, upcoming []upcomingBirthday) apiUpcomingResponse {
+	resp := apiUpcomingResponse{GuildID: guildID, Days: days, Birthdays: make([]apiUpcomingBirthday, 0, len(upcoming))}
+	for _, u := range upcoming {
+		bd := apiUpcomingBirthday{
+			UserID:      u.UserID,
+			Month:       u.Month,
+			Day:         u.Day,
+			Year:        u.Year,
+			Date:        u.Next.Format("2006-01-02"),
+			DaysAway:    u.DaysAway,
+			AnnouncesAt: u.AnnouncesAt.UTC(),
+		}
+		if age := u.Age(); age > 0 {
+			bd.Age = &age
+		}
+		resp.Birthdays = append(resp.Birthdays, bd)
+	}
+	return resp
+}
+
+// handleAPIUpcoming serves the birthdays in the next ?days= days (default 7)
+func (b *Bot) handleAPIUpcoming(w http.ResponseWriter, r *http.Request, guildID string) {
+	days, err := queryInt(r, "days", apiDefaultUpcomingDays, 1, apiMaxUpcomingDays)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	upcoming, _, err := b.upcomingBirthdays(r.Context(), guildID, days)
+	if err != nil {
+		slog.Error("Failed to get upcoming birthdays for API", "guild_id", guildID, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, newAPIUpcomingResponse(guildID, days, upcoming))
+}
+
+// apiSettingsResponse is the body of GET /api/v1/guilds/{guild}/settings
+type apiSettingsResponse struct {
+	GuildID  string          `json:"guild_id"`
+	Settings *exportSettings `json:"settings"`
+}
+
+// handleAPISettings serves the guild's settings, in the same shape as the JSON export
+func (b *Bot) handleAPISettings(w http.ResponseWriter, r *http.Request, guildID string) {
+	resp := apiSettingsResponse{GuildID: guildID}
+	gs, err := b.repo.GetGuildSettings(r.Context(), guildID)
+	switch {
+	case err == nil:
+		resp.Settings = newExportSettings(gs)
+	case !errors.Is(err, pgx.ErrNoRows):
+		slog.Error("Failed to get guild settings for API", "guild_id", guildID, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// apiAnnouncement is a recorded announcement in API responses
+type apiAnnouncement struct {
+	UserID       string    `json:"user_id"`
+	BirthdayYear int       `json:"birthday_year"`
+	ChannelID    string    `json:"channel_id"`
+	MessageID    *string   `json:"message_id"`
+	AnnouncedAt  time.Time `json:"announced_at"`
+}
+
+// apiAnnouncementsResponse is the body of GET /api/v1/guilds/{guild}/announcements
+type apiAnnouncementsResponse struct {
+	GuildID       string            `json:"guild_id"`
+	Announcements []apiAnnouncement `json:"announcements"`
+}
+
+// handleAPIAnnouncements serves the most recent announcements, optionally filtered by ?user_id=
+func (b *Bot) handleAPIAnnouncements(w http.ResponseWriter, r *http.Request, guildID string) {
+	limit, err := queryInt(r, "limit", apiDefaultHistoryLimit, 1, apiMaxHistoryLimit)
+	if err != nil {
+		writeAPIError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	var userID *string
+	if id := r.URL.Query().Get("user_id"); id != "" {
+		userID = &id
+	}
+
+	history, err := b.repo.GetAnnouncementHistory(r.Context(), guildID, userID, limit)
+	if err != nil {
+		slog.Error("Failed to get announcement history for API", "guild_id", guildID, "error", err)
+		writeAPIError(w, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	resp := apiAnnouncementsResponse{GuildID: guildID, Announcements: make([]apiAnnouncement, 0, len(history))}
+	for _, a := range history {
+		resp.Announcements = append(resp.Announcements, apiAnnouncement{
+			UserID:       a.UserID,
+			BirthdayYear: a.BirthdayYear,
+			ChannelID:    a.ChannelID,
+			MessageID:    a.MessageID,
+			AnnouncedAt:  a.AnnouncedAt.UTC(),
+		})
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// queryInt reads an integer query parameter, returning def if it is absent
+func queryInt(r *http.Request, name string, def, lo, hi int) (int, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < lo || n > hi {
+		return 0, fmt.Errorf("%s must be a number from %d to %d", name, lo, hi)
+	}
+	return n, nil
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Debug("Failed to write JSON response", "error", err)
+	}
+}
+
+// writeAPIError writes a JSON error response
+func writeAPIError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{"error": message})
+}
+
+// createAPIKey generates and stores a new API key for a guild, returning the key (shown once) and its ID
+func (b *Bot) createAPIKey(ctx context.Context, guildID, name, createdBy string) (string, string, error) {
+	key, keyID := newAPIKey()
+	err := b.repo.CreateAPIKey(ctx, &database.APIKey{
+		KeyID:     keyID,
+		GuildID:   guildID,
+		Name:      name,
+		KeyHash:   hashAPIKey(key),
+		CreatedBy: createdBy,
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return key, keyID, nil
+}
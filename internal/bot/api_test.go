@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+)
+
+func TestNewAPIKey(t *testing.T) {
+	key, keyID := newAPIKey()
+	if !strings.HasPrefix(key, apiKeyPrefix+keyID+"_") || len(keyID) != 8 {
+		t.Fatalf("key %q does not embed its ID %q", key, keyID)
+	}
+	other, _ := newAPIKey()
+	if key == other {
+		t.Fatal("two generated keys are equal")
+	}
+	if hashAPIKey(key) == hashAPIKey(other) || len(hashAPIKey(key)) != 64 {
+		t.Error("unexpected key hashes")
+	}
+}
+
+func TestBearerToken(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+		ok     bool
+	}{
+		{"Bearer cbk_abc", "cbk_abc", true},
+		{"bearer  cbk_abc ", "cbk_abc", true},
+		{"Basic dXNlcg==", "", false},
+		{"Bearer", "", false},
+		{"Bearer ", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := bearerToken(tt.header)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("bearerToken(%q) = %q, %v; want %q, %v", tt.header, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestAPIRequiresKey(t *testing.T) {
+	b := newTestBot(newFakeDiscord(), newFakeStore(), clock.NewFake(testNow))
+	for _, path := range []string{
+		"/api/v1/guilds/" + testGuild + "/birthdays/upcoming",
+		"/api/v1/guilds/" + testGuild + "/settings",
+		"/api/v1/guilds/" + testGuild + "/announcements",
+	} {
+		rec := httptest.NewRecorder()
+		b.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusUnauthorized || rec.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("%s without a key: status %d", path, rec.Code)
+		}
+	}
+}
+
+func TestQueryInt(t *testing.T) {
+	tests := []struct {
+		query   string
+		want    int
+		wantErr bool
+	}{
+		{"", 7, false},
+		{"days=30", 30, false},
+		{"days=0", 0, true},
+		{"days=366", 0, true},
+		{"days=soon", 0, true},
+	}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/?"+tt.query, nil)
+		got, err := queryInt(r, "days", apiDefaultUpcomingDays, 1, apiMaxUpcomingDays)
+		if got != tt.want || (err != nil) != tt.wantErr {
+			t.Errorf("queryInt(%q) = %d, %v", tt.query, got, err)
+		}
+	}
+}
+
+func TestNewAPIUpcomingResponse(t *testing.T) {
+	next := time.Date(2026, 6, 20, 0, 0, 0, 0, time.UTC)
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	resp := newAPIUpcomingResponse(testGuild, 7, []upcomingBirthday{
+		{UserID: "100", Month: 6, Day: 20, Year: intPtr(1990), Next: next, DaysAway: 5, AnnouncesAt: time.Date(2026, 6, 20, 9, 0, 0, 0, berlin)},
+		{UserID: "200", Month: 6, Day: 20, Next: next, DaysAway: 5, AnnouncesAt: next},
+	})
+
+	if len(resp.Birthdays) != 2 {
+		t.Fatalf("got %d birthdays", len(resp.Birthdays))
+	}
+	first := resp.Birthdays[0]
+	if first.Age == nil || *first.Age != 36 || first.Date != "2026-06-20" || first.AnnouncesAt.Location() != time.UTC || first.AnnouncesAt.Hour() != 7 {
+		t.Errorf("first birthday: %+v", first)
+	}
+	if resp.Birthdays[1].Age != nil {
+		t.Error("birthday without a year has an age")
+	}
+
+	empty := newAPIUpcomingResponse(testGuild, 7, nil)
+	if empty.Birthdays == nil {
+		t.Error("no birthdays should encode as an empty list, not null")
+	}
+}
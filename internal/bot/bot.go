@@ -4,6 +4,7 @@ import (
 	"log/slog"
 	"sync"
 
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
 	"github.com/Johnnycyan/cyan-birthdays/internal/config"
 	"github.com/Johnnycyan/cyan-birthdays/internal/database"
 	"github.com/bwmarrin/discordgo"
@@ -13,12 +14,26 @@ import (
 // Bot represents the Discord bot
 type Bot struct {
 	session   *discordgo.Session
+	client    DiscordClient
 	config    *config.Config
 	repo      *database.Repository
+	store     birthdayStore
 	pool      *pgxpool.Pool
+	clock     clock.Clock
 	stopCh    chan struct{}
 	loopOnce  sync.Once
 	processMu sync.Mutex
+
+	importsMu      sync.Mutex
+	pendingImports map[string]*pendingImport
+
+	membersMu    sync.Mutex
+	membersCache map[string]cachedGuildMembers // guild ID -> member list for calendars
+
+	metrics  metrics
+	webhooks *webhookDispatcher
+
+	randIntN func(n int) int // picks random messages; nil uses math/rand
 }
 
 // New creates a new Bot instance
@@ -31,12 +46,19 @@ func New(cfg *config.Config, pool *pgxpool.Pool) (*Bot, error) {
 	// Set intents
 	session.Identify.Intents = discordgo.IntentsGuilds | discordgo.IntentsGuildMembers
 
+	repo := database.NewRepository(pool)
+	stopCh := make(chan struct{})
+
 	return &Bot{
-		session: session,
-		config:  cfg,
-		repo:    database.NewRepository(pool),
-		pool:    pool,
-		stopCh:  make(chan struct{}),
+		session:  session,
+		client:   session,
+		config:   cfg,
+		repo:     repo,
+		store:    repo,
+		pool:     pool,
+		clock:    clock.Real{},
+		stopCh:   stopCh,
+		webhooks: newWebhookDispatcher(repo, clock.Real{}, stopCh),
 	}, nil
 }
 
@@ -58,6 +80,11 @@ func (b *Bot) Start() error {
 func (b *Bot) Stop() error {
 	close(b.stopCh)
 
+	// Let in-flight webhook deliveries finish; pending retries are abandoned
+	if b.webhooks != nil {
+		b.webhooks.stop()
+	}
+
 	// Unregister commands
 	if err := b.unregisterCommands(); err != nil {
 		slog.Warn("Failed to unregister commands", "error", err)
@@ -83,6 +110,7 @@ func (b *Bot) handleReady(s *discordgo.Session, r *discordgo.Ready) {
 func (b *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	switch i.Type {
 	case discordgo.InteractionApplicationCommand:
+		b.metrics.commandHandled(commandPath(i.ApplicationCommandData()))
 		b.handleCommand(s, i)
 	case discordgo.InteractionApplicationCommandAutocomplete:
 		b.handleAutocomplete(s, i)
@@ -92,3 +120,18 @@ func (b *Bot) handleInteraction(s *discordgo.Session, i *discordgo.InteractionCr
 		b.handleComponent(s, i)
 	}
 }
+
+// commandPath returns the command name followed by any subcommand group and subcommand, e.g. "bdset channel"
+func commandPath(data discordgo.ApplicationCommandInteractionData) string {
+	path := data.Name
+	options := data.Options
+	for len(options) > 0 {
+		opt := options[0]
+		if opt.Type != discordgo.ApplicationCommandOptionSubCommand && opt.Type != discordgo.ApplicationCommandOptionSubCommandGroup {
+			break
+		}
+		path += " " + opt.Name
+		options = opt.Options
+	}
+	return path
+}
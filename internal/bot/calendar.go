@@ -0,0 +1,264 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+	"github.com/bwmarrin/discordgo"
+	"github.com/jackc/pgx/v5"
+)
+
+// calendarProductID identifies the bot in generated calendars
+const calendarProductID = "-//Cyan Birthdays//Birthday Calendar//EN"
+
+// calendarNoYear is the DTSTART year for birthdays without a year. It is a leap year so Feb 29 exists,
+// and is marked with X-APPLE-OMIT-YEAR so clients don't show an age.
+const calendarNoYear = 2000
+
+// guildMembersPageSize is the maximum number of members Discord returns per request
+const guildMembersPageSize = 1000
+
+// guildMembersCacheTTL is how long a guild's member list is reused for its calendar. Feeds are
+// only polled hourly, so a fresher list wouldn't reach subscribers any sooner.
+const guildMembersCacheTTL = time.Hour
+
+// calendarEntry is a birthday shown in the calendar, with the member's display name
+type calendarEntry struct {
+	database.MemberBirthday
+	Name string
+}
+
+// cachedGuildMembers is a guild's member list and when it was fetched
+type cachedGuildMembers struct {
+	members   map[string]*discordgo.Member
+	fetchedAt time.Time
+}
+
+// guildMembers returns the guild's members, paging through them at most once per guildMembersCacheTTL
+func (b *Bot) guildMembers(guildID string) (map[string]*discordgo.Member, error) {
+	now := b.clock.Now()
+
+	b.membersMu.Lock()
+	cached, ok := b.membersCache[guildID]
+	b.membersMu.Unlock()
+	if ok && now.Sub(cached.fetchedAt) < guildMembersCacheTTL {
+		return cached.members, nil
+	}
+
+	members, err := b.fetchGuildMembers(guildID)
+	if err != nil {
+		return nil, err
+	}
+
+	b.membersMu.Lock()
+	defer b.membersMu.Unlock()
+	if b.membersCache == nil {
+		b.membersCache = make(map[string]cachedGuildMembers)
+	}
+	for key, old := range b.membersCache {
+		if now.Sub(old.fetchedAt) >= guildMembersCacheTTL {
+			delete(b.membersCache, key)
+		}
+	}
+	b.membersCache[guildID] = cachedGuildMembers{members: members, fetchedAt: now}
+	return members, nil
+}
+
+// fetchGuildMembers pages through every member of a guild, keyed by user ID
+func (b *Bot) fetchGuildMembers(guildID string) (map[string]*discordgo.Member, error) {
+	members := make(map[string]*discordgo.Member)
+	after := ""
+	for {
+		page, err := b.client.GuildMembers(guildID, after, guildMembersPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			members[m.User.ID] = m
+		}
+		if len(page) < guildMembersPageSize {
+			return members, nil
+		}
+		after = page[len(page)-1].User.ID
+	}
+}
+
+// calendarEntries keeps birthdays of current members that hold the required role, if one is set
+func calendarEntries(birthdays []database.MemberBirthday, members map[string]*discordgo.Member, requiredRoleID *string) []calendarEntry {
+	var entries []calendarEntry
+	for _, bd := range birthdays {
+		member, ok := members[bd.UserID]
+		if !ok {
+			continue
+		}
+		if requiredRoleID != nil {
+			hasRole := false
+			for _, roleID := range member.Roles {
+				if roleID == *requiredRoleID {
+					hasRole = true
+					break
+				}
+			}
+			if !hasRole {
+				continue
+			}
+		}
+		entries = append(entries, calendarEntry{MemberBirthday: bd, Name: member.DisplayName()})
+	}
+	return entries
+}
+
+// buildGuildCalendar renders the guild's birthday calendar and returns it with the number of birthdays in it
+func (b *Bot) buildGuildCalendar(ctx context.Context, guildID string) ([]byte, int, error) {
+	gs, err := b.repo.GetGuildSettings(ctx, guildID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, 0, err
+		}
+		gs = &database.GuildSettings{}
+	}
+
+	birthdays, err := b.repo.GetCalendarBirthdays(ctx, guildID)
+	if err != nil {
+		return nil, 0, err
+	}
+	members, err := b.guildMembers(guildID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("fetch guild members: %w", err)
+	}
+	entries := calendarEntries(birthdays, members, gs.RequiredRoleID)
+
+	loc := guildFormatSettings(*gs).Locale
+	name := i18n.T(loc, "calendar.name")
+	if guildName := b.guildName(guildID); guildName != "" {
+		name = i18n.T(loc, "calendar.name_guild", guildName)
+	}
+
+	var buf bytes.Buffer
+	if err := writeBirthdayCalendar(&buf, guildID, name, entries, gs.LeapDayPolicy, loc, b.clock.Now()); err != nil {
+		return nil, 0, err
+	}
+	return buf.Bytes(), len(entries), nil
+}
+
+// guildName returns the guild's name from the session state, or "" if it isn't known
+func (b *Bot) guildName(guildID string) string {
+	if b.session == nil || b.session.State == nil {
+		return ""
+	}
+	g, err := b.session.State.Guild(guildID)
+	if err != nil {
+		return ""
+	}
+	return g.Name
+}
+
+// calendarFeedURL returns the subscription URL for a guild's calendar feed, or "" if the HTTP server isn't public
+func (b *Bot) calendarFeedURL(guildID, token string) string {
+	if b.config == nil || b.config.HTTPAddr == "" || b.config.PublicURL == "" {
+		return ""
+	}
+	return fmt.Sprintf("%s/calendar/%s/%s.ics", b.config.PublicURL, guildID, token)
+}
+
+// newCalendarToken generates a secret calendar feed token
+func newCalendarToken() string {
+	buf := make([]byte, 24)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// writeBirthdayCalendar writes an RFC 5545 calendar with a yearly all-day event per birthday, titled in loc
+func writeBirthdayCalendar(w io.Writer, guildID, name string, entries []calendarEntry, leapPolicy string, loc i18n.Locale, now time.Time) error {
+	cw := &icalWriter{w: w}
+	cw.line("BEGIN:VCALENDAR")
+	cw.line("VERSION:2.0")
+	cw.line("PRODID:" + calendarProductID)
+	cw.line("CALSCALE:GREGORIAN")
+	cw.line("METHOD:PUBLISH")
+	cw.line("X-WR-CALNAME:" + escapeICalText(name))
+
+	stamp := now.UTC().Format("20060102T150405Z")
+	for _, e := range entries {
+		hasYear := e.Year != nil && *e.Year > 0
+		year := calendarNoYear
+		if hasYear {
+			year = *e.Year
+		}
+		start := time.Date(year, time.Month(e.Month), e.Day, 0, 0, 0, 0, time.UTC)
+
+		cw.line("BEGIN:VEVENT")
+		cw.line(fmt.Sprintf("UID:%s-%s@cyan-birthdays", guildID, e.UserID))
+		cw.line("DTSTAMP:" + stamp)
+		cw.line("DTSTART;VALUE=DATE:" + start.Format("20060102"))
+		cw.line("DTEND;VALUE=DATE:" + start.AddDate(0, 0, 1).Format("20060102"))
+		cw.line("RRULE:" + calendarRecurrence(e.Month, e.Day, leapPolicy))
+		if !hasYear {
+			cw.line(fmt.Sprintf("X-APPLE-OMIT-YEAR:%d", calendarNoYear))
+		}
+		cw.line("SUMMARY:" + escapeICalText(i18n.T(loc, "calendar.event_summary", e.Name)))
+		cw.line(icalUserIDProperty + ":" + e.UserID)
+		cw.line("TRANSP:TRANSPARENT")
+		cw.line("END:VEVENT")
+	}
+
+	cw.line("END:VCALENDAR")
+	return cw.err
+}
+
+// calendarRecurrence returns the RRULE for a birthday. Feb 29 follows the guild's leap-day policy:
+// the last day of February (feb28), the 60th day of the year (mar1), or only Feb 29 itself (leaponly).
+func calendarRecurrence(month, day int, leapPolicy string) string {
+	if month == 2 && day == 29 {
+		switch leapPolicy {
+		case timezone.LeapDayMar1:
+			return "FREQ=YEARLY;BYYEARDAY=60"
+		case timezone.LeapDayLeapOnly:
+			return "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29"
+		default:
+			return "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1"
+		}
+	}
+	return "FREQ=YEARLY"
+}
+
+// icalWriter writes CRLF terminated content lines, folded at 75 octets, and keeps the first error
+type icalWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (cw *icalWriter) line(s string) {
+	if cw.err != nil {
+		return
+	}
+	var sb strings.Builder
+	width := 0
+	for _, r := range s {
+		size := utf8.RuneLen(r)
+		if width+size > 75 {
+			sb.WriteString("\r\n ")
+			width = 1
+		}
+		sb.WriteRune(r)
+		width += size
+	}
+	sb.WriteString("\r\n")
+	_, cw.err = io.WriteString(cw.w, sb.String())
+}
+
+// escapeICalText applies iCalendar TEXT escaping
+func escapeICalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(s)
+}
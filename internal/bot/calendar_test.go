@@ -0,0 +1,206 @@
+package bot
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+)
+
+func TestWriteBirthdayCalendar(t *testing.T) {
+	entries := []calendarEntry{
+		{MemberBirthday: database.MemberBirthday{UserID: "100000000000000001", Month: 3, Day: 14, Year: intPtr(1990)}, Name: "Alice"},
+		{MemberBirthday: database.MemberBirthday{UserID: "100000000000000002", Month: 2, Day: 29}, Name: "Bob, the; Builder"},
+		// Born in 2000, the year used for birthdays without one
+		{MemberBirthday: database.MemberBirthday{UserID: "100000000000000003", Month: 7, Day: 1, Year: intPtr(2000)}, Name: "Carol"},
+	}
+	now := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+
+	var buf bytes.Buffer
+	if err := writeBirthdayCalendar(&buf, testGuild, "Test Birthdays", entries, timezone.LeapDayMar1, i18n.English, now); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-CALNAME:Test Birthdays\r\n",
+		"UID:" + testGuild + "-100000000000000001@cyan-birthdays\r\n",
+		"DTSTAMP:20260301T123000Z\r\n",
+		"DTSTART;VALUE=DATE:19900314\r\n",
+		"DTEND;VALUE=DATE:19900315\r\n",
+		"DTSTART;VALUE=DATE:20000229\r\n",
+		"DTEND;VALUE=DATE:20000301\r\n",
+		"DTSTART;VALUE=DATE:20000701\r\n",
+		"RRULE:FREQ=YEARLY;BYYEARDAY=60\r\n",
+		"X-APPLE-OMIT-YEAR:2000\r\n",
+		`SUMMARY:🎂 Bob\, the\; Builder's birthday` + "\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("calendar is missing %q", want)
+		}
+	}
+	if strings.Count(out, "X-APPLE-OMIT-YEAR") != 1 {
+		t.Error("only the birthday without a year should omit the year")
+	}
+	if strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Error("calendar contains bare LF line endings")
+	}
+
+	buf.Reset()
+	if err := writeBirthdayCalendar(&buf, testGuild, "Geburtstage", entries[:1], timezone.LeapDayMar1, i18n.German, now); err != nil {
+		t.Fatal(err)
+	}
+	if want := "SUMMARY:🎂 Geburtstag von Alice\r\n"; !strings.Contains(buf.String(), want) {
+		t.Errorf("German calendar is missing %q", want)
+	}
+}
+
+func TestCalendarRecurrence(t *testing.T) {
+	tests := []struct {
+		month, day int
+		policy     string
+		want       string
+	}{
+		{3, 14, timezone.LeapDayMar1, "FREQ=YEARLY"},
+		{2, 28, timezone.LeapDayLeapOnly, "FREQ=YEARLY"},
+		{2, 29, timezone.LeapDayFeb28, "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1"},
+		{2, 29, "", "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=-1"},
+		{2, 29, timezone.LeapDayMar1, "FREQ=YEARLY;BYYEARDAY=60"},
+		{2, 29, timezone.LeapDayLeapOnly, "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29"},
+	}
+	for _, tt := range tests {
+		if got := calendarRecurrence(tt.month, tt.day, tt.policy); got != tt.want {
+			t.Errorf("calendarRecurrence(%d, %d, %q) = %q, want %q", tt.month, tt.day, tt.policy, got, tt.want)
+		}
+	}
+}
+
+func TestICalLineFolding(t *testing.T) {
+	var buf bytes.Buffer
+	cw := &icalWriter{w: &buf}
+	cw.line("SUMMARY:" + strings.Repeat("🎂", 40))
+	if cw.err != nil {
+		t.Fatal(cw.err)
+	}
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	if len(lines) < 3 {
+		t.Fatalf("long line was not folded: %q", buf.String())
+	}
+	for i, l := range lines {
+		if len(l) > 75 {
+			t.Errorf("line %d is %d octets, want at most 75", i, len(l))
+		}
+		if i > 0 && !strings.HasPrefix(l, " ") {
+			t.Errorf("continuation line %d does not start with a space", i)
+		}
+	}
+}
+
+func TestCalendarEntries(t *testing.T) {
+	client := newFakeDiscord()
+	client.addMember(testGuild, "100000000000000001", "alice", "role-member")
+	client.addMember(testGuild, "100000000000000002", "bob")
+	b := &Bot{client: client}
+
+	members, err := b.fetchGuildMembers(testGuild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	birthdays := []database.MemberBirthday{
+		{GuildID: testGuild, UserID: "100000000000000001", Month: 3, Day: 14},
+		{GuildID: testGuild, UserID: "100000000000000002", Month: 4, Day: 1},
+		{GuildID: testGuild, UserID: "100000000000000003", Month: 5, Day: 2}, // left the server
+	}
+
+	entries := calendarEntries(birthdays, members, nil)
+	if len(entries) != 2 || entries[0].Name != "alice" || entries[1].Name != "bob" {
+		t.Errorf("without a required role: %+v", entries)
+	}
+
+	role := "role-member"
+	entries = calendarEntries(birthdays, members, &role)
+	if len(entries) != 1 || entries[0].UserID != "100000000000000001" {
+		t.Errorf("with a required role: %+v", entries)
+	}
+}
+
+func TestFetchGuildMembersPaging(t *testing.T) {
+	client := newFakeDiscord()
+	total := guildMembersPageSize + 5
+	for i := 1; i <= total; i++ {
+		client.addMember(testGuild, fmt.Sprint(100000000000000000+i), "member")
+	}
+	b := &Bot{client: client}
+
+	members, err := b.fetchGuildMembers(testGuild)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != total {
+		t.Errorf("fetched %d members, want %d", len(members), total)
+	}
+}
+
+func TestGuildMembersCache(t *testing.T) {
+	client := newFakeDiscord()
+	client.addMember(testGuild, "100000000000000001", "alice")
+	clk := clock.NewFake(testNow)
+	b := newTestBot(client, newFakeStore(), clk)
+
+	fetch := func() int {
+		t.Helper()
+		members, err := b.guildMembers(testGuild)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(members)
+	}
+
+	fetch()
+	client.addMember(testGuild, "100000000000000002", "bob")
+	clk.Advance(guildMembersCacheTTL - time.Minute)
+	if n := fetch(); n != 1 || client.memberPages != 1 {
+		t.Errorf("got %d members after %d page requests, want the cached list", n, client.memberPages)
+	}
+
+	clk.Advance(time.Minute)
+	if n := fetch(); n != 2 || client.memberPages != 2 {
+		t.Errorf("got %d members after %d page requests, want a fresh list once the cache expires", n, client.memberPages)
+	}
+}
+
+func TestCalendarRoundTripsThroughImporter(t *testing.T) {
+	entries := []calendarEntry{
+		{MemberBirthday: database.MemberBirthday{UserID: "100000000000000001", Month: 3, Day: 14, Year: intPtr(1990)}, Name: "Alice"},
+		{MemberBirthday: database.MemberBirthday{UserID: "100000000000000002", Month: 2, Day: 29}, Name: strings.Repeat("Long name ", 10)},
+	}
+	var buf bytes.Buffer
+	if err := writeBirthdayCalendar(&buf, testGuild, "Birthdays", entries, timezone.LeapDayFeb28, i18n.English, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if !(icalImporter{}).Detect(buf.Bytes()) {
+		t.Fatal("generated calendar is not detected as iCalendar")
+	}
+	plan, err := icalImporter{}.Parse(buf.Bytes(), importContext{GuildID: testGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []database.MemberBirthday{
+		{GuildID: testGuild, UserID: "100000000000000001", Month: 3, Day: 14, Year: intPtr(1990)},
+		{GuildID: testGuild, UserID: "100000000000000002", Month: 2, Day: 29},
+	}
+	if !reflect.DeepEqual(plan.Birthdays, want) || len(plan.Invalid) != 0 {
+		t.Errorf("round trip:\n got: %+v (invalid %+v)\nwant: %+v", plan.Birthdays, plan.Invalid, want)
+	}
+}
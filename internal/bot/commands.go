@@ -2,7 +2,11 @@ package bot
 
 import (
 	"log/slog"
+	"time"
 
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
 	"github.com/bwmarrin/discordgo"
 )
 
@@ -51,6 +55,72 @@ var commands = []*discordgo.ApplicationCommand{
 					},
 				},
 			},
+			{
+				Name:        "calendar",
+				Description: "Get this server's birthdays as a calendar file",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "show_me",
+						Description: "Set whether your birthday appears in the calendar instead",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "notifications",
+				Description: "Choose which birthday DMs the bot sends you",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "greeting",
+						Description: "DM you a birthday greeting when your birthday is announced",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Required:    false,
+					},
+					{
+						Name:        "reminder",
+						Description: "DM you the day before your birthday",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "follow",
+				Description: "Get a DM before another member's birthday",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "user",
+						Description: "The member whose birthday to follow",
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Required:    true,
+					},
+					{
+						Name:        "days",
+						Description: "How many days before their birthday to remind you (default: 1)",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    floatPtr(1),
+						MaxValue:    maxFollowDaysAway,
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "following",
+				Description: "List the birthdays you follow, or unfollow one",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "unfollow",
+						Description: "Stop following this member's birthday",
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Required:    false,
+					},
+				},
+			},
 		},
 	},
 	{
@@ -99,6 +169,14 @@ var commands = []*discordgo.ApplicationCommand{
 						MaxValue:    23,
 						Required:    true,
 					},
+					{
+						Name:        "catchup_hours",
+						Description: "How many hours late a missed announcement may still be sent (0 to disable)",
+						Type:        discordgo.ApplicationCommandOptionInteger,
+						MinValue:    floatPtr(0),
+						MaxValue:    23,
+						Required:    false,
+					},
 				},
 			},
 			{
@@ -108,7 +186,7 @@ var commands = []*discordgo.ApplicationCommand{
 				Options: []*discordgo.ApplicationCommandOption{
 					{
 						Name:        "message",
-						Description: "Message with placeholders: {mention}, {name}, {new_age}",
+						Description: "Message with placeholders such as {mention}, {display_name}, {ordinal_age}, {server}",
 						Type:        discordgo.ApplicationCommandOptionString,
 						Required:    true,
 					},
@@ -121,7 +199,7 @@ var commands = []*discordgo.ApplicationCommand{
 				Options: []*discordgo.ApplicationCommandOption{
 					{
 						Name:        "message",
-						Description: "Message with placeholders: {mention}, {name}",
+						Description: "Message with placeholders such as {mention}, {display_name}, {server}",
 						Type:        discordgo.ApplicationCommandOptionString,
 						Required:    true,
 					},
@@ -234,17 +312,351 @@ var commands = []*discordgo.ApplicationCommand{
 					},
 				},
 			},
+			{
+				Name:        "language",
+				Description: "Set the language of the bot's replies and announcements",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "language",
+						Description: "Language to use",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Automatic (each member's Discord language)", Value: languageAuto},
+							{Name: "English", Value: string(i18n.English)},
+							{Name: "Deutsch", Value: string(i18n.German)},
+							{Name: "Français", Value: string(i18n.French)},
+							{Name: "Español", Value: string(i18n.Spanish)},
+						},
+					},
+				},
+			},
+			{
+				Name:        "leapday",
+				Description: "Choose when Feb 29 birthdays are celebrated in non-leap years",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "policy",
+						Description: "When to celebrate Feb 29 birthdays",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "On February 28", Value: timezone.LeapDayFeb28},
+							{Name: "On March 1", Value: timezone.LeapDayMar1},
+							{Name: "Only in leap years", Value: timezone.LeapDayLeapOnly},
+						},
+					},
+				},
+			},
+			{
+				Name:        "embed",
+				Description: "Design the birthday announcement embed, or switch back to plain text",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "style",
+						Description: "How birthdays are announced",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Plain text", Value: "text"},
+							{Name: "Embed", Value: "embed"},
+						},
+					},
+					{
+						Name:        "show_avatar",
+						Description: "Show the member's avatar in the embed",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "messages",
+				Description: "Manage extra birthday messages picked at random, and the daily and weekly digests",
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "add",
+						Description: "Add a birthday message",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "message",
+								Description: "Message with placeholders such as {mention}, {display_name}, {new_age}, {server}",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Required:    true,
+								MaxLength:   maxGuildMessageLength,
+							},
+							{
+								Name:        "weight",
+								Description: "How often it's picked relative to the others (default 1)",
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								MinValue:    floatPtr(1),
+								MaxValue:    maxGuildMessageWeight,
+								Required:    false,
+							},
+							{
+								Name:        "audience",
+								Description: "Which members it's used for (default: with year if it uses {new_age})",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Required:    false,
+								Choices: []*discordgo.ApplicationCommandOptionChoice{
+									{Name: "Everyone", Value: database.MessageAudienceAny},
+									{Name: "Members with a birth year", Value: database.MessageAudienceWithYear},
+									{Name: "Members without a birth year", Value: database.MessageAudienceWithoutYear},
+								},
+							},
+						},
+					},
+					{
+						Name:        "list",
+						Description: "List this server's birthday messages",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+					},
+					{
+						Name:        "remove",
+						Description: "Remove a birthday message",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "id",
+								Description: "The message's ID, as shown in /bdset messages list",
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								Required:    true,
+							},
+						},
+					},
+					{
+						Name:        "preview",
+						Description: "Preview a birthday message with yourself as the member",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "id",
+								Description: "The message's ID (default: a random one)",
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								Required:    false,
+							},
+						},
+					},
+					{
+						Name:        "digest",
+						Description: "Post one daily digest instead of separate announcements, and a weekly preview",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "mode",
+								Description: "How today's birthdays are announced",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Required:    false,
+								Choices: []*discordgo.ApplicationCommandOptionChoice{
+									{Name: "One message per birthday", Value: database.AnnouncementModeIndividual},
+									{Name: "One daily digest", Value: database.AnnouncementModeDigest},
+								},
+							},
+							{
+								Name:        "weekly_day",
+								Description: "Day to post the birthdays coming up that week",
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								Required:    false,
+								Choices: []*discordgo.ApplicationCommandOptionChoice{
+									{Name: "Off", Value: -1},
+									{Name: "Sunday", Value: int(time.Sunday)},
+									{Name: "Monday", Value: int(time.Monday)},
+									{Name: "Tuesday", Value: int(time.Tuesday)},
+									{Name: "Wednesday", Value: int(time.Wednesday)},
+									{Name: "Thursday", Value: int(time.Thursday)},
+									{Name: "Friday", Value: int(time.Friday)},
+									{Name: "Saturday", Value: int(time.Saturday)},
+								},
+							},
+							{
+								Name:        "weekly_hour",
+								Description: "Hour to post the weekly digest, in the server's default timezone (0-23)",
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								MinValue:    floatPtr(0),
+								MaxValue:    23,
+								Required:    false,
+							},
+						},
+					},
+				},
+			},
+			{
+				Name:        "history",
+				Description: "View recent birthday announcements",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "user",
+						Description: "Only show announcements for this user",
+						Type:        discordgo.ApplicationCommandOptionUser,
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "calendar",
+				Description: "Manage the subscribable calendar feed of server birthdays",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "action",
+						Description: "What to do with the feed",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    true,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "Enable / show link", Value: "enable"},
+							{Name: "Rotate link", Value: "rotate"},
+							{Name: "Disable", Value: "disable"},
+						},
+					},
+				},
+			},
+			{
+				Name:        "export",
+				Description: "Export this server's birthdays and settings as a file",
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "format",
+						Description: "File format (default: JSON)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						Choices: []*discordgo.ApplicationCommandOptionChoice{
+							{Name: "JSON (birthdays and settings)", Value: "json"},
+							{Name: "CSV (birthdays only)", Value: "csv"},
+						},
+					},
+				},
+			},
 			{
 				Name:        "import",
-				Description: "Import birthdays from RedBot Birthday cog JSON file (bot owner only)",
+				Description: "Import birthdays from a file or another birthday bot (bot owner only)",
 				Type:        discordgo.ApplicationCommandOptionSubCommand,
 				Options: []*discordgo.ApplicationCommandOption{
 					{
 						Name:        "file",
-						Description: "The JSON file from the RedBot cog",
+						Description: "A /bdset export, CSV, spreadsheet (TSV), iCalendar (.ics) or RedBot cog file",
 						Type:        discordgo.ApplicationCommandOptionAttachment,
 						Required:    true,
 					},
+					{
+						Name:        "format",
+						Description: "File format (default: detect automatically)",
+						Type:        discordgo.ApplicationCommandOptionString,
+						Required:    false,
+						Choices:     importFormatChoices(),
+					},
+					{
+						Name:        "dry_run",
+						Description: "Preview the changes and confirm before anything is saved",
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Required:    false,
+					},
+				},
+			},
+			{
+				Name:        "webhook",
+				Description: "Manage outgoing webhooks for birthday events",
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "add",
+						Description: "Send birthday events to a URL",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "url",
+								Description: "The https:// URL to POST events to",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Required:    true,
+							},
+						},
+					},
+					{
+						Name:        "remove",
+						Description: "Stop sending events to a webhook",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "id",
+								Description: "The webhook's ID, as shown in /bdset webhook list",
+								Type:        discordgo.ApplicationCommandOptionInteger,
+								Required:    true,
+							},
+						},
+					},
+					{
+						Name:        "list",
+						Description: "List this server's webhooks",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+					},
+					{
+						Name:        "secret",
+						Description: "Show the secret used to sign webhook payloads",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "rotate",
+								Description: "Replace the secret with a new one",
+								Type:        discordgo.ApplicationCommandOptionBoolean,
+								Required:    false,
+							},
+						},
+					},
+					{
+						Name:        "test",
+						Description: "Send a ping event to every webhook",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+					},
+					{
+						Name:        "log",
+						Description: "View recent webhook deliveries",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+					},
+				},
+			},
+			{
+				Name:        "apikey",
+				Description: "Manage API keys for the birthday REST API",
+				Type:        discordgo.ApplicationCommandOptionSubCommandGroup,
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Name:        "create",
+						Description: "Create an API key (shown once)",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "name",
+								Description: "What the key is for, e.g. \"website widget\"",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Required:    true,
+								MaxLength:   100,
+							},
+						},
+					},
+					{
+						Name:        "list",
+						Description: "List this server's API keys",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+					},
+					{
+						Name:        "revoke",
+						Description: "Revoke an API key",
+						Type:        discordgo.ApplicationCommandOptionSubCommand,
+						Options: []*discordgo.ApplicationCommandOption{
+							{
+								Name:        "key_id",
+								Description: "The key's ID, as shown in /bdset apikey list",
+								Type:        discordgo.ApplicationCommandOptionString,
+								Required:    true,
+							},
+						},
+					},
 				},
 			},
 			{
@@ -305,10 +717,61 @@ func floatPtr(f float64) *float64 {
 	return &f
 }
 
+// discordLocales maps each translated locale to the Discord locales that use it. English is the
+// commands' default text, so it isn't listed.
+var discordLocales = map[i18n.Locale][]discordgo.Locale{
+	i18n.German:  {discordgo.German},
+	i18n.French:  {discordgo.French},
+	i18n.Spanish: {discordgo.SpanishES, discordgo.SpanishLATAM},
+}
+
+// commandKey returns the catalog key for the name or description of the command, subcommand or
+// option at path, e.g. "command.birthday.set.timezone.description"
+func commandKey(path, field string) string {
+	return "command." + path + "." + field
+}
+
+// localizeCommands fills in the translated names and descriptions of every command, subcommand and
+// option from the i18n catalogs
+func localizeCommands(cmds []*discordgo.ApplicationCommand) {
+	for _, cmd := range cmds {
+		names, descriptions := commandLocalizations(cmd.Name)
+		cmd.NameLocalizations = &names
+		cmd.DescriptionLocalizations = &descriptions
+		localizeOptions(cmd.Name, cmd.Options)
+	}
+}
+
+func localizeOptions(parent string, opts []*discordgo.ApplicationCommandOption) {
+	for _, opt := range opts {
+		path := parent + "." + opt.Name
+		opt.NameLocalizations, opt.DescriptionLocalizations = commandLocalizations(path)
+		localizeOptions(path, opt.Options)
+	}
+}
+
+func commandLocalizations(path string) (names, descriptions map[discordgo.Locale]string) {
+	names = make(map[discordgo.Locale]string)
+	descriptions = make(map[discordgo.Locale]string)
+	for loc, targets := range discordLocales {
+		for _, target := range targets {
+			if key := commandKey(path, "name"); i18n.Has(loc, key) {
+				names[target] = i18n.T(loc, key)
+			}
+			if key := commandKey(path, "description"); i18n.Has(loc, key) {
+				descriptions[target] = i18n.T(loc, key)
+			}
+		}
+	}
+	return names, descriptions
+}
+
 // registerCommands registers all slash commands globally
 func (b *Bot) registerCommands() error {
 	slog.Info("Registering slash commands...")
 
+	localizeCommands(commands)
+
 	_, err := b.session.ApplicationCommandBulkOverwrite(b.session.State.User.ID, "", commands)
 	if err != nil {
 		return err
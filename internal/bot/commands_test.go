@@ -0,0 +1,120 @@
+package bot
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// commandNamePattern is Discord's rule for command and option names
+var commandNamePattern = regexp.MustCompile(`^[-_\p{L}\p{N}]{1,32}$`)
+
+// commandPaths lists the catalog path of every command, subcommand and option
+func commandPaths() []string {
+	var paths []string
+	var walk func(parent string, opts []*discordgo.ApplicationCommandOption)
+	walk = func(parent string, opts []*discordgo.ApplicationCommandOption) {
+		for _, opt := range opts {
+			path := parent + "." + opt.Name
+			paths = append(paths, path)
+			walk(path, opt.Options)
+		}
+	}
+	for _, cmd := range commands {
+		paths = append(paths, cmd.Name)
+		walk(cmd.Name, cmd.Options)
+	}
+	return paths
+}
+
+func TestCommandsAreTranslated(t *testing.T) {
+	for loc := range discordLocales {
+		for _, path := range commandPaths() {
+			for _, field := range []string{"name", "description"} {
+				if key := commandKey(path, field); !i18n.Has(loc, key) {
+					t.Errorf("%s is missing %q", loc, key)
+				}
+			}
+		}
+	}
+}
+
+func TestLocalizedCommandsAreValid(t *testing.T) {
+	localizeCommands(commands)
+
+	check := func(path string, names, descriptions map[discordgo.Locale]string) {
+		for target, name := range names {
+			if !commandNamePattern.MatchString(name) || name != strings.ToLower(name) {
+				t.Errorf("%s name for %s is invalid: %q", target, path, name)
+			}
+		}
+		for target, description := range descriptions {
+			if n := utf8.RuneCountInString(description); n == 0 || n > 100 {
+				t.Errorf("%s description for %s is %d characters", target, path, n)
+			}
+		}
+	}
+
+	// Discord rejects siblings that share a name in any locale
+	unique := func(parent string, opts []*discordgo.ApplicationCommandOption) {
+		seen := make(map[discordgo.Locale]map[string]bool)
+		for _, opt := range opts {
+			for target, name := range opt.NameLocalizations {
+				if seen[target] == nil {
+					seen[target] = make(map[string]bool)
+				}
+				if seen[target][name] {
+					t.Errorf("%s has two %s options named %q", parent, target, name)
+				}
+				seen[target][name] = true
+			}
+		}
+	}
+
+	var walk func(parent string, opts []*discordgo.ApplicationCommandOption)
+	walk = func(parent string, opts []*discordgo.ApplicationCommandOption) {
+		unique(parent, opts)
+		for _, opt := range opts {
+			path := parent + "." + opt.Name
+			check(path, opt.NameLocalizations, opt.DescriptionLocalizations)
+			walk(path, opt.Options)
+		}
+	}
+	for _, cmd := range commands {
+		check(cmd.Name, *cmd.NameLocalizations, *cmd.DescriptionLocalizations)
+		walk(cmd.Name, cmd.Options)
+	}
+
+	set := commands[0].Options[0]
+	if got := set.NameLocalizations[discordgo.German]; got != "festlegen" {
+		t.Errorf("German name of /birthday set = %q, want festlegen", got)
+	}
+	if set.DescriptionLocalizations[discordgo.SpanishLATAM] != set.DescriptionLocalizations[discordgo.SpanishES] {
+		t.Error("both Spanish locales should get the Spanish description")
+	}
+}
+
+// maxCommandOptions is the most options or choices Discord allows at any level of a command
+const maxCommandOptions = 25
+
+func TestCommandsFitDiscordLimits(t *testing.T) {
+	var walk func(path string, opts []*discordgo.ApplicationCommandOption)
+	walk = func(path string, opts []*discordgo.ApplicationCommandOption) {
+		if len(opts) > maxCommandOptions {
+			t.Errorf("%s has %d options, Discord allows %d", path, len(opts), maxCommandOptions)
+		}
+		for _, opt := range opts {
+			if len(opt.Choices) > maxCommandOptions {
+				t.Errorf("%s.%s has %d choices, Discord allows %d", path, opt.Name, len(opt.Choices), maxCommandOptions)
+			}
+			walk(path+"."+opt.Name, opt.Options)
+		}
+	}
+	for _, cmd := range commands {
+		walk(cmd.Name, cmd.Options)
+	}
+}
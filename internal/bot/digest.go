@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+	"github.com/bwmarrin/discordgo"
+)
+
+// digestKindWeekly identifies weekly "coming up" posts in the digest history
+const digestKindWeekly = "weekly"
+
+// weeklyDigestDays is how far ahead the weekly digest looks, today included
+const weeklyDigestDays = 6
+
+// digestCelebrant is a member included in a daily digest
+type digestCelebrant struct {
+	birthday database.MemberBirthday
+	age      *int
+}
+
+// guildTimezone returns the guild's default timezone, falling back to UTC
+func guildTimezone(gs database.GuildSettings) string {
+	if _, err := time.LoadLocation(gs.DefaultTimezone); err != nil || gs.DefaultTimezone == "" {
+		return "UTC"
+	}
+	return gs.DefaultTimezone
+}
+
+// processDailyDigest posts one message for all of today's birthdays in a digest-mode guild. Days are
+// counted in the guild's default timezone and the digest is posted at the guild's announcement hour.
+func (b *Bot) processDailyDigest(ctx context.Context, gs database.GuildSettings, now time.Time, lastRun *time.Time) {
+	postAt, err := timezone.AnnouncementTime(now, gs.TimeUTC, guildTimezone(gs))
+	if err != nil {
+		slog.Warn("Failed to check digest time", "guild_id", gs.GuildID, "error", err)
+		return
+	}
+	due, late := isAnnouncementDue(postAt, now, lastRun, gs.CatchupHours)
+	if !due {
+		return
+	}
+
+	birthdays, err := b.store.GetAllGuildBirthdays(ctx, gs.GuildID)
+	if err != nil {
+		slog.Error("Failed to get birthdays for digest", "guild_id", gs.GuildID, "error", err)
+		return
+	}
+
+	birthdayYear := postAt.Year()
+	expiresAt := postAt.Add(24 * time.Hour).UTC()
+	var celebrants []digestCelebrant
+	for _, bd := range birthdays {
+		m, d, ok := timezone.ObservedBirthday(bd.Month, bd.Day, birthdayYear, gs.LeapDayPolicy)
+		if !ok || m != int(postAt.Month()) || d != postAt.Day() {
+			continue
+		}
+
+		announced, err := b.store.HasAnnouncement(ctx, gs.GuildID, bd.UserID, birthdayYear)
+		if err != nil {
+			slog.Warn("Failed to check announcement history, skipping", "user_id", bd.UserID, "error", err)
+			continue
+		}
+		if announced {
+			continue
+		}
+
+		if _, ok := b.eligibleMember(gs, bd.UserID); !ok {
+			continue
+		}
+
+		c := digestCelebrant{birthday: bd}
+		if bd.Year != nil && *bd.Year > 0 {
+			age := birthdayYear - *bd.Year
+			c.age = &age
+		}
+		celebrants = append(celebrants, c)
+	}
+	if len(celebrants) == 0 {
+		return
+	}
+
+	allowedMentions := &discordgo.MessageAllowedMentions{}
+	for _, c := range celebrants {
+		allowedMentions.Users = append(allowedMentions.Users, c.birthday.UserID)
+	}
+	if gs.AllowRoleMention {
+		allowedMentions.Parse = []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeRoles}
+	}
+
+	sent, err := b.client.ChannelMessageSendComplex(*gs.ChannelID, &discordgo.MessageSend{
+		Content:         formatDailyDigest(guildFormatSettings(gs).Locale, celebrants),
+		AllowedMentions: allowedMentions,
+	})
+	if err != nil {
+		slog.Error("Failed to send birthday digest", "guild_id", gs.GuildID, "channel_id", *gs.ChannelID, "error", err)
+		return
+	}
+	slog.Info("Sent birthday digest", "guild_id", gs.GuildID, "birthdays", len(celebrants), "late", late)
+
+	for _, c := range celebrants {
+		if gs.RoleID != nil {
+			b.addBirthdayRole(ctx, gs, c.birthday.UserID, expiresAt)
+		}
+		b.birthdayAnnounced(ctx, gs, c.birthday, birthdayYear, sent.ID, nil, expiresAt, late, now)
+	}
+}
+
+// formatDailyDigest lists everyone celebrating today in one message
+func formatDailyDigest(loc i18n.Locale, celebrants []digestCelebrant) string {
+	var sb strings.Builder
+	sb.WriteString(i18n.T(loc, "digest.daily_title") + "\n")
+	for _, c := range celebrants {
+		if c.age != nil {
+			sb.WriteString(i18n.T(loc, "digest.daily_line_age", c.birthday.UserID, *c.age) + "\n")
+		} else {
+			sb.WriteString(i18n.T(loc, "digest.daily_line", c.birthday.UserID) + "\n")
+		}
+	}
+	if len(celebrants) == 1 {
+		sb.WriteString(i18n.T(loc, "digest.daily_closing_one"))
+	} else {
+		sb.WriteString(i18n.T(loc, "digest.daily_closing_many"))
+	}
+	return sb.String()
+}
+
+// processWeeklyDigest posts the week's upcoming birthdays on the guild's configured weekday and hour,
+// in the guild's default timezone
+func (b *Bot) processWeeklyDigest(ctx context.Context, gs database.GuildSettings, now time.Time, lastRun *time.Time) {
+	if gs.WeeklyDigestDay == nil {
+		return
+	}
+
+	postAt, err := timezone.AnnouncementTime(now, gs.WeeklyDigestHour, guildTimezone(gs))
+	if err != nil || int(postAt.Weekday()) != *gs.WeeklyDigestDay {
+		return
+	}
+	if due, _ := isAnnouncementDue(postAt, now, lastRun, gs.CatchupHours); !due {
+		return
+	}
+
+	period := time.Date(postAt.Year(), postAt.Month(), postAt.Day(), 0, 0, 0, 0, time.UTC)
+	posted, err := b.store.HasDigestPost(ctx, gs.GuildID, digestKindWeekly, period)
+	if err != nil {
+		slog.Warn("Failed to check digest history, skipping", "guild_id", gs.GuildID, "error", err)
+		return
+	}
+	if posted {
+		return
+	}
+
+	birthdays, err := b.store.GetAllGuildBirthdays(ctx, gs.GuildID)
+	if err != nil {
+		slog.Error("Failed to get birthdays for weekly digest", "guild_id", gs.GuildID, "error", err)
+		return
+	}
+	upcoming := computeUpcoming(birthdays, &gs, now, weeklyDigestDays, func(userID string) bool {
+		_, ok := b.eligibleMember(gs, userID)
+		return ok
+	})
+
+	post := &database.DigestPost{
+		GuildID:   gs.GuildID,
+		Kind:      digestKindWeekly,
+		Period:    period,
+		ChannelID: *gs.ChannelID,
+	}
+	if len(upcoming) > 0 {
+		loc := guildFormatSettings(gs).Locale
+		sent, err := b.client.ChannelMessageSendComplex(*gs.ChannelID, &discordgo.MessageSend{
+			Embeds: []*discordgo.MessageEmbed{{
+				Title:  i18n.T(loc, "digest.weekly_title"),
+				Color:  0x00D9FF, // Cyan
+				Fields: upcomingEmbedFields(upcoming, loc),
+			}},
+			AllowedMentions: &discordgo.MessageAllowedMentions{},
+		})
+		if err != nil {
+			slog.Error("Failed to send weekly birthday digest", "guild_id", gs.GuildID, "channel_id", *gs.ChannelID, "error", err)
+			return
+		}
+		post.MessageID = &sent.ID
+		slog.Info("Sent weekly birthday digest", "guild_id", gs.GuildID, "birthdays", len(upcoming))
+	}
+
+	post.PostedAt = now
+	if err := b.store.RecordDigestPost(ctx, post); err != nil {
+		slog.Error("Failed to record weekly digest", "guild_id", gs.GuildID, "error", err)
+	}
+}
@@ -0,0 +1,176 @@
+package bot
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+)
+
+func TestDailyDigest(t *testing.T) {
+	now := testNow
+	tomorrow := now.AddDate(0, 0, 1)
+	gs := testGuildSettings(now.Hour())
+	gs.AnnouncementMode = database.AnnouncementModeDigest
+
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = gs
+	store.birthdays[testGuild] = []database.MemberBirthday{
+		{GuildID: testGuild, UserID: "alice", Month: int(now.Month()), Day: now.Day(), Year: intPtr(now.Year() - 25), Timezone: "UTC"},
+		// Counted in the server's timezone, not the member's own
+		{GuildID: testGuild, UserID: "bob", Month: int(now.Month()), Day: now.Day(), Timezone: "Pacific/Honolulu"},
+		{GuildID: testGuild, UserID: "carol", Month: int(tomorrow.Month()), Day: tomorrow.Day(), Timezone: "UTC"},
+		{GuildID: testGuild, UserID: "gone", Month: int(now.Month()), Day: now.Day(), Timezone: "UTC"},
+	}
+	for _, user := range []string{"alice", "bob", "carol"} {
+		client.addMember(testGuild, user, user)
+	}
+
+	clk := clock.NewFake(now)
+	b := newTestBot(client, store, clk)
+	b.processBirthdays()
+	clk.Advance(10 * time.Minute)
+	b.processBirthdays()
+
+	if len(client.messages) != 1 {
+		t.Fatalf("sent %d messages, want one digest", len(client.messages))
+	}
+	msg := client.messages[0].Message
+	for _, want := range []string{"<@alice> turns 25", "<@bob>", "all of you"} {
+		if !strings.Contains(msg.Content, want) {
+			t.Errorf("digest %q missing %q", msg.Content, want)
+		}
+	}
+	if strings.Contains(msg.Content, "carol") || strings.Contains(msg.Content, "gone") {
+		t.Errorf("digest includes members it shouldn't: %q", msg.Content)
+	}
+	if !slices.Equal(msg.AllowedMentions.Users, []string{"alice", "bob"}) {
+		t.Errorf("allowed mentions = %v", msg.AllowedMentions.Users)
+	}
+
+	if len(client.rolesAdded) != 2 || len(store.announcements) != 2 {
+		t.Fatalf("roles added = %d, announcements = %d; want 2 each", len(client.rolesAdded), len(store.announcements))
+	}
+	for _, a := range store.announcements {
+		if a.MessageID == nil || *a.MessageID != "msg1" {
+			t.Errorf("announcement %+v doesn't point at the digest", a)
+		}
+	}
+	if expires := store.activeRoles[[2]string{testGuild, "bob"}].RoleExpiresAt; !expires.Equal(now.Add(24 * time.Hour)) {
+		t.Errorf("role expires at %v, want %v", expires, now.Add(24*time.Hour))
+	}
+}
+
+func TestDigestsWithoutBirthdayRole(t *testing.T) {
+	now := testNow // a Monday
+	gs := testGuildSettings(now.Hour())
+	gs.RoleID = nil
+	gs.AnnouncementMode = database.AnnouncementModeDigest
+	gs.WeeklyDigestDay = intPtr(int(now.Weekday()))
+	gs.WeeklyDigestHour = now.Hour()
+
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = gs
+	store.birthdays[testGuild] = []database.MemberBirthday{
+		{GuildID: testGuild, UserID: "alice", Month: int(now.Month()), Day: now.Day(), Timezone: "UTC"},
+	}
+	client.addMember(testGuild, "alice", "alice")
+
+	newTestBot(client, store, clock.NewFake(now)).processBirthdays()
+
+	if len(client.messages) != 2 {
+		t.Fatalf("sent %d messages, want the daily and the weekly digest", len(client.messages))
+	}
+	if len(client.rolesAdded) != 0 || len(store.announcements) != 1 {
+		t.Errorf("roles added = %d, announcements = %d; want no roles and 1 announcement", len(client.rolesAdded), len(store.announcements))
+	}
+}
+
+func TestWeeklyDigest(t *testing.T) {
+	now := testNow // a Monday
+	gs := testGuildSettings(now.Hour())
+	gs.WeeklyDigestDay = intPtr(int(now.Weekday()))
+	gs.WeeklyDigestHour = 10
+
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = gs
+	soon, later := now.AddDate(0, 0, 2), now.AddDate(0, 0, 10)
+	store.birthdays[testGuild] = []database.MemberBirthday{
+		{GuildID: testGuild, UserID: "soon", Month: int(soon.Month()), Day: soon.Day(), Timezone: "UTC"},
+		{GuildID: testGuild, UserID: "later", Month: int(later.Month()), Day: later.Day(), Timezone: "UTC"},
+	}
+	client.addMember(testGuild, "soon", "soon")
+	client.addMember(testGuild, "later", "later")
+
+	clk := clock.NewFake(now)
+	b := newTestBot(client, store, clk)
+	for range 4 {
+		b.processBirthdays()
+		if len(client.messages) > 0 && clk.Now().Hour() < gs.WeeklyDigestHour {
+			t.Fatalf("weekly digest posted early at %s", clk.Now())
+		}
+		clk.Advance(time.Hour)
+	}
+
+	if len(client.messages) != 1 || len(client.messages[0].Message.Embeds) != 1 {
+		t.Fatalf("sent %d messages, want one weekly digest", len(client.messages))
+	}
+	fields := client.messages[0].Message.Embeds[0].Fields
+	if len(fields) != 1 || fields[0].Name != "In 2 days" || !strings.Contains(fields[0].Value, "<@soon>") {
+		t.Errorf("fields = %+v", fields)
+	}
+	if len(store.digests) != 1 || store.digests[0].MessageID == nil || store.digests[0].Period.Weekday() != time.Monday {
+		t.Errorf("digests = %+v", store.digests)
+	}
+
+	// Nothing is posted on other days
+	clk.Set(now.AddDate(0, 0, 1))
+	b.processBirthdays()
+	if len(client.messages) != 1 {
+		t.Errorf("weekly digest posted on the wrong day")
+	}
+}
+
+func TestUpcomingEmbedFields(t *testing.T) {
+	next := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+	fields := upcomingEmbedFields([]upcomingBirthday{
+		{UserID: "a", DaysAway: 0, Next: next, AnnouncesAt: next},
+		{UserID: "b", DaysAway: 1, Year: intPtr(2000), Next: next.AddDate(0, 0, 1), AnnouncesAt: next},
+		{UserID: "c", DaysAway: 1, Next: next.AddDate(0, 0, 1), AnnouncesAt: next},
+		{UserID: "d", DaysAway: 5, Next: next.AddDate(0, 0, 5), AnnouncesAt: next},
+	}, i18n.English)
+
+	var names []string
+	for _, f := range fields {
+		names = append(names, f.Name)
+	}
+	if !slices.Equal(names, []string{"Today!", "Tomorrow", "In 5 days"}) {
+		t.Fatalf("field names = %v", names)
+	}
+	if want := "<@b> (turning 26) - <t:1781481600:t>\n<@c> - <t:1781481600:t>"; fields[1].Value != want {
+		t.Errorf("tomorrow = %q, want %q", fields[1].Value, want)
+	}
+
+	fields = upcomingEmbedFields([]upcomingBirthday{{UserID: "a", DaysAway: 1, Next: next, AnnouncesAt: next}}, i18n.German)
+	if fields[0].Name != "Morgen" {
+		t.Errorf("German field name = %q, want Morgen", fields[0].Name)
+	}
+}
+
+func TestFormatDailyDigestLocalized(t *testing.T) {
+	celebrants := []digestCelebrant{
+		{birthday: database.MemberBirthday{UserID: "alice"}, age: intPtr(25)},
+		{birthday: database.MemberBirthday{UserID: "bob"}},
+	}
+	want := "🎉 **Anniversaires du jour** 🎉\n🎂 <@alice> fête ses 25 ans\n🎂 <@bob>\nJoyeux anniversaire à vous tous !"
+	if got := formatDailyDigest(i18n.French, celebrants); got != want {
+		t.Errorf("digest = %q, want %q", got, want)
+	}
+}
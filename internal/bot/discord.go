@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/bwmarrin/discordgo"
+)
+
+// DiscordClient is the subset of the Discord REST API used by the birthday loop and calendar.
+// *discordgo.Session satisfies it; tests substitute an in-memory fake.
+type DiscordClient interface {
+	GuildMember(guildID, userID string, options ...discordgo.RequestOption) (*discordgo.Member, error)
+	GuildMembers(guildID, after string, limit int, options ...discordgo.RequestOption) ([]*discordgo.Member, error)
+	GuildMemberRoleAdd(guildID, userID, roleID string, options ...discordgo.RequestOption) error
+	GuildMemberRoleRemove(guildID, userID, roleID string, options ...discordgo.RequestOption) error
+	ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, options ...discordgo.RequestOption) (*discordgo.Message, error)
+	UserChannelCreate(recipientID string, options ...discordgo.RequestOption) (*discordgo.Channel, error)
+}
+
+// birthdayStore is the subset of the repository used by the birthday loop
+type birthdayStore interface {
+	GetGuildSettings(ctx context.Context, guildID string) (*database.GuildSettings, error)
+	GetAllSetupGuilds(ctx context.Context) ([]database.GuildSettings, error)
+	GetBirthdayCandidates(ctx context.Context, now time.Time) ([]database.MemberBirthday, error)
+	SetActiveBirthdayRole(ctx context.Context, guildID, userID string, expiresAt time.Time) error
+	GetExpiredBirthdayRoles(ctx context.Context, now time.Time) ([]database.ActiveBirthdayRole, error)
+	DeleteActiveBirthdayRole(ctx context.Context, guildID, userID string) error
+	HasAnnouncement(ctx context.Context, guildID, userID string, year int) (bool, error)
+	RecordAnnouncement(ctx context.Context, a *database.Announcement) error
+	GetLastProcessedAt(ctx context.Context) (*time.Time, error)
+	SetLastProcessedAt(ctx context.Context, t time.Time) error
+	HasDMNotification(ctx context.Context, guildID, userID, recipientID, kind string, year int) (bool, error)
+	RecordDMNotification(ctx context.Context, n *database.DMNotification) error
+	GetBirthdayFollows(ctx context.Context) ([]database.BirthdayFollow, error)
+	GetAllGuildBirthdays(ctx context.Context, guildID string) ([]database.MemberBirthday, error)
+	HasDigestPost(ctx context.Context, guildID, kind string, period time.Time) (bool, error)
+	RecordDigestPost(ctx context.Context, d *database.DigestPost) error
+	GetAnnouncementEmbed(ctx context.Context, guildID string) (*database.AnnouncementEmbed, error)
+	GetGuildMessages(ctx context.Context, guildID string) ([]database.GuildMessage, error)
+	GetLastAnnouncementTemplate(ctx context.Context, guildID, userID string) (*int64, error)
+}
+
+var (
+	_ DiscordClient = (*discordgo.Session)(nil)
+	_ birthdayStore = (*database.Repository)(nil)
+)
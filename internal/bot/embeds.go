@@ -0,0 +1,294 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// defaultEmbedColor is used when a guild hasn't picked an embed color
+const defaultEmbedColor = 0x00D9FF // Cyan
+
+// Custom IDs for the embed designer
+const (
+	embedModalID      = "bdset_embed_modal"
+	embedEditButtonID = "bdset_embed_edit"
+)
+
+// Embed length limits enforced by the designer modal
+const (
+	maxEmbedTitle       = 256
+	maxEmbedDescription = 2000
+	maxEmbedFooter      = 256
+	maxEmbedImageURL    = 512
+)
+
+// defaultAnnouncementEmbed is the design offered to guilds that haven't configured one
+func defaultAnnouncementEmbed(guildID string) *database.AnnouncementEmbed {
+	return &database.AnnouncementEmbed{
+		GuildID:    guildID,
+		Title:      "🎉 Happy Birthday, {name}!",
+		ShowAvatar: true,
+	}
+}
+
+// announcementEmbed returns the guild's embed design if embed announcements are enabled, or nil to
+// announce in plain text
+func (b *Bot) announcementEmbed(ctx context.Context, guildID string) *database.AnnouncementEmbed {
+	e, err := b.store.GetAnnouncementEmbed(ctx, guildID)
+	if err != nil {
+		slog.Warn("Failed to get announcement embed, using plain text", "guild_id", guildID, "error", err)
+		return nil
+	}
+	if e == nil || !e.Enabled {
+		return nil
+	}
+	return e
+}
+
+// guildAnnouncementEmbed returns the guild's saved embed design, or the default design if it has none
+func (b *Bot) guildAnnouncementEmbed(ctx context.Context, guildID string) (*database.AnnouncementEmbed, error) {
+	e, err := b.repo.GetAnnouncementEmbed(ctx, guildID)
+	if err != nil {
+		return nil, err
+	}
+	if e == nil {
+		e = defaultAnnouncementEmbed(guildID)
+	}
+	return e, nil
+}
+
+// newAnnouncementMessage builds a member's birthday announcement from a message template, as an
+// embed when e is non-nil
+func newAnnouncementMessage(gs database.GuildSettings, template string, e *database.AnnouncementEmbed, member *discordgo.Member, data templateData) *discordgo.MessageSend {
+	userID := member.User.ID
+	message := renderTemplate(template, data)
+
+	allowedMentions := &discordgo.MessageAllowedMentions{
+		Users: []string{userID},
+	}
+	if gs.AllowRoleMention {
+		allowedMentions.Parse = []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeRoles}
+	}
+
+	send := &discordgo.MessageSend{
+		Content:         message,
+		AllowedMentions: allowedMentions,
+	}
+	if e != nil {
+		// Mentions inside embeds don't notify, so the member is pinged in the message itself
+		send.Content = "<@" + userID + ">"
+		send.Embeds = []*discordgo.MessageEmbed{buildAnnouncementEmbed(e, message, member, data)}
+	}
+	return send
+}
+
+// buildAnnouncementEmbed renders an embed design for a member. message is the guild's birthday
+// message, used when the design has no description of its own.
+func buildAnnouncementEmbed(e *database.AnnouncementEmbed, message string, member *discordgo.Member, data templateData) *discordgo.MessageEmbed {
+	format := func(template string) string {
+		return renderTemplate(template, data)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       format(e.Title),
+		Description: message,
+		Color:       defaultEmbedColor,
+	}
+	if e.Description != "" {
+		embed.Description = format(e.Description)
+	}
+	if e.Color != nil {
+		embed.Color = *e.Color
+	}
+	if e.ShowAvatar {
+		embed.Thumbnail = &discordgo.MessageEmbedThumbnail{URL: member.AvatarURL("256")}
+	}
+	if e.ImageURL != "" {
+		embed.Image = &discordgo.MessageEmbedImage{URL: e.ImageURL}
+	}
+	if e.Footer != "" {
+		embed.Footer = &discordgo.MessageEmbedFooter{Text: format(e.Footer)}
+	}
+	return embed
+}
+
+// parseEmbedColor parses a hex color like #FF66AA. An empty string means the default color.
+func parseEmbedColor(s string) (*int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	hex := strings.TrimPrefix(strings.TrimPrefix(strings.ToLower(s), "#"), "0x")
+	if len(hex) != 6 {
+		return nil, fmt.Errorf("%q is not a hex color like #FF66AA", s)
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return nil, fmt.Errorf("%q is not a hex color like #FF66AA", s)
+	}
+	color := int(v)
+	return &color, nil
+}
+
+// formatEmbedColor formats a color for the designer, or "" for the default
+func formatEmbedColor(color *int) string {
+	if color == nil {
+		return ""
+	}
+	return fmt.Sprintf("#%06X", *color)
+}
+
+// embedDesignerModal returns the modal for editing an embed design, filled in with its current values
+func embedDesignerModal(loc i18n.Locale, e *database.AnnouncementEmbed) *discordgo.InteractionResponse {
+	input := func(id, label, value, placeholder string, style discordgo.TextInputStyle, maxLength int) discordgo.MessageComponent {
+		return discordgo.ActionsRow{
+			Components: []discordgo.MessageComponent{
+				discordgo.TextInput{
+					CustomID:    id,
+					Label:       label,
+					Style:       style,
+					Value:       value,
+					Placeholder: placeholder,
+					Required:    false,
+					MaxLength:   maxLength,
+				},
+			},
+		}
+	}
+
+	return &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseModal,
+		Data: &discordgo.InteractionResponseData{
+			CustomID: embedModalID,
+			Title:    i18n.T(loc, "embed.designer_title"),
+			Components: []discordgo.MessageComponent{
+				input("title", i18n.T(loc, "embed.field_title"), e.Title, i18n.T(loc, "embed.example_title"), discordgo.TextInputShort, maxEmbedTitle),
+				input("description", i18n.T(loc, "embed.field_description"), e.Description, i18n.T(loc, "embed.example_description"), discordgo.TextInputParagraph, maxEmbedDescription),
+				input("color", i18n.T(loc, "embed.field_color"), formatEmbedColor(e.Color), "#00D9FF", discordgo.TextInputShort, 8),
+				input("image_url", i18n.T(loc, "embed.field_image"), e.ImageURL, "https://example.com/cake.gif", discordgo.TextInputShort, maxEmbedImageURL),
+				input("footer", i18n.T(loc, "embed.field_footer"), e.Footer, i18n.T(loc, "embed.example_footer"), discordgo.TextInputShort, maxEmbedFooter),
+			},
+		},
+	}
+}
+
+// parseEmbedModal applies a submitted designer modal to an embed design
+func parseEmbedModal(data discordgo.ModalSubmitInteractionData, e *database.AnnouncementEmbed) error {
+	values := make(map[string]string)
+	for _, comp := range data.Components {
+		row := comp.(*discordgo.ActionsRow)
+		for _, c := range row.Components {
+			input := c.(*discordgo.TextInput)
+			values[input.CustomID] = strings.TrimSpace(input.Value)
+		}
+	}
+
+	for _, field := range []string{"title", "description", "footer"} {
+		if err := validateTemplate(values[field], true); err != nil {
+			return fmt.Errorf("invalid %s: %w", field, err)
+		}
+	}
+	color, err := parseEmbedColor(values["color"])
+	if err != nil {
+		return err
+	}
+	if imageURL := values["image_url"]; imageURL != "" {
+		if err := validateImageURL(imageURL); err != nil {
+			return err
+		}
+	}
+	e.Title = values["title"]
+	e.Description = values["description"]
+	e.Color = color
+	e.ImageURL = values["image_url"]
+	e.Footer = values["footer"]
+	return nil
+}
+
+// validateImageURL checks that an embed image is an http(s) URL
+func validateImageURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+		return errors.New("the image URL must start with https:// or http://")
+	}
+	return nil
+}
+
+// embedPreview returns the designer's preview reply, rendering the design with the admin as the
+// birthday member
+func embedPreview(loc i18n.Locale, gs database.GuildSettings, e *database.AnnouncementEmbed, member *discordgo.Member, data templateData, note string) *discordgo.InteractionResponseData {
+	preview := *member
+	preview.GuildID = gs.GuildID // interaction members don't carry it, but guild avatars need it
+	msg := newAnnouncementMessage(gs, defaultMessage(gs, data.Age), e, &preview, data)
+
+	content := note + "\n"
+	if e.Enabled {
+		content += i18n.T(loc, "embed.preview_enabled")
+	} else {
+		content += i18n.T(loc, "embed.preview_disabled")
+	}
+	return &discordgo.InteractionResponseData{
+		Content: content,
+		Embeds:  msg.Embeds,
+		Components: []discordgo.MessageComponent{
+			discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{
+					discordgo.Button{
+						Label:    i18n.T(loc, "button.edit"),
+						Style:    discordgo.SecondaryButton,
+						CustomID: embedEditButtonID,
+						Emoji:    &discordgo.ComponentEmoji{Name: "✏️"},
+					},
+				},
+			},
+		},
+		Flags: discordgo.MessageFlagsEphemeral,
+	}
+}
+
+// handleEmbedModal saves a submitted embed design and switches the guild to embed announcements
+func (b *Bot) handleEmbedModal(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+	e, err := b.guildAnnouncementEmbed(ctx, i.GuildID)
+	if err != nil {
+		respondError(s, i, i18n.T(loc, "embed.get_failed"))
+		return
+	}
+	if err := parseEmbedModal(i.ModalSubmitData(), e); err != nil {
+		respondError(s, i, err.Error())
+		return
+	}
+	e.Enabled = true
+
+	if err := b.repo.SetAnnouncementEmbed(ctx, e); err != nil {
+		respondError(s, i, i18n.T(loc, "embed.save_failed"))
+		return
+	}
+	b.respondEmbedPreview(s, i, e, i18n.T(loc, "embed.saved"))
+}
+
+// respondEmbedPreview replies with a preview of the embed design, rendered with the guild's messages
+func (b *Bot) respondEmbedPreview(s *discordgo.Session, i *discordgo.InteractionCreate, e *database.AnnouncementEmbed, note string) {
+	gs, err := b.repo.GetGuildSettings(context.Background(), i.GuildID)
+	if err != nil {
+		gs = &database.GuildSettings{
+			GuildID:            i.GuildID,
+			MessageWithYear:    "{mention} has turned {new_age}, happy birthday!",
+			MessageWithoutYear: "Happy birthday {mention}!",
+		}
+	}
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: embedPreview(b.interactionLocale(i), *gs, e, i.Member, b.previewTemplateData(*gs, i.Member, intPtr(25)), note),
+	})
+}
@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestParseEmbedColor(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		isNil   bool
+		wantErr bool
+	}{
+		{in: "", isNil: true},
+		{in: "#FF66AA", want: 0xFF66AA},
+		{in: "ff66aa", want: 0xFF66AA},
+		{in: "0x00d9ff", want: 0x00D9FF},
+		{in: "#FFF", wantErr: true},
+		{in: "#GGGGGG", wantErr: true},
+		{in: "pink", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseEmbedColor(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseEmbedColor(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if tt.wantErr {
+			continue
+		}
+		if (got == nil) != tt.isNil || (got != nil && *got != tt.want) {
+			t.Errorf("parseEmbedColor(%q) = %v, want %#x", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestBuildAnnouncementEmbed(t *testing.T) {
+	member := &discordgo.Member{GuildID: testGuild, User: &discordgo.User{ID: testUser, Username: "alice", Avatar: "abc"}}
+
+	e := defaultAnnouncementEmbed(testGuild)
+	e.Footer = "{name} is {new_age} today"
+	embed := buildAnnouncementEmbed(e, "Happy birthday <@user1>!", member, templateData{UserID: testUser, Name: "alice", Age: intPtr(30)})
+	if embed.Title != "🎉 Happy Birthday, alice!" || embed.Description != "Happy birthday <@user1>!" {
+		t.Errorf("title = %q, description = %q", embed.Title, embed.Description)
+	}
+	if embed.Color != defaultEmbedColor || embed.Thumbnail == nil || embed.Image != nil {
+		t.Errorf("embed = %+v", embed)
+	}
+	if embed.Footer == nil || embed.Footer.Text != "alice is 30 today" {
+		t.Errorf("footer = %+v", embed.Footer)
+	}
+
+	e.Description = "{mention} levels up!"
+	e.Color = intPtr(0xFF66AA)
+	e.ShowAvatar = false
+	e.ImageURL = "https://example.com/cake.gif"
+	embed = buildAnnouncementEmbed(e, "ignored", member, templateData{UserID: testUser, Name: "alice"})
+	if embed.Description != "<@user1> levels up!" || embed.Color != 0xFF66AA || embed.Thumbnail != nil || embed.Image == nil {
+		t.Errorf("embed = %+v", embed)
+	}
+}
+
+func TestParseEmbedModal(t *testing.T) {
+	modal := func(values map[string]string) discordgo.ModalSubmitInteractionData {
+		var data discordgo.ModalSubmitInteractionData
+		for id, v := range values {
+			data.Components = append(data.Components, &discordgo.ActionsRow{
+				Components: []discordgo.MessageComponent{&discordgo.TextInput{CustomID: id, Value: v}},
+			})
+		}
+		return data
+	}
+
+	e := defaultAnnouncementEmbed(testGuild)
+	err := parseEmbedModal(modal(map[string]string{"title": " Party ", "color": "#123456", "image_url": "https://example.com/a.png"}), e)
+	if err != nil || e.Title != "Party" || e.Color == nil || *e.Color != 0x123456 || e.ImageURL != "https://example.com/a.png" {
+		t.Fatalf("parsed = %+v, %v", e, err)
+	}
+
+	for _, values := range []map[string]string{{"color": "blue"}, {"image_url": "ftp://example.com/a.png"}, {"image_url": "cake.gif"}} {
+		e := defaultAnnouncementEmbed(testGuild)
+		if err := parseEmbedModal(modal(values), e); err == nil {
+			t.Errorf("parseEmbedModal(%v) accepted invalid input", values)
+		}
+		if e.Title != defaultAnnouncementEmbed(testGuild).Title {
+			t.Errorf("parseEmbedModal(%v) changed the design on error", values)
+		}
+	}
+}
+
+func TestProcessBirthdaysEmbed(t *testing.T) {
+	now := testNow
+	for _, enabled := range []bool{false, true} {
+		client := newFakeDiscord()
+		store := newFakeStore()
+		store.guilds[testGuild] = testGuildSettings(now.Hour())
+		store.birthdays[testGuild] = []database.MemberBirthday{{
+			GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Year: intPtr(now.Year() - 20), Timezone: "UTC",
+		}}
+		e := defaultAnnouncementEmbed(testGuild)
+		e.Enabled = enabled
+		store.embeds[testGuild] = *e
+		client.addMember(testGuild, testUser, "alice")
+
+		newTestBot(client, store, clock.NewFake(now)).processBirthdays()
+
+		if len(client.messages) != 1 {
+			t.Fatalf("enabled=%v: expected 1 message, got %d", enabled, len(client.messages))
+		}
+		msg := client.messages[0].Message
+		if !enabled {
+			if len(msg.Embeds) != 0 || !strings.Contains(msg.Content, "has turned 20") {
+				t.Errorf("plain text announcement = %+v", msg)
+			}
+			continue
+		}
+		if msg.Content != "<@"+testUser+">" || len(msg.Embeds) != 1 {
+			t.Fatalf("embed announcement content = %q, embeds = %d", msg.Content, len(msg.Embeds))
+		}
+		if got := msg.Embeds[0].Description; got != "<@"+testUser+"> has turned 20, happy birthday!" {
+			t.Errorf("embed description = %q", got)
+		}
+		if msg.AllowedMentions == nil || strings.Join(msg.AllowedMentions.Users, ",") != testUser {
+			t.Errorf("allowed mentions = %+v", msg.AllowedMentions)
+		}
+	}
+}
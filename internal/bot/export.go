@@ -0,0 +1,241 @@
+package bot
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+)
+
+// exportFormat and exportVersion identify the bot's own export files
+const (
+	exportFormat  = "cyan-birthdays"
+	exportVersion = 1
+)
+
+// guildExport is the native JSON export of a guild's birthdays and settings (documented in the README)
+type guildExport struct {
+	Format     string           `json:"format"`
+	Version    int              `json:"version"`
+	ExportedAt time.Time        `json:"exported_at"`
+	GuildID    string           `json:"guild_id"`
+	Settings   *exportSettings  `json:"settings"`
+	Birthdays  []exportBirthday `json:"birthdays"`
+
+	rawSettings json.RawMessage // settings as read from a file, to tell missing fields from zero values
+}
+
+// exportSettings mirrors database.GuildSettings without bookkeeping columns
+type exportSettings struct {
+	ChannelID          *string `json:"channel_id"`
+	RoleID             *string `json:"role_id"`
+	AnnouncementHour   int     `json:"announcement_hour"`
+	MessageWithYear    string  `json:"message_with_year"`
+	MessageWithoutYear string  `json:"message_without_year"`
+	AllowRoleMention   bool    `json:"allow_role_mention"`
+	RequiredRoleID     *string `json:"required_role_id"`
+	DefaultTimezone    string  `json:"default_timezone"`
+	EuropeanDateFormat bool    `json:"european_date_format"`
+	Use24hTime         bool    `json:"use_24h_time"`
+	LeapDayPolicy      string  `json:"leap_day_policy"`
+	CatchupHours       int     `json:"catchup_hours"`
+	AnnouncementMode   string  `json:"announcement_mode"`
+	WeeklyDigestDay    *int    `json:"weekly_digest_day"`
+	WeeklyDigestHour   int     `json:"weekly_digest_hour"`
+	Language           string  `json:"language,omitempty"`
+	SetupComplete      bool    `json:"setup_complete"`
+}
+
+// exportBirthday is a single member birthday in an export. The preferences are always exported,
+// and are nil when an imported file leaves them out.
+type exportBirthday struct {
+	UserID         string `json:"user_id"`
+	Month          int    `json:"month"`
+	Day            int    `json:"day"`
+	Year           *int   `json:"year"`
+	Timezone       string `json:"timezone"`
+	CalendarOptOut *bool  `json:"calendar_opt_out"`
+	DMGreeting     *bool  `json:"dm_greeting"`
+	DMReminder     *bool  `json:"dm_reminder"`
+}
+
+// newGuildExport builds an export from a guild's settings (nil if the guild has none) and birthdays
+func newGuildExport(guildID string, gs *database.GuildSettings, birthdays []database.MemberBirthday, exportedAt time.Time) *guildExport {
+	export := &guildExport{
+		Format:     exportFormat,
+		Version:    exportVersion,
+		ExportedAt: exportedAt.UTC(),
+		GuildID:    guildID,
+		Birthdays:  make([]exportBirthday, 0, len(birthdays)),
+	}
+
+	if gs != nil {
+		export.Settings = newExportSettings(gs)
+	}
+
+	for _, bd := range birthdays {
+		export.Birthdays = append(export.Birthdays, exportBirthday{
+			UserID:         bd.UserID,
+			Month:          bd.Month,
+			Day:            bd.Day,
+			Year:           bd.Year,
+			Timezone:       bd.Timezone,
+			CalendarOptOut: boolPtr(bd.CalendarOptOut),
+			DMGreeting:     boolPtr(bd.DMGreeting),
+			DMReminder:     boolPtr(bd.DMReminder),
+		})
+	}
+
+	return export
+}
+
+// newExportSettings converts stored guild settings to their exported form
+func newExportSettings(gs *database.GuildSettings) *exportSettings {
+	return &exportSettings{
+		ChannelID:          gs.ChannelID,
+		RoleID:             gs.RoleID,
+		AnnouncementHour:   gs.TimeUTC,
+		MessageWithYear:    gs.MessageWithYear,
+		MessageWithoutYear: gs.MessageWithoutYear,
+		AllowRoleMention:   gs.AllowRoleMention,
+		RequiredRoleID:     gs.RequiredRoleID,
+		DefaultTimezone:    gs.DefaultTimezone,
+		EuropeanDateFormat: gs.EuropeanDateFormat,
+		Use24hTime:         gs.Use24hTime,
+		LeapDayPolicy:      gs.LeapDayPolicy,
+		CatchupHours:       gs.CatchupHours,
+		AnnouncementMode:   gs.AnnouncementMode,
+		WeeklyDigestDay:    gs.WeeklyDigestDay,
+		WeeklyDigestHour:   gs.WeeklyDigestHour,
+		Language:           gs.Language,
+		SetupComplete:      gs.SetupComplete,
+	}
+}
+
+// guildSettings applies the exported settings on top of base for the given guild, or returns nil if
+// there are none. Fields missing from an imported file keep base's values.
+func (e *guildExport) guildSettings(guildID string, base *database.GuildSettings) (*database.GuildSettings, error) {
+	if e.Settings == nil {
+		return nil, nil
+	}
+	s := e.Settings
+	if e.rawSettings != nil {
+		s = newExportSettings(base)
+		if err := json.Unmarshal(e.rawSettings, s); err != nil {
+			return nil, err
+		}
+	}
+
+	gs := *base
+	gs.GuildID = guildID
+	gs.ChannelID = s.ChannelID
+	gs.RoleID = s.RoleID
+	gs.TimeUTC = s.AnnouncementHour
+	gs.MessageWithYear = s.MessageWithYear
+	gs.MessageWithoutYear = s.MessageWithoutYear
+	gs.AllowRoleMention = s.AllowRoleMention
+	gs.RequiredRoleID = s.RequiredRoleID
+	gs.DefaultTimezone = s.DefaultTimezone
+	gs.EuropeanDateFormat = s.EuropeanDateFormat
+	gs.Use24hTime = s.Use24hTime
+	gs.LeapDayPolicy = s.LeapDayPolicy
+	gs.CatchupHours = s.CatchupHours
+	gs.AnnouncementMode = s.AnnouncementMode
+	gs.WeeklyDigestDay = s.WeeklyDigestDay
+	gs.WeeklyDigestHour = s.WeeklyDigestHour
+	gs.Language = s.Language
+	gs.SetupComplete = s.SetupComplete
+	return &gs, nil
+}
+
+// memberBirthdays converts the exported birthdays back for the given guild
+func (e *guildExport) memberBirthdays(guildID string) []database.MemberBirthday {
+	birthdays := make([]database.MemberBirthday, 0, len(e.Birthdays))
+	for _, bd := range e.Birthdays {
+		birthdays = append(birthdays, database.MemberBirthday{
+			GuildID:  guildID,
+			UserID:   bd.UserID,
+			Month:    bd.Month,
+			Day:      bd.Day,
+			Year:     bd.Year,
+			Timezone: bd.Timezone,
+		})
+	}
+	return birthdays
+}
+
+// memberPreferences returns the calendar and DM preferences included for each exported member
+func (e *guildExport) memberPreferences() map[string]importPreferences {
+	prefs := make(map[string]importPreferences)
+	for _, bd := range e.Birthdays {
+		p := importPreferences{CalendarOptOut: bd.CalendarOptOut, DMGreeting: bd.DMGreeting, DMReminder: bd.DMReminder}
+		if p != (importPreferences{}) {
+			prefs[bd.UserID] = p
+		}
+	}
+	return prefs
+}
+
+// parseGuildExport decodes a native JSON export. ok is false when data isn't one of our exports,
+// so callers can fall back to other formats.
+func parseGuildExport(data []byte) (export *guildExport, ok bool, err error) {
+	var header struct {
+		Format  string `json:"format"`
+		Version int    `json:"version"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil || header.Format != exportFormat {
+		return nil, false, nil
+	}
+	if header.Version > exportVersion {
+		return nil, true, fmt.Errorf("export version %d is newer than this bot supports (%d)", header.Version, exportVersion)
+	}
+
+	var raw struct {
+		Settings json.RawMessage `json:"settings"`
+	}
+	export = &guildExport{}
+	if err := json.Unmarshal(data, export); err != nil {
+		return nil, true, err
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, true, err
+	}
+	if export.Settings != nil {
+		export.rawSettings = raw.Settings
+	}
+	return export, true, nil
+}
+
+// writeExportJSON writes an export as indented JSON
+func writeExportJSON(w io.Writer, export *guildExport) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(export)
+}
+
+// writeExportCSV writes an export's birthdays as user_id,date,timezone rows.
+// The date is YYYY-MM-DD, or MM-DD when the year is unknown.
+func writeExportCSV(w io.Writer, export *guildExport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"user_id", "date", "timezone"}); err != nil {
+		return err
+	}
+	for _, bd := range export.Birthdays {
+		if err := cw.Write([]string{bd.UserID, formatExportDate(bd), bd.Timezone}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// formatExportDate formats a birthday as YYYY-MM-DD, or MM-DD without a year
+func formatExportDate(bd exportBirthday) string {
+	if bd.Year != nil && *bd.Year > 0 {
+		return fmt.Sprintf("%04d-%02d-%02d", *bd.Year, bd.Month, bd.Day)
+	}
+	return fmt.Sprintf("%02d-%02d", bd.Month, bd.Day)
+}
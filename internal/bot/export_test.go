@@ -0,0 +1,149 @@
+package bot
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+)
+
+func testExportBirthdays() []database.MemberBirthday {
+	return []database.MemberBirthday{
+		{GuildID: testGuild, UserID: "100", Month: 3, Day: 14, Year: intPtr(1990), Timezone: "Europe/Berlin"},
+		{GuildID: testGuild, UserID: "200", Month: 2, Day: 29, Timezone: "America/New_York"},
+	}
+}
+
+func TestGuildExportRoundTrip(t *testing.T) {
+	gs := testGuildSettings(9)
+	gs.RequiredRoleID = strPtr(testRequired)
+	gs.EuropeanDateFormat = true
+	gs.LeapDayPolicy = timezone.LeapDayMar1
+	gs.CatchupHours = 3
+	gs.AnnouncementMode = database.AnnouncementModeDigest
+	gs.WeeklyDigestDay = intPtr(int(time.Monday))
+	gs.WeeklyDigestHour = 8
+	gs.Language = "de"
+
+	birthdays := testExportBirthdays()
+	birthdays[0].CalendarOptOut = true
+	birthdays[0].DMGreeting = true
+
+	var buf bytes.Buffer
+	if err := writeExportJSON(&buf, newGuildExport(testGuild, &gs, birthdays, testNow)); err != nil {
+		t.Fatal(err)
+	}
+
+	export, ok, err := parseGuildExport(buf.Bytes())
+	if !ok || err != nil {
+		t.Fatalf("parseGuildExport: ok=%v err=%v", ok, err)
+	}
+	if export.GuildID != testGuild || !export.ExportedAt.Equal(testNow) {
+		t.Errorf("header = %q %v, want %q %v", export.GuildID, export.ExportedAt, testGuild, testNow)
+	}
+
+	if got, err := export.guildSettings(testGuild, database.DefaultGuildSettings(testGuild)); err != nil || !reflect.DeepEqual(*got, gs) {
+		t.Errorf("settings round trip (err %v):\n got: %+v\nwant: %+v", err, *got, gs)
+	}
+	if got := export.memberBirthdays(testGuild); !reflect.DeepEqual(got, testExportBirthdays()) {
+		t.Errorf("birthdays round trip:\n got: %+v\nwant: %+v", got, testExportBirthdays())
+	}
+
+	wantPrefs := map[string]importPreferences{
+		"100": {CalendarOptOut: boolPtr(true), DMGreeting: boolPtr(true), DMReminder: boolPtr(false)},
+		"200": {CalendarOptOut: boolPtr(false), DMGreeting: boolPtr(false), DMReminder: boolPtr(false)},
+	}
+	if got := export.memberPreferences(); !reflect.DeepEqual(got, wantPrefs) {
+		t.Errorf("preferences round trip:\n got: %+v\nwant: %+v", got, wantPrefs)
+	}
+}
+
+func TestGuildExportPartialSettings(t *testing.T) {
+	data := `{"format": "cyan-birthdays", "version": 1, "guild_id": "` + testGuild + `",
+		"settings": {"channel_id": "` + testChannel + `", "announcement_hour": 7},
+		"birthdays": [{"user_id": "100", "month": 3, "day": 14, "timezone": "UTC"}]}`
+	export, _, err := parseGuildExport([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A new guild gets the schema defaults for everything the file leaves out
+	want := database.DefaultGuildSettings(testGuild)
+	want.ChannelID = strPtr(testChannel)
+	want.TimeUTC = 7
+	if got, err := export.guildSettings(testGuild, database.DefaultGuildSettings(testGuild)); err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("settings for a new guild (err %v):\n got: %+v\nwant: %+v", err, got, want)
+	}
+
+	// An existing guild keeps its current values
+	existing := testGuildSettings(9)
+	existing.CatchupHours = 2
+	existing.WeeklyDigestHour = 18
+	got, err := export.guildSettings(testGuild, &existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TimeUTC != 7 || got.CatchupHours != 2 || got.WeeklyDigestHour != 18 || *got.RoleID != testRole {
+		t.Errorf("settings for an existing guild = %+v, want the hour replaced and everything else kept", got)
+	}
+
+	if prefs := export.memberPreferences(); len(prefs) != 0 {
+		t.Errorf("preferences = %+v, want none for a file without them", prefs)
+	}
+}
+
+func TestGuildExportWithoutSettings(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeExportJSON(&buf, newGuildExport(testGuild, nil, nil, testNow)); err != nil {
+		t.Fatal(err)
+	}
+	export, _, err := parseGuildExport(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gs, _ := export.guildSettings(testGuild, database.DefaultGuildSettings(testGuild)); gs != nil {
+		t.Error("expected no settings")
+	}
+	if len(export.memberBirthdays(testGuild)) != 0 {
+		t.Error("expected no birthdays")
+	}
+}
+
+func TestParseGuildExportDetection(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantOK  bool
+		wantErr bool
+	}{
+		{"RedBot cog data", `{"1234": {"GUILD": {}, "MEMBER": {}}}`, false, false},
+		{"not JSON", "user_id,date,timezone\n", false, false},
+		{"other format", `{"format": "something-else", "version": 1}`, false, false},
+		{"native export", `{"format": "cyan-birthdays", "version": 1, "birthdays": []}`, true, false},
+		{"newer version", `{"format": "cyan-birthdays", "version": 99}`, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok, err := parseGuildExport([]byte(tt.data))
+			if ok != tt.wantOK || (err != nil) != tt.wantErr {
+				t.Errorf("parseGuildExport = ok %v err %v, want ok %v err %v", ok, err, tt.wantOK, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteExportCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeExportCSV(&buf, newGuildExport(testGuild, nil, testExportBirthdays(), testNow)); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "user_id,date,timezone\n100,1990-03-14,Europe/Berlin\n200,02-29,America/New_York\n"
+	if buf.String() != want {
+		t.Errorf("CSV =\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
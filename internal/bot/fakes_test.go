@@ -0,0 +1,467 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/bwmarrin/discordgo"
+	"github.com/jackc/pgx/v5"
+)
+
+// fakeDiscord is an in-memory DiscordClient that records role changes and sent messages
+type fakeDiscord struct {
+	mu           sync.Mutex
+	members      map[string]map[string]*discordgo.Member // guildID -> userID -> member
+	rolesAdded   []roleChange
+	rolesRemoved []roleChange
+	messages     []sentMessage
+	failRoleAdd  bool
+	failSend     bool
+	memberPages  int             // GuildMembers calls
+	closedDMs    map[string]bool // user IDs that don't accept direct messages
+}
+
+type roleChange struct {
+	GuildID string
+	UserID  string
+	RoleID  string
+}
+
+type sentMessage struct {
+	ChannelID string
+	Message   *discordgo.MessageSend
+}
+
+func newFakeDiscord() *fakeDiscord {
+	return &fakeDiscord{members: make(map[string]map[string]*discordgo.Member), closedDMs: make(map[string]bool)}
+}
+
+// addMember registers a guild member holding the given roles
+func (f *fakeDiscord) addMember(guildID, userID, username string, roles ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.members[guildID] == nil {
+		f.members[guildID] = make(map[string]*discordgo.Member)
+	}
+	f.members[guildID][userID] = &discordgo.Member{
+		GuildID: guildID,
+		User:    &discordgo.User{ID: userID, Username: username},
+		Roles:   append([]string(nil), roles...),
+	}
+}
+
+// hasRole reports whether a member currently holds a role
+func (f *fakeDiscord) hasRole(guildID, userID, roleID string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, ok := f.members[guildID][userID]
+	if !ok {
+		return false
+	}
+	for _, r := range m.Roles {
+		if r == roleID {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeDiscord) GuildMember(guildID, userID string, _ ...discordgo.RequestOption) (*discordgo.Member, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, ok := f.members[guildID][userID]
+	if !ok {
+		return nil, errors.New("unknown member")
+	}
+	cp := *m
+	cp.Roles = append([]string(nil), m.Roles...)
+	return &cp, nil
+}
+
+// GuildMembers pages through members in user ID order, like the Discord API
+func (f *fakeDiscord) GuildMembers(guildID, after string, limit int, _ ...discordgo.RequestOption) ([]*discordgo.Member, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.memberPages++
+	var ids []string
+	for id := range f.members[guildID] {
+		if after == "" || snowflakeLess(after, id) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(a, b int) bool { return snowflakeLess(ids[a], ids[b]) })
+	if len(ids) > limit {
+		ids = ids[:limit]
+	}
+	members := make([]*discordgo.Member, 0, len(ids))
+	for _, id := range ids {
+		cp := *f.members[guildID][id]
+		cp.Roles = append([]string(nil), cp.Roles...)
+		members = append(members, &cp)
+	}
+	return members, nil
+}
+
+// snowflakeLess orders IDs numerically when they are numbers and lexically otherwise
+func snowflakeLess(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+func (f *fakeDiscord) GuildMemberRoleAdd(guildID, userID, roleID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failRoleAdd {
+		return errors.New("missing permissions")
+	}
+	m, ok := f.members[guildID][userID]
+	if !ok {
+		return errors.New("unknown member")
+	}
+	m.Roles = append(m.Roles, roleID)
+	f.rolesAdded = append(f.rolesAdded, roleChange{guildID, userID, roleID})
+	return nil
+}
+
+func (f *fakeDiscord) GuildMemberRoleRemove(guildID, userID, roleID string, _ ...discordgo.RequestOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	m, ok := f.members[guildID][userID]
+	if !ok {
+		return errors.New("unknown member")
+	}
+	roles := m.Roles[:0]
+	for _, r := range m.Roles {
+		if r != roleID {
+			roles = append(roles, r)
+		}
+	}
+	m.Roles = roles
+	f.rolesRemoved = append(f.rolesRemoved, roleChange{guildID, userID, roleID})
+	return nil
+}
+
+func (f *fakeDiscord) ChannelMessageSendComplex(channelID string, data *discordgo.MessageSend, _ ...discordgo.RequestOption) (*discordgo.Message, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failSend {
+		return nil, errors.New("missing access")
+	}
+	f.messages = append(f.messages, sentMessage{ChannelID: channelID, Message: data})
+	id := "msg" + strconv.Itoa(len(f.messages))
+	return &discordgo.Message{ID: id, ChannelID: channelID, Content: data.Content}, nil
+}
+
+// UserChannelCreate returns a DM channel with the ID "dm:<userID>", or Discord's
+// "Cannot send messages to this user" error for users in closedDMs
+func (f *fakeDiscord) UserChannelCreate(recipientID string, _ ...discordgo.RequestOption) (*discordgo.Channel, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.closedDMs[recipientID] {
+		return nil, &discordgo.RESTError{
+			Response: &http.Response{StatusCode: http.StatusForbidden},
+			Message:  &discordgo.APIErrorMessage{Code: discordgo.ErrCodeCannotSendMessagesToThisUser, Message: "Cannot send messages to this user"},
+		}
+	}
+	return &discordgo.Channel{ID: "dm:" + recipientID, Type: discordgo.ChannelTypeDM}, nil
+}
+
+// directMessages returns the messages sent to a user's DM channel
+func (f *fakeDiscord) directMessages(userID string) []*discordgo.MessageSend {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var msgs []*discordgo.MessageSend
+	for _, m := range f.messages {
+		if m.ChannelID == "dm:"+userID {
+			msgs = append(msgs, m.Message)
+		}
+	}
+	return msgs
+}
+
+// fakeStore is an in-memory birthdayStore
+type fakeStore struct {
+	mu            sync.Mutex
+	guilds        map[string]database.GuildSettings
+	birthdays     map[string][]database.MemberBirthday
+	activeRoles   map[[2]string]database.ActiveBirthdayRole
+	announcements []database.Announcement
+	notifications []database.DMNotification
+	follows       []database.BirthdayFollow
+	digests       []database.DigestPost
+	embeds        map[string]database.AnnouncementEmbed
+	messages      map[string][]database.GuildMessage
+	lastRun       *time.Time
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{
+		guilds:      make(map[string]database.GuildSettings),
+		birthdays:   make(map[string][]database.MemberBirthday),
+		activeRoles: make(map[[2]string]database.ActiveBirthdayRole),
+		embeds:      make(map[string]database.AnnouncementEmbed),
+	}
+}
+
+func (f *fakeStore) GetGuildSettings(_ context.Context, guildID string) (*database.GuildSettings, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	gs, ok := f.guilds[guildID]
+	if !ok {
+		return nil, pgx.ErrNoRows
+	}
+	return &gs, nil
+}
+
+func (f *fakeStore) GetAllSetupGuilds(_ context.Context) ([]database.GuildSettings, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var guilds []database.GuildSettings
+	for _, gs := range f.guilds {
+		if gs.SetupComplete {
+			guilds = append(guilds, gs)
+		}
+	}
+	return guilds, nil
+}
+
+// GetBirthdayCandidates mirrors the SQL filter: today's local month/day, plus Feb 29 on Feb 28 and Mar 1
+func (f *fakeStore) GetBirthdayCandidates(_ context.Context, now time.Time) ([]database.MemberBirthday, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var candidates []database.MemberBirthday
+	for guildID, birthdays := range f.birthdays {
+		if gs, ok := f.guilds[guildID]; !ok || !gs.SetupComplete {
+			continue
+		}
+		for _, bd := range birthdays {
+			loc, err := time.LoadLocation(bd.Timezone)
+			if err != nil {
+				loc = time.UTC
+			}
+			local := now.In(loc)
+			m, d := int(local.Month()), local.Day()
+			leapCandidate := bd.Month == 2 && bd.Day == 29 && ((m == 2 && d == 28) || (m == 3 && d == 1))
+			if (bd.Month == m && bd.Day == d) || leapCandidate {
+				candidates = append(candidates, bd)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+func (f *fakeStore) SetActiveBirthdayRole(_ context.Context, guildID, userID string, expiresAt time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.activeRoles[[2]string{guildID, userID}] = database.ActiveBirthdayRole{
+		GuildID:        guildID,
+		UserID:         userID,
+		RoleAssignedAt: expiresAt.Add(-24 * time.Hour),
+		RoleExpiresAt:  expiresAt,
+	}
+	return nil
+}
+
+func (f *fakeStore) GetExpiredBirthdayRoles(_ context.Context, now time.Time) ([]database.ActiveBirthdayRole, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var roles []database.ActiveBirthdayRole
+	for _, ar := range f.activeRoles {
+		if !ar.RoleExpiresAt.After(now) {
+			roles = append(roles, ar)
+		}
+	}
+	return roles, nil
+}
+
+func (f *fakeStore) DeleteActiveBirthdayRole(_ context.Context, guildID, userID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.activeRoles, [2]string{guildID, userID})
+	return nil
+}
+
+func (f *fakeStore) HasActiveBirthdayRole(_ context.Context, guildID, userID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	_, ok := f.activeRoles[[2]string{guildID, userID}]
+	return ok, nil
+}
+
+func (f *fakeStore) HasAnnouncement(_ context.Context, guildID, userID string, year int) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, a := range f.announcements {
+		if a.GuildID == guildID && a.UserID == userID && a.BirthdayYear == year {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeStore) RecordAnnouncement(_ context.Context, a *database.Announcement) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.announcements = append(f.announcements, *a)
+	return nil
+}
+
+func (f *fakeStore) GetLastProcessedAt(_ context.Context) (*time.Time, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.lastRun == nil {
+		return nil, nil
+	}
+	t := *f.lastRun
+	return &t, nil
+}
+
+func (f *fakeStore) SetLastProcessedAt(_ context.Context, t time.Time) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.lastRun = &t
+	return nil
+}
+
+func (f *fakeStore) HasDMNotification(_ context.Context, guildID, userID, recipientID, kind string, year int) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, n := range f.notifications {
+		if n.GuildID == guildID && n.UserID == userID && n.RecipientID == recipientID && n.Kind == kind && n.BirthdayYear == year {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeStore) RecordDMNotification(_ context.Context, n *database.DMNotification) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.notifications = append(f.notifications, *n)
+	return nil
+}
+
+// GetBirthdayFollows mirrors the SQL filter: follows of members with a birthday in setup guilds
+func (f *fakeStore) GetBirthdayFollows(_ context.Context) ([]database.BirthdayFollow, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var follows []database.BirthdayFollow
+	for _, follow := range f.follows {
+		if gs, ok := f.guilds[follow.GuildID]; ok && gs.SetupComplete && follow.Birthday != nil {
+			follows = append(follows, follow)
+		}
+	}
+	return follows, nil
+}
+
+func (f *fakeStore) GetAllGuildBirthdays(_ context.Context, guildID string) ([]database.MemberBirthday, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]database.MemberBirthday(nil), f.birthdays[guildID]...), nil
+}
+
+func (f *fakeStore) HasDigestPost(_ context.Context, guildID, kind string, period time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, d := range f.digests {
+		if d.GuildID == guildID && d.Kind == kind && d.Period.Equal(period) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeStore) RecordDigestPost(_ context.Context, d *database.DigestPost) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.digests = append(f.digests, *d)
+	return nil
+}
+
+func (f *fakeStore) GetAnnouncementEmbed(_ context.Context, guildID string) (*database.AnnouncementEmbed, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	e, ok := f.embeds[guildID]
+	if !ok {
+		return nil, nil
+	}
+	return &e, nil
+}
+
+func (f *fakeStore) GetGuildMessages(_ context.Context, guildID string) ([]database.GuildMessage, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.messages[guildID], nil
+}
+
+func (f *fakeStore) GetLastAnnouncementTemplate(_ context.Context, guildID, userID string) (*int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var last *database.Announcement
+	for i, a := range f.announcements {
+		if a.GuildID == guildID && a.UserID == userID && (last == nil || a.BirthdayYear > last.BirthdayYear) {
+			last = &f.announcements[i]
+		}
+	}
+	if last == nil {
+		return nil, nil
+	}
+	return last.TemplateID, nil
+}
+
+// newTestBot builds a Bot wired to in-memory fakes and a fake clock
+func newTestBot(client *fakeDiscord, store *fakeStore, clk *clock.Fake) *Bot {
+	return &Bot{
+		client: client,
+		store:  store,
+		clock:  clk,
+		stopCh: make(chan struct{}),
+	}
+}
+
+// fakeWebhookStore is an in-memory webhookStore that records deliveries
+type fakeWebhookStore struct {
+	mu         sync.Mutex
+	webhooks   map[string][]database.Webhook // guildID -> webhooks
+	secrets    map[string]string
+	deliveries []database.WebhookDelivery
+}
+
+func newFakeWebhookStore() *fakeWebhookStore {
+	return &fakeWebhookStore{
+		webhooks: make(map[string][]database.Webhook),
+		secrets:  make(map[string]string),
+	}
+}
+
+func (f *fakeWebhookStore) GetGuildWebhooks(_ context.Context, guildID string) ([]database.Webhook, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]database.Webhook(nil), f.webhooks[guildID]...), nil
+}
+
+func (f *fakeWebhookStore) GetWebhookSecret(_ context.Context, guildID string) (*string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	secret, ok := f.secrets[guildID]
+	if !ok {
+		return nil, nil
+	}
+	return &secret, nil
+}
+
+func (f *fakeWebhookStore) RecordWebhookDelivery(_ context.Context, d *database.WebhookDelivery) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deliveries = append(f.deliveries, *d)
+	return nil
+}
@@ -1,19 +1,26 @@
 package bot
 
 import (
+	"cmp"
 	"context"
-	"fmt"
-	"strconv"
-	"strings"
+	"errors"
 	"time"
 
 	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/dateparse"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+	"github.com/bwmarrin/discordgo"
 )
 
+// languageAuto is the /bdset language choice for following each member's Discord language
+const languageAuto = "auto"
+
 // FormatSettings holds the formatting preferences for a guild
 type FormatSettings struct {
 	EuropeanDateFormat bool
 	Use24hTime         bool
+	Locale             i18n.Locale // "" if the guild has no language set, which formats as English
 }
 
 // GetFormatSettings retrieves format settings for a guild
@@ -22,10 +29,38 @@ func (b *Bot) GetFormatSettings(ctx context.Context, guildID string) FormatSetti
 	if err != nil || gs == nil {
 		return FormatSettings{} // Default to American format
 	}
-	return FormatSettings{
+	return guildFormatSettings(*gs)
+}
+
+// guildFormatSettings returns the format settings from already loaded guild settings
+func guildFormatSettings(gs database.GuildSettings) FormatSettings {
+	fs := FormatSettings{
 		EuropeanDateFormat: gs.EuropeanDateFormat,
 		Use24hTime:         gs.Use24hTime,
 	}
+	if loc, ok := i18n.Match(gs.Language); ok {
+		fs.Locale = loc
+	}
+	return fs
+}
+
+// interactionFormatSettings returns the guild's format settings with the locale to reply to i in:
+// the guild's language if one is set, otherwise the member's Discord language, otherwise English
+func (b *Bot) interactionFormatSettings(ctx context.Context, i *discordgo.InteractionCreate) FormatSettings {
+	fs := b.GetFormatSettings(ctx, i.GuildID)
+	if fs.Locale != "" {
+		return fs
+	}
+	fs.Locale = i18n.English
+	if loc, ok := i18n.Match(string(i.Locale)); ok {
+		fs.Locale = loc
+	}
+	return fs
+}
+
+// interactionLocale returns the locale to reply to i in
+func (b *Bot) interactionLocale(i *discordgo.InteractionCreate) i18n.Locale {
+	return b.interactionFormatSettings(context.Background(), i).Locale
 }
 
 // FormatTime formats a time according to guild settings
@@ -36,147 +71,55 @@ func FormatTime(t time.Time, settings FormatSettings) string {
 	return t.Format("3:04 PM")
 }
 
-// FormatDate formats a date according to guild settings
+// FormatDate formats a date according to guild settings, with the month name in the settings' locale
 func FormatDate(month, day int, year *int, settings FormatSettings) string {
-	var dateDisplay string
+	// European: Day Month, American: Month Day. Languages that never put the month first
+	// translate both orders the same way.
+	key := "date.month_day"
 	if settings.EuropeanDateFormat {
-		// European: Day Month
-		dateDisplay = fmt.Sprintf("%d %s", day, time.Month(month).String())
-	} else {
-		// American: Month Day
-		dateDisplay = time.Month(month).String() + " " + strconv.Itoa(day)
+		key = "date.day_month"
 	}
+	name := i18n.MonthName(settings.Locale, month)
 	if year != nil {
-		dateDisplay += ", " + strconv.Itoa(*year)
+		return i18n.T(settings.Locale, key+"_year", day, name, *year)
 	}
-	return dateDisplay
+	return i18n.T(settings.Locale, key, day, name)
 }
 
-// ParseDateWithSettings parses a date string according to guild settings
-// Returns month, day, year (optional)
-func ParseDateWithSettings(input string, settings FormatSettings) (month, day int, year *int, err error) {
-	input = strings.TrimSpace(input)
-
-	// Try ISO format YYYY-MM-DD first (e.g., 2000-12-07)
-	// ISO is always YYYY-MM-DD regardless of settings
-	if len(input) >= 8 {
-		for _, sep := range []string{"-", "/", "."} {
-			parts := strings.Split(input, sep)
-			if len(parts) == 3 {
-				// Check if first part looks like a year (4 digits)
-				if len(parts[0]) == 4 {
-					y, err1 := strconv.Atoi(parts[0])
-					m, err2 := strconv.Atoi(parts[1])
-					d, err3 := strconv.Atoi(parts[2])
-					if err1 == nil && err2 == nil && err3 == nil &&
-						y >= 1900 && y <= 2100 && m >= 1 && m <= 12 && d >= 1 && d <= 31 {
-						return m, d, &y, nil
-					}
-				}
-			}
-		}
-	}
-
-	// Try numeric format with separators
-	for _, sep := range []string{"/", "-", "."} {
-		parts := strings.Split(input, sep)
-		if len(parts) >= 2 {
-			first, err1 := strconv.Atoi(parts[0])
-			second, err2 := strconv.Atoi(parts[1])
-			if err1 == nil && err2 == nil {
-				var m, d int
-				if settings.EuropeanDateFormat {
-					// European: DD/MM/YYYY
-					d, m = first, second
-				} else {
-					// American: MM/DD/YYYY
-					m, d = first, second
-				}
-
-				if m >= 1 && m <= 12 && d >= 1 && d <= 31 {
-					if len(parts) == 3 {
-						y, err3 := strconv.Atoi(parts[2])
-						if err3 == nil {
-							if y < 100 {
-								y += 2000
-							}
-							return m, d, &y, nil
-						}
-					}
-					return m, d, nil, nil
-				}
-			}
-		}
-	}
-
-	// Try natural language format
-	months := map[string]int{
-		"january": 1, "jan": 1, "february": 2, "feb": 2, "march": 3, "mar": 3,
-		"april": 4, "apr": 4, "may": 5, "june": 6, "jun": 6,
-		"july": 7, "jul": 7, "august": 8, "aug": 8, "september": 9, "sep": 9, "sept": 9,
-		"october": 10, "oct": 10, "november": 11, "nov": 11, "december": 12, "dec": 12,
+// dateOptions returns the options for parsing a date typed by a member whose timezone is tz, so
+// "today" means their today
+func (b *Bot) dateOptions(fs FormatSettings, tz string) dateparse.Options {
+	today, err := timezone.GetCurrentTime(b.clock, tz)
+	if err != nil {
+		today = b.clock.Now()
 	}
+	minAge, maxAge := b.ageRange()
+	return dateparse.Options{DayFirst: fs.EuropeanDateFormat, Today: today, MinAge: minAge, MaxAge: maxAge}
+}
 
-	// Clean up input
-	cleaned := input
-	cleaned = strings.ReplaceAll(cleaned, ",", " ")
-	cleaned = strings.ReplaceAll(strings.ToLower(cleaned), " of ", " ")
-	if strings.HasPrefix(strings.ToLower(cleaned), "the ") {
-		cleaned = cleaned[4:]
+// ageRange returns the ages a birth year may give, from the config or the parser's defaults
+func (b *Bot) ageRange() (minAge, maxAge int) {
+	maxAge = dateparse.DefaultMaxAge
+	if b.config != nil {
+		minAge = b.config.MinAge
+		maxAge = cmp.Or(b.config.MaxAge, maxAge)
 	}
+	return minAge, maxAge
+}
 
-	words := strings.Fields(strings.ToLower(cleaned))
-	// Process words to remove ordinal suffixes from numbers only
-	for i, word := range words {
-		if len(word) > 2 {
-			suffix := word[len(word)-2:]
-			if suffix == "st" || suffix == "nd" || suffix == "rd" || suffix == "th" {
-				numPart := word[:len(word)-2]
-				if _, err := strconv.Atoi(numPart); err == nil {
-					words[i] = numPart
-				}
-			}
-		}
-	}
+// parseMemberDate parses a date typed by a member whose timezone is tz. Dates that could be read
+// either way follow the guild's format.
+func (b *Bot) parseMemberDate(input string, fs FormatSettings, tz string) (dateparse.Date, error) {
+	return preferredDate(dateparse.Parse(input, b.dateOptions(fs, tz)))
+}
 
-	for i, word := range words {
-		if m, ok := months[word]; ok {
-			// Try "Month Day [Year]" format (e.g., "December 7, 2000")
-			if i+1 < len(words) {
-				d, err := strconv.Atoi(words[i+1])
-				if err == nil && d >= 1 && d <= 31 {
-					if i+2 < len(words) {
-						y, err := strconv.Atoi(words[i+2])
-						if err == nil {
-							if y < 100 {
-								y += 2000
-							}
-							return m, d, &y, nil
-						}
-					}
-					return m, d, nil, nil
-				}
-			}
-			// Try "Day Month [Year]" format (e.g., "7 December 2000")
-			if i > 0 {
-				d, err := strconv.Atoi(words[i-1])
-				if err == nil && d >= 1 && d <= 31 {
-					if i+1 < len(words) {
-						y, err := strconv.Atoi(words[i+1])
-						if err == nil {
-							if y < 100 {
-								y += 2000
-							}
-							return m, d, &y, nil
-						}
-					}
-					return m, d, nil, nil
-				}
-			}
-		}
+// preferredDate resolves an ambiguous parse to its preferred reading
+func preferredDate(d dateparse.Date, err error) (dateparse.Date, error) {
+	var ambiguous *dateparse.AmbiguousError
+	if errors.As(err, &ambiguous) {
+		return ambiguous.Candidates[0], nil
 	}
-
-	return 0, 0, nil, fmt.Errorf("could not parse date: %s", input)
+	return d, err
 }
 
 // Ensure we use the database package
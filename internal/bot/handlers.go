@@ -1,18 +1,19 @@
 package bot
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
-	"strconv"
+	"path"
 	"strings"
-	"time"
 
 	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/dateparse"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
 	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
 	"github.com/bwmarrin/discordgo"
 	"github.com/jackc/pgx/v5"
@@ -45,6 +46,14 @@ func (b *Bot) handleBirthdayCommand(s *discordgo.Session, i *discordgo.Interacti
 		b.handleBirthdayRemove(s, i)
 	case "upcoming":
 		b.handleBirthdayUpcoming(s, i)
+	case "calendar":
+		b.handleBirthdayCalendar(s, i)
+	case "notifications":
+		b.handleBirthdayNotifications(s, i)
+	case "follow":
+		b.handleBirthdayFollow(s, i)
+	case "following":
+		b.handleBirthdayFollowing(s, i)
 	}
 }
 
@@ -67,7 +76,8 @@ func (b *Bot) handleBirthdaySet(s *discordgo.Session, i *discordgo.InteractionCr
 	slog.Debug("Parsed options", "dateStr", dateStr, "tzStr", tzStr)
 
 	ctx := context.Background()
-	formatSettings := b.GetFormatSettings(ctx, i.GuildID)
+	formatSettings := b.interactionFormatSettings(ctx, i)
+	loc := formatSettings.Locale
 
 	// Get default timezone if not provided
 	if tzStr == "" {
@@ -79,33 +89,35 @@ func (b *Bot) handleBirthdaySet(s *discordgo.Session, i *discordgo.InteractionCr
 		}
 	}
 
-	// Parse the date with format settings
-	month, day, year, err := ParseDateWithSettings(dateStr, formatSettings)
-	if err != nil {
-		slog.Debug("Failed to parse date", "dateStr", dateStr, "error", err)
-		formatHint := "MM/DD"
-		if formatSettings.EuropeanDateFormat {
-			formatHint = "DD/MM"
-		}
-		respondError(s, i, fmt.Sprintf("Invalid date format. Use formats like: %s, September 24, or %s/2002", formatHint, formatHint))
+	// Validate timezone
+	if !timezone.ValidateTimezone(tzStr) {
+		respondError(s, i, i18n.T(loc, "error.invalid_timezone", tzStr))
 		return
 	}
 
-	slog.Debug("Parsed date", "month", month, "day", day, "year", year)
-
-	// Validate timezone
-	if !timezone.ValidateTimezone(tzStr) {
-		respondError(s, i, fmt.Sprintf("Invalid timezone: %s. Please select from the autocomplete list.", tzStr))
+	// Parse the date with format settings, reading "today" in the member's timezone. Dates like 3/4
+	// are saved only once the member picks what they meant.
+	date, err := dateparse.Parse(dateStr, b.dateOptions(formatSettings, tzStr))
+	var ambiguous *dateparse.AmbiguousError
+	if errors.As(err, &ambiguous) {
+		s.InteractionRespond(i.Interaction, ambiguousDateResponse(ambiguous, tzStr, formatSettings))
+		return
+	}
+	if err != nil {
+		slog.Debug("Failed to parse date", "dateStr", dateStr, "error", err)
+		respondError(s, i, b.dateErrorMessage(err, formatSettings))
 		return
 	}
 
+	slog.Debug("Parsed date", "month", date.Month, "day", date.Day, "year", date.Year)
+
 	// Save to database
 	mb := &database.MemberBirthday{
 		GuildID:  i.GuildID,
 		UserID:   i.Member.User.ID,
-		Month:    month,
-		Day:      day,
-		Year:     year,
+		Month:    date.Month,
+		Day:      date.Day,
+		Year:     date.Year,
 		Timezone: tzStr,
 	}
 
@@ -113,40 +125,99 @@ func (b *Bot) handleBirthdaySet(s *discordgo.Session, i *discordgo.InteractionCr
 
 	if err := b.repo.SetMemberBirthday(ctx, mb); err != nil {
 		slog.Error("Failed to save birthday", "error", err)
-		respondError(s, i, "Failed to save your birthday")
+		respondError(s, i, i18n.T(loc, "birthday.set.failed"))
 		return
 	}
 
 	slog.Info("Birthday saved successfully", "guildID", mb.GuildID, "userID", mb.UserID)
 
-	// Format confirmation using guild settings
-	dateDisplay := FormatDate(month, day, year, formatSettings)
-	currentTime, _ := timezone.GetCurrentTime(tzStr)
-	timeDisplay := FormatTime(currentTime, formatSettings)
+	respondEphemeral(s, i, b.birthdaySetMessage(mb, formatSettings))
+}
 
-	respondEphemeral(s, i, fmt.Sprintf(
-		"🎂 Your birthday has been set to **%s**!\nTimezone: %s (current time: %s)",
-		dateDisplay, tzStr, timeDisplay,
-	))
+// birthdaySetMessage confirms a member's new birthday, formatted with the guild's settings
+func (b *Bot) birthdaySetMessage(mb *database.MemberBirthday, fs FormatSettings) string {
+	dateDisplay := FormatDate(mb.Month, mb.Day, mb.Year, fs)
+	currentTime, _ := timezone.GetCurrentTime(b.clock, mb.Timezone)
+	return i18n.T(fs.Locale, "birthday.set.success", dateDisplay, mb.Timezone, FormatTime(currentTime, fs))
+}
+
+// invalidDateMessage explains the accepted date formats, using the guild's day/month order
+func invalidDateMessage(fs FormatSettings) string {
+	hint := i18n.T(fs.Locale, "date.hint_month_day")
+	if fs.EuropeanDateFormat {
+		hint = i18n.T(fs.Locale, "date.hint_day_month")
+	}
+	return i18n.T(fs.Locale, "error.invalid_date", hint, FormatDate(9, 24, nil, fs))
+}
+
+// dateErrorMessage explains why a typed date was rejected: the date doesn't exist or can't be a
+// birthday, or it couldn't be read at all
+func (b *Bot) dateErrorMessage(err error, fs FormatSettings) string {
+	var invalid *dateparse.InvalidDateError
+	if !errors.As(err, &invalid) {
+		return invalidDateMessage(fs)
+	}
+
+	d := invalid.Date
+	switch {
+	case errors.Is(err, dateparse.ErrNoSuchDay):
+		return i18n.T(fs.Locale, "error.date.no_such_day", i18n.MonthName(fs.Locale, d.Month), dateparse.DaysIn(d.Month, d.Year))
+	case errors.Is(err, dateparse.ErrNotLeapYear):
+		return i18n.T(fs.Locale, "error.date.not_leap_year", *d.Year)
+	case errors.Is(err, dateparse.ErrFutureDate):
+		return i18n.T(fs.Locale, "error.date.future", FormatDate(d.Month, d.Day, d.Year, fs))
+	case errors.Is(err, dateparse.ErrImplausibleAge):
+		minAge, maxAge := b.ageRange()
+		return i18n.T(fs.Locale, "error.date.implausible_age", *d.Year, minAge, maxAge)
+	}
+	return invalidDateMessage(fs)
+}
+
+// ambiguousDateResponse asks the member which reading of their date they meant, with one button per
+// reading and the guild's preferred order first
+func ambiguousDateResponse(ambiguous *dateparse.AmbiguousError, tz string, fs FormatSettings) *discordgo.InteractionResponse {
+	var buttons []discordgo.MessageComponent
+	for _, d := range ambiguous.Candidates {
+		buttons = append(buttons, discordgo.Button{
+			Label:    FormatDate(d.Month, d.Day, d.Year, fs),
+			Style:    discordgo.PrimaryButton,
+			CustomID: birthdayPickID(d, tz),
+		})
+	}
+	buttons = append(buttons, discordgo.Button{
+		Label:    i18n.T(fs.Locale, "button.cancel"),
+		Style:    discordgo.SecondaryButton,
+		CustomID: "birthday_set_cancel",
+	})
+
+	return &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content:    i18n.T(fs.Locale, "birthday.set.ambiguous", ambiguous.Input),
+			Flags:      discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{discordgo.ActionsRow{Components: buttons}},
+		},
+	}
 }
 
 // handleBirthdayRemove shows confirmation for removing birthday
 func (b *Bot) handleBirthdayRemove(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loc := b.interactionLocale(i)
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "Are you sure you want to remove your birthday?",
+			Content: i18n.T(loc, "birthday.remove.confirm"),
 			Flags:   discordgo.MessageFlagsEphemeral,
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
 						discordgo.Button{
-							Label:    "Yes, remove it",
+							Label:    i18n.T(loc, "birthday.remove.button"),
 							Style:    discordgo.DangerButton,
 							CustomID: "birthday_remove_confirm",
 						},
 						discordgo.Button{
-							Label:    "Cancel",
+							Label:    i18n.T(loc, "button.cancel"),
 							Style:    discordgo.SecondaryButton,
 							CustomID: "birthday_remove_cancel",
 						},
@@ -167,168 +238,273 @@ func (b *Bot) handleBirthdayUpcoming(s *discordgo.Session, i *discordgo.Interact
 		}
 	}
 
-	ctx := context.Background()
-	birthdays, err := b.repo.GetAllGuildBirthdays(ctx, i.GuildID)
+	loc := b.interactionLocale(i)
+	upcoming, total, err := b.upcomingBirthdays(context.Background(), i.GuildID, days)
 	if err != nil {
-		respondError(s, i, "Failed to fetch birthdays")
+		respondError(s, i, i18n.T(loc, "error.fetch_birthdays"))
 		return
 	}
 
-	if len(birthdays) == 0 {
-		respondEphemeral(s, i, "No birthdays have been set in this server yet.")
+	if total == 0 {
+		respondEphemeral(s, i, i18n.T(loc, "upcoming.none_set"))
 		return
 	}
 
-	// Get guild settings for announcement hour
-	gs, err := b.repo.GetGuildSettings(ctx, i.GuildID)
-	var announcementHour int
-	if err != nil || gs == nil {
-		announcementHour = 0 // Default to midnight
-	} else {
-		announcementHour = gs.TimeUTC
-	}
-
-	// Filter to upcoming birthdays
-	now := time.Now().UTC()
-	// Truncate to start of day for accurate date comparison
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
-
-	slog.Debug("Checking upcoming birthdays", "today", today.Format("2006-01-02"), "totalBirthdays", len(birthdays))
-
-	type upcomingBday struct {
-		UserID   string
-		Month    int
-		Day      int
-		Year     *int
-		Timezone string
-		DaysAway int
-	}
-
-	var upcoming []upcomingBday
-	for _, bd := range birthdays {
-		// Calculate days until birthday using date-only comparison
-		thisYearBday := time.Date(now.Year(), time.Month(bd.Month), bd.Day, 0, 0, 0, 0, time.UTC)
-
-		// If birthday this year is before today, use next year
-		if thisYearBday.Before(today) {
-			thisYearBday = thisYearBday.AddDate(1, 0, 0)
-		}
-
-		daysAway := int(thisYearBday.Sub(today).Hours() / 24)
-
-		slog.Debug("Checking birthday", "userID", bd.UserID, "month", bd.Month, "day", bd.Day, "thisYearBday", thisYearBday.Format("2006-01-02"), "daysAway", daysAway)
-
-		if daysAway <= days {
-			// Check if required role is set and user has it
-			if gs.RequiredRoleID != nil {
-				member, err := s.GuildMember(i.GuildID, bd.UserID)
-				if err != nil {
-					slog.Debug("Could not fetch member for role check", "userID", bd.UserID, "error", err)
-					continue // Skip user if we can't check their roles
-				}
-
-				hasRole := false
-				for _, roleID := range member.Roles {
-					if roleID == *gs.RequiredRoleID {
-						hasRole = true
-						break
-					}
-				}
-				if !hasRole {
-					slog.Debug("User missing required role, skipping from upcoming", "userID", bd.UserID)
-					continue
-				}
-			}
+	if len(upcoming) == 0 {
+		respondEphemeral(s, i, i18n.T(loc, "upcoming.none", days))
+		return
+	}
 
-			upcoming = append(upcoming, upcomingBday{
-				UserID:   bd.UserID,
-				Month:    bd.Month,
-				Day:      bd.Day,
-				Year:     bd.Year,
-				Timezone: bd.Timezone,
-				DaysAway: daysAway,
-			})
-		}
+	embed := &discordgo.MessageEmbed{
+		Title:  i18n.T(loc, "upcoming.title", days),
+		Color:  0x00D9FF, // Cyan
+		Fields: upcomingEmbedFields(upcoming, loc),
 	}
 
-	slog.Debug("Upcoming birthdays filtered", "count", len(upcoming))
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+		},
+	}); err != nil {
+		slog.Error("Failed to respond with upcoming birthdays embed", "error", err)
+	}
+}
 
-	if len(upcoming) == 0 {
-		respondEphemeral(s, i, fmt.Sprintf("No upcoming birthdays in the next %d days.", days))
+// handleBirthdayCalendar sends the server's birthday calendar, or changes whether the user appears in it
+func (b *Bot) handleBirthdayCalendar(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options[0].Options
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+
+	for _, opt := range opts {
+		if opt.Name != "show_me" {
+			continue
+		}
+		show := opt.BoolValue()
+		found, err := b.repo.SetCalendarOptOut(ctx, i.GuildID, i.Member.User.ID, !show)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "calendar.visibility.failed"))
+			return
+		}
+		if !found {
+			respondError(s, i, i18n.T(loc, "error.no_birthday"))
+			return
+		}
+		if show {
+			respondEphemeral(s, i, i18n.T(loc, "calendar.visibility.shown"))
+		} else {
+			respondEphemeral(s, i, i18n.T(loc, "calendar.visibility.hidden"))
+		}
 		return
 	}
 
-	// Sort by days away
-	for i := 0; i < len(upcoming)-1; i++ {
-		for j := i + 1; j < len(upcoming); j++ {
-			if upcoming[j].DaysAway < upcoming[i].DaysAway {
-				upcoming[i], upcoming[j] = upcoming[j], upcoming[i]
-			}
+	data, count, err := b.buildGuildCalendar(ctx, i.GuildID)
+	if err != nil {
+		slog.Error("Failed to build calendar", "guild_id", i.GuildID, "error", err)
+		respondError(s, i, i18n.T(loc, "calendar.build_failed"))
+		return
+	}
+
+	content := i18n.T(loc, "calendar.file", count)
+	token, err := b.repo.GetCalendarToken(ctx, i.GuildID)
+	if err != nil {
+		slog.Warn("Failed to get calendar token", "guild_id", i.GuildID, "error", err)
+	} else if token != nil {
+		if url := b.calendarFeedURL(i.GuildID, *token); url != "" {
+			content += "\n" + i18n.T(loc, "calendar.subscribe", url)
 		}
 	}
+	content += "\n" + i18n.T(loc, "calendar.hide_hint")
 
-	// Build embed
-	embed := &discordgo.MessageEmbed{
-		Title: fmt.Sprintf("🎂 Upcoming Birthdays (Next %d Days)", days),
-		Color: 0x00D9FF, // Cyan
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Files: []*discordgo.File{{
+				Name:        "birthdays.ics",
+				ContentType: "text/calendar",
+				Reader:      bytes.NewReader(data),
+			}},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		slog.Error("Failed to send calendar", "guild_id", i.GuildID, "error", err)
 	}
+}
 
-	// Group by date
-	dateMap := make(map[string][]string)
-	for _, bd := range upcoming {
-		dateKey := fmt.Sprintf("%s %d", time.Month(bd.Month).String(), bd.Day)
-		switch bd.DaysAway {
-		case 0:
-			dateKey = "Today!"
-		case 1:
-			dateKey = "Tomorrow"
-		default:
-			dateKey = fmt.Sprintf("In %d days", bd.DaysAway)
+// handleBirthdayNotifications shows or updates which birthday DMs a member receives
+func (b *Bot) handleBirthdayNotifications(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options[0].Options
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+
+	var greeting, reminder *bool
+	for _, opt := range opts {
+		v := opt.BoolValue()
+		switch opt.Name {
+		case "greeting":
+			greeting = &v
+		case "reminder":
+			reminder = &v
 		}
+	}
 
-		// Calculate announcement time in user's timezone, then convert to Unix timestamp
-		loc, err := time.LoadLocation(bd.Timezone)
+	if greeting != nil || reminder != nil {
+		found, err := b.repo.SetMemberNotifications(ctx, i.GuildID, i.Member.User.ID, greeting, reminder)
 		if err != nil {
-			loc = time.UTC
+			slog.Error("Failed to update birthday notifications", "guild_id", i.GuildID, "user_id", i.Member.User.ID, "error", err)
+			respondError(s, i, i18n.T(loc, "notifications.update_failed"))
+			return
+		}
+		if !found {
+			respondError(s, i, i18n.T(loc, "error.no_birthday"))
+			return
 		}
+	}
+
+	bd, err := b.repo.GetMemberBirthday(ctx, i.GuildID, i.Member.User.ID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		respondError(s, i, i18n.T(loc, "error.no_birthday"))
+		return
+	}
+	if err != nil {
+		slog.Error("Failed to get member birthday", "guild_id", i.GuildID, "user_id", i.Member.User.ID, "error", err)
+		respondError(s, i, i18n.T(loc, "notifications.get_failed"))
+		return
+	}
 
-		// Get the birthday date in user's timezone with announcement hour
-		bdayDate := time.Date(now.Year(), time.Month(bd.Month), bd.Day, announcementHour, 0, 0, 0, loc)
-		if bdayDate.Before(now) && bd.DaysAway > 0 {
-			bdayDate = bdayDate.AddDate(1, 0, 0)
+	onOff := func(enabled bool) string {
+		if enabled {
+			return i18n.T(loc, "value.on")
 		}
+		return i18n.T(loc, "value.off")
+	}
+	respondEphemeral(s, i, i18n.T(loc, "notifications.status", onOff(bd.DMGreeting), onOff(bd.DMReminder)))
+}
 
-		// Format as Discord timestamp (shows time only in viewer's local time)
-		timestamp := fmt.Sprintf("<t:%d:t>", bdayDate.Unix())
+// handleBirthdayFollow follows another member's birthday
+func (b *Bot) handleBirthdayFollow(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options[0].Options
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
 
-		mention := fmt.Sprintf("<@%s> - %s", bd.UserID, timestamp)
-		if bd.Year != nil && *bd.Year > 0 {
-			age := now.Year() - *bd.Year
-			if bd.DaysAway > 0 {
-				mention = fmt.Sprintf("<@%s> (turning %d) - %s", bd.UserID, age, timestamp)
-			} else {
-				mention = fmt.Sprintf("<@%s> (now %d) - %s", bd.UserID, age, timestamp)
-			}
+	var user *discordgo.User
+	days := 1
+	for _, opt := range opts {
+		switch opt.Name {
+		case "user":
+			user = opt.UserValue(s)
+		case "days":
+			days = int(opt.IntValue())
 		}
-		dateMap[dateKey] = append(dateMap[dateKey], mention)
 	}
 
-	for date, users := range dateMap {
-		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:   date,
-			Value:  strings.Join(users, "\n"),
-			Inline: false,
-		})
+	if user == nil {
+		respondError(s, i, i18n.T(loc, "error.user_required"))
+		return
+	}
+	if user.ID == i.Member.User.ID {
+		respondError(s, i, i18n.T(loc, "follow.self"))
+		return
+	}
+	if user.Bot {
+		respondError(s, i, i18n.T(loc, "follow.bot"))
+		return
 	}
 
-	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
-		Type: discordgo.InteractionResponseChannelMessageWithSource,
-		Data: &discordgo.InteractionResponseData{
-			Embeds: []*discordgo.MessageEmbed{embed},
-		},
+	follows, err := b.repo.GetMemberFollows(ctx, i.GuildID, i.Member.User.ID)
+	if err != nil {
+		slog.Error("Failed to get birthday follows", "guild_id", i.GuildID, "user_id", i.Member.User.ID, "error", err)
+		respondError(s, i, i18n.T(loc, "follow.failed"))
+		return
+	}
+	following := false
+	for _, f := range follows {
+		if f.UserID == user.ID {
+			following = true
+			break
+		}
+	}
+	if !following && len(follows) >= maxFollows {
+		respondError(s, i, i18n.T(loc, "follow.limit", maxFollows))
+		return
+	}
+
+	if err := b.repo.AddBirthdayFollow(ctx, &database.BirthdayFollow{
+		GuildID:    i.GuildID,
+		FollowerID: i.Member.User.ID,
+		UserID:     user.ID,
+		DaysBefore: days,
 	}); err != nil {
-		slog.Error("Failed to respond with upcoming birthdays embed", "error", err)
+		slog.Error("Failed to follow birthday", "guild_id", i.GuildID, "user_id", i.Member.User.ID, "followed", user.ID, "error", err)
+		respondError(s, i, i18n.T(loc, "follow.failed"))
+		return
+	}
+
+	msg := i18n.T(loc, "follow.success", formatFollowDays(loc, days), user.ID)
+	if _, err := b.repo.GetMemberBirthday(ctx, i.GuildID, user.ID); errors.Is(err, pgx.ErrNoRows) {
+		msg += " " + i18n.T(loc, "follow.no_birthday_yet")
+	}
+	respondEphemeral(s, i, msg+"\n"+i18n.T(loc, "follow.allow_dms"))
+}
+
+// handleBirthdayFollowing lists the birthdays a member follows, or unfollows one
+func (b *Bot) handleBirthdayFollowing(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options[0].Options
+	ctx := context.Background()
+	formatSettings := b.interactionFormatSettings(ctx, i)
+	loc := formatSettings.Locale
+
+	for _, opt := range opts {
+		if opt.Name != "unfollow" {
+			continue
+		}
+		userID := opt.UserValue(s).ID
+		removed, err := b.repo.RemoveBirthdayFollow(ctx, i.GuildID, i.Member.User.ID, userID)
+		if err != nil {
+			slog.Error("Failed to unfollow birthday", "guild_id", i.GuildID, "user_id", i.Member.User.ID, "followed", userID, "error", err)
+			respondError(s, i, i18n.T(loc, "following.unfollow_failed"))
+			return
+		}
+		if !removed {
+			respondError(s, i, i18n.T(loc, "following.not_following", userID))
+			return
+		}
+		respondEphemeral(s, i, i18n.T(loc, "following.unfollowed", userID))
+		return
+	}
+
+	follows, err := b.repo.GetMemberFollows(ctx, i.GuildID, i.Member.User.ID)
+	if err != nil {
+		slog.Error("Failed to get birthday follows", "guild_id", i.GuildID, "user_id", i.Member.User.ID, "error", err)
+		respondError(s, i, i18n.T(loc, "following.failed"))
+		return
+	}
+	if len(follows) == 0 {
+		respondEphemeral(s, i, i18n.T(loc, "following.none"))
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(i18n.T(loc, "following.title", len(follows), maxFollows) + "\n")
+	for _, f := range follows {
+		date := i18n.T(loc, "following.no_birthday")
+		if f.Birthday != nil {
+			date = FormatDate(f.Birthday.Month, f.Birthday.Day, nil, formatSettings)
+		}
+		sb.WriteString(i18n.T(loc, "following.line", f.UserID, date, formatFollowDays(loc, f.DaysBefore)) + "\n")
+	}
+	sb.WriteString("\n" + i18n.T(loc, "following.unfollow_hint"))
+	respondEphemeral(s, i, sb.String())
+}
+
+// formatFollowDays describes how early a follow reminds
+func formatFollowDays(loc i18n.Locale, days int) string {
+	if days == 1 {
+		return i18n.T(loc, "follow.days_before_one")
 	}
+	return i18n.T(loc, "follow.days_before", days)
 }
 
 // handleBdsetCommand handles /bdset subcommands
@@ -339,7 +515,7 @@ func (b *Bot) handleBdsetCommand(s *discordgo.Session, i *discordgo.InteractionC
 
 	// Check if user has permission to use admin commands
 	if !b.HasBotAdminPermission(s, i) {
-		respondError(s, i, "You don't have permission to use this command. You need Manage Server permission or be a bot admin.")
+		respondError(s, i, i18n.T(b.interactionLocale(i), "error.no_permission"))
 		return
 	}
 
@@ -374,26 +550,56 @@ func (b *Bot) handleBdsetCommand(s *discordgo.Session, i *discordgo.InteractionC
 		b.handleBdsetDateFormat(s, i)
 	case "timeformat":
 		b.handleBdsetTimeFormat(s, i)
+	case "language":
+		b.handleBdsetLanguage(s, i)
+	case "leapday":
+		b.handleBdsetLeapDay(s, i)
+	case "embed":
+		b.handleBdsetEmbed(s, i)
+	case "messages":
+		b.handleBdsetMessages(s, i)
+	case "history":
+		b.handleBdsetHistory(s, i)
+	case "calendar":
+		b.handleBdsetCalendar(s, i)
+	case "export":
+		b.handleBdsetExport(s, i)
 	case "import":
 		b.handleBdsetImport(s, i)
+	case "webhook":
+		b.handleBdsetWebhook(s, i)
+	case "apikey":
+		b.handleBdsetAPIKey(s, i)
 	case "admin":
 		b.handleBdsetAdmin(s, i)
 	}
 }
 
+// subcommandOptions returns the options of the subcommand that was run, looking inside its group if it
+// has one
+func subcommandOptions(i *discordgo.InteractionCreate) []*discordgo.ApplicationCommandInteractionDataOption {
+	opts := i.ApplicationCommandData().Options
+	for len(opts) > 0 && (opts[0].Type == discordgo.ApplicationCommandOptionSubCommand ||
+		opts[0].Type == discordgo.ApplicationCommandOptionSubCommandGroup) {
+		opts = opts[0].Options
+	}
+	return opts
+}
+
 // handleBdsetChannel sets the birthday announcement channel
 func (b *Bot) handleBdsetChannel(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	opts := i.ApplicationCommandData().Options[0].Options
 	channelID := opts[0].ChannelValue(s).ID
 
 	ctx := context.Background()
+	loc := b.interactionLocale(i)
 	if err := b.repo.UpdateGuildChannel(ctx, i.GuildID, channelID); err != nil {
-		respondError(s, i, "Failed to update channel")
+		respondError(s, i, i18n.T(loc, "channel.failed"))
 		return
 	}
 
 	b.checkSetupComplete(ctx, i.GuildID)
-	respondEphemeral(s, i, fmt.Sprintf("✅ Birthday channel set to <#%s>", channelID))
+	respondEphemeral(s, i, i18n.T(loc, "channel.success", channelID))
 }
 
 // handleBdsetRole sets the birthday role
@@ -402,64 +608,94 @@ func (b *Bot) handleBdsetRole(s *discordgo.Session, i *discordgo.InteractionCrea
 	roleID := opts[0].RoleValue(s, i.GuildID).ID
 
 	ctx := context.Background()
+	loc := b.interactionLocale(i)
 	if err := b.repo.UpdateGuildRole(ctx, i.GuildID, roleID); err != nil {
-		respondError(s, i, "Failed to update role")
+		respondError(s, i, i18n.T(loc, "role.failed"))
 		return
 	}
 
 	b.checkSetupComplete(ctx, i.GuildID)
-	respondEphemeral(s, i, fmt.Sprintf("✅ Birthday role set to <@&%s>", roleID))
+	respondEphemeral(s, i, i18n.T(loc, "role.success", roleID))
 }
 
-// handleBdsetTime sets the announcement hour
+// handleBdsetTime sets the announcement hour and, if given, the catch-up window for announcements
+// missed while the bot was offline
 func (b *Bot) handleBdsetTime(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	opts := i.ApplicationCommandData().Options[0].Options
 	hour := int(opts[0].IntValue())
 
 	ctx := context.Background()
+	loc := b.interactionLocale(i)
 	if err := b.repo.UpdateGuildTime(ctx, i.GuildID, hour); err != nil {
-		respondError(s, i, "Failed to update time")
+		respondError(s, i, i18n.T(loc, "time.failed"))
 		return
 	}
+	msg := i18n.T(loc, "time.success", hour)
+
+	for _, opt := range opts[1:] {
+		if opt.Name != "catchup_hours" {
+			continue
+		}
+		hours := int(opt.IntValue())
+		if err := b.repo.UpdateGuildCatchupHours(ctx, i.GuildID, hours); err != nil {
+			respondError(s, i, i18n.T(loc, "error.update_setting"))
+			return
+		}
+		if hours == 0 {
+			msg += "\n" + i18n.T(loc, "catchup.disabled")
+		} else {
+			msg += "\n" + i18n.T(loc, "catchup.success", hours)
+		}
+	}
 
 	b.checkSetupComplete(ctx, i.GuildID)
-	respondEphemeral(s, i, fmt.Sprintf("✅ Birthday announcements will be sent at %02d:00 (in each user's timezone)", hour))
+	respondEphemeral(s, i, msg)
 }
 
 // handleBdsetMsgWithYear sets the birthday message with year from command
 func (b *Bot) handleBdsetMsgWithYear(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	opts := i.ApplicationCommandData().Options[0].Options
+	opts := subcommandOptions(i)
 	message := opts[0].StringValue()
+	loc := b.interactionLocale(i)
+	if err := validateTemplate(message, true); err != nil {
+		respondError(s, i, i18n.T(loc, "message.invalid", err))
+		return
+	}
 
 	ctx := context.Background()
 	if err := b.repo.UpdateGuildMessageWithYear(ctx, i.GuildID, message); err != nil {
-		respondError(s, i, "Failed to update message")
+		respondError(s, i, i18n.T(loc, "message.update_failed"))
 		return
 	}
 
 	b.checkSetupComplete(ctx, i.GuildID)
 
 	// Show preview
-	preview := formatMessage(message, i.Member.User.Username, i.Member.User.ID, intPtr(25))
-	respondEphemeral(s, i, fmt.Sprintf("✅ Message updated! Preview:\n> %s", preview))
+	preview := b.previewMessage(ctx, i.GuildID, i.Member, message, intPtr(25))
+	respondEphemeral(s, i, i18n.T(loc, "message.updated", preview))
 }
 
 // handleBdsetMsgWithoutYear sets the birthday message without year from command
 func (b *Bot) handleBdsetMsgWithoutYear(s *discordgo.Session, i *discordgo.InteractionCreate) {
-	opts := i.ApplicationCommandData().Options[0].Options
+	opts := subcommandOptions(i)
 	message := opts[0].StringValue()
+	loc := b.interactionLocale(i)
+	if err := validateTemplate(message, false); err != nil {
+		respondError(s, i, i18n.T(loc, "message.invalid", err))
+		return
+	}
 
 	ctx := context.Background()
 	if err := b.repo.UpdateGuildMessageWithoutYear(ctx, i.GuildID, message); err != nil {
-		respondError(s, i, "Failed to update message")
+		respondError(s, i, i18n.T(loc, "message.update_failed"))
 		return
 	}
 
 	b.checkSetupComplete(ctx, i.GuildID)
 
 	// Show preview
-	preview := formatMessage(message, i.Member.User.Username, i.Member.User.ID, nil)
-	respondEphemeral(s, i, fmt.Sprintf("✅ Message updated! Preview:\n> %s", preview))
+	preview := b.previewMessage(ctx, i.GuildID, i.Member, message, nil)
+	respondEphemeral(s, i, i18n.T(loc, "message.updated", preview))
 }
 
 // handleBdsetRoleMention toggles role mention permission
@@ -468,16 +704,17 @@ func (b *Bot) handleBdsetRoleMention(s *discordgo.Session, i *discordgo.Interact
 	enabled := opts[0].BoolValue()
 
 	ctx := context.Background()
+	loc := b.interactionLocale(i)
 	if err := b.repo.UpdateGuildRoleMention(ctx, i.GuildID, enabled); err != nil {
-		respondError(s, i, "Failed to update setting")
+		respondError(s, i, i18n.T(loc, "error.update_setting"))
 		return
 	}
 
-	status := "disabled"
 	if enabled {
-		status = "enabled"
+		respondEphemeral(s, i, i18n.T(loc, "rolemention.enabled"))
+	} else {
+		respondEphemeral(s, i, i18n.T(loc, "rolemention.disabled"))
 	}
-	respondEphemeral(s, i, fmt.Sprintf("✅ Role mentions in birthday messages are now %s", status))
 }
 
 // handleBdsetRequiredRole sets the required role for birthday announcements
@@ -485,22 +722,23 @@ func (b *Bot) handleBdsetRequiredRole(s *discordgo.Session, i *discordgo.Interac
 	opts := i.ApplicationCommandData().Options[0].Options
 
 	ctx := context.Background()
+	loc := b.interactionLocale(i)
 	var roleID *string
 
 	if len(opts) > 0 {
 		id := opts[0].RoleValue(s, i.GuildID).ID
 		roleID = &id
 		if err := b.repo.UpdateGuildRequiredRole(ctx, i.GuildID, roleID); err != nil {
-			respondError(s, i, "Failed to update setting")
+			respondError(s, i, i18n.T(loc, "error.update_setting"))
 			return
 		}
-		respondEphemeral(s, i, fmt.Sprintf("✅ Users must have <@&%s> to have their birthday announced", id))
+		respondEphemeral(s, i, i18n.T(loc, "requiredrole.set", id))
 	} else {
 		if err := b.repo.UpdateGuildRequiredRole(ctx, i.GuildID, nil); err != nil {
-			respondError(s, i, "Failed to update setting")
+			respondError(s, i, i18n.T(loc, "error.update_setting"))
 			return
 		}
-		respondEphemeral(s, i, "✅ Required role removed. All users can have their birthday announced.")
+		respondEphemeral(s, i, i18n.T(loc, "requiredrole.removed"))
 	}
 }
 
@@ -509,21 +747,23 @@ func (b *Bot) handleBdsetDefaultTimezone(s *discordgo.Session, i *discordgo.Inte
 	opts := i.ApplicationCommandData().Options[0].Options
 	tz := opts[0].StringValue()
 
+	ctx := context.Background()
+	formatSettings := b.interactionFormatSettings(ctx, i)
+	loc := formatSettings.Locale
+
 	if !timezone.ValidateTimezone(tz) {
-		respondError(s, i, "Invalid timezone. Please select from the autocomplete list.")
+		respondError(s, i, i18n.T(loc, "error.invalid_timezone", tz))
 		return
 	}
 
-	ctx := context.Background()
 	if err := b.repo.UpdateGuildDefaultTimezone(ctx, i.GuildID, tz); err != nil {
-		respondError(s, i, "Failed to update setting")
+		respondError(s, i, i18n.T(loc, "error.update_setting"))
 		return
 	}
 
-	formatSettings := b.GetFormatSettings(ctx, i.GuildID)
-	currentTime, _ := timezone.GetCurrentTime(tz)
+	currentTime, _ := timezone.GetCurrentTime(b.clock, tz)
 	timeDisplay := FormatTime(currentTime, formatSettings)
-	respondEphemeral(s, i, fmt.Sprintf("✅ Default timezone set to %s (current time: %s)", tz, timeDisplay))
+	respondEphemeral(s, i, i18n.T(loc, "defaulttimezone.success", tz, timeDisplay))
 }
 
 // handleBdsetForce force-sets a user's birthday from command options
@@ -543,14 +783,15 @@ func (b *Bot) handleBdsetForce(s *discordgo.Session, i *discordgo.InteractionCre
 		}
 	}
 
+	ctx := context.Background()
+	formatSettings := b.interactionFormatSettings(ctx, i)
+	loc := formatSettings.Locale
+
 	if user == nil {
-		respondError(s, i, "User is required")
+		respondError(s, i, i18n.T(loc, "error.user_required"))
 		return
 	}
 
-	ctx := context.Background()
-	formatSettings := b.GetFormatSettings(ctx, i.GuildID)
-
 	// Get default timezone if not provided
 	if tzStr == "" {
 		gs, err := b.repo.GetGuildSettings(ctx, i.GuildID)
@@ -561,20 +802,16 @@ func (b *Bot) handleBdsetForce(s *discordgo.Session, i *discordgo.InteractionCre
 		}
 	}
 
-	// Parse the date with format settings
-	month, day, year, err := ParseDateWithSettings(dateStr, formatSettings)
+	// Parse the date with format settings, reading "today" in the member's timezone
+	date, err := b.parseMemberDate(dateStr, formatSettings, tzStr)
 	if err != nil {
-		formatHint := "MM/DD"
-		if formatSettings.EuropeanDateFormat {
-			formatHint = "DD/MM"
-		}
-		respondError(s, i, fmt.Sprintf("Invalid date format. Use formats like: %s, September 24, or %s/2002", formatHint, formatHint))
+		respondError(s, i, b.dateErrorMessage(err, formatSettings))
 		return
 	}
 
 	// Validate timezone
 	if !timezone.ValidateTimezone(tzStr) {
-		respondError(s, i, fmt.Sprintf("Invalid timezone: %s. Please select from the autocomplete list.", tzStr))
+		respondError(s, i, i18n.T(loc, "error.invalid_timezone", tzStr))
 		return
 	}
 
@@ -582,105 +819,75 @@ func (b *Bot) handleBdsetForce(s *discordgo.Session, i *discordgo.InteractionCre
 	mb := &database.MemberBirthday{
 		GuildID:  i.GuildID,
 		UserID:   user.ID,
-		Month:    month,
-		Day:      day,
-		Year:     year,
+		Month:    date.Month,
+		Day:      date.Day,
+		Year:     date.Year,
 		Timezone: tzStr,
 	}
 
 	if err := b.repo.SetMemberBirthday(ctx, mb); err != nil {
 		slog.Error("Failed to save birthday", "error", err)
-		respondError(s, i, "Failed to save birthday")
+		respondError(s, i, i18n.T(loc, "force.failed"))
 		return
 	}
 
 	slog.Info("Birthday force-set", "guildID", mb.GuildID, "targetUserID", mb.UserID, "adminUserID", i.Member.User.ID)
 
 	// Format confirmation using guild settings
-	dateDisplay := FormatDate(month, day, year, formatSettings)
-	currentTime, _ := timezone.GetCurrentTime(tzStr)
+	dateDisplay := FormatDate(date.Month, date.Day, date.Year, formatSettings)
+	currentTime, _ := timezone.GetCurrentTime(b.clock, tzStr)
 	timeDisplay := FormatTime(currentTime, formatSettings)
 
-	respondEphemeral(s, i, fmt.Sprintf(
-		"🎂 Birthday for <@%s> has been set to **%s**!\nTimezone: %s (current time: %s)",
-		user.ID, dateDisplay, tzStr, timeDisplay,
-	))
+	respondEphemeral(s, i, i18n.T(loc, "force.success", user.ID, dateDisplay, tzStr, timeDisplay))
 }
 
 // handleBdsetSettings shows current settings
 func (b *Bot) handleBdsetSettings(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	ctx := context.Background()
+	loc := b.interactionLocale(i)
 	gs, err := b.repo.GetGuildSettings(ctx, i.GuildID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			respondEphemeral(s, i, "No settings configured yet. Use `/bdset interactive` to start setup.")
+			respondEphemeral(s, i, i18n.T(loc, "settings.none"))
 			return
 		}
-		respondError(s, i, "Failed to fetch settings")
+		respondError(s, i, i18n.T(loc, "settings.fetch_failed"))
 		return
 	}
 
+	field := func(key, value string) *discordgo.MessageEmbedField {
+		return &discordgo.MessageEmbedField{Name: i18n.T(loc, key), Value: value, Inline: true}
+	}
 	embed := &discordgo.MessageEmbed{
-		Title: "🎂 Birthday Bot Settings",
+		Title: i18n.T(loc, "settings.title"),
 		Color: 0xFF69B4,
 		Fields: []*discordgo.MessageEmbedField{
-			{
-				Name:   "Channel",
-				Value:  formatChannelSetting(gs.ChannelID),
-				Inline: true,
-			},
-			{
-				Name:   "Role",
-				Value:  formatRoleSetting(gs.RoleID),
-				Inline: true,
-			},
-			{
-				Name:   "Announcement Hour",
-				Value:  fmt.Sprintf("%02d:00", gs.TimeUTC),
-				Inline: true,
-			},
-			{
-				Name:   "Default Timezone",
-				Value:  gs.DefaultTimezone,
-				Inline: true,
-			},
-			{
-				Name:   "Role Mentions",
-				Value:  formatBool(gs.AllowRoleMention),
-				Inline: true,
-			},
-			{
-				Name:   "Required Role",
-				Value:  formatRoleSetting(gs.RequiredRoleID),
-				Inline: true,
-			},
-			{
-				Name:   "Date Format",
-				Value:  formatDateFormatSetting(gs.EuropeanDateFormat),
-				Inline: true,
-			},
-			{
-				Name:   "Time Format",
-				Value:  formatTimeFormatSetting(gs.Use24hTime),
-				Inline: true,
-			},
-			{
-				Name:   "Setup Complete",
-				Value:  formatBool(gs.SetupComplete),
-				Inline: true,
-			},
+			field("settings.channel", formatChannelSetting(loc, gs.ChannelID)),
+			field("settings.role", formatRoleSetting(loc, gs.RoleID)),
+			field("settings.hour", fmt.Sprintf("%02d:00", gs.TimeUTC)),
+			field("settings.default_timezone", gs.DefaultTimezone),
+			field("settings.role_mentions", formatBool(loc, gs.AllowRoleMention)),
+			field("settings.required_role", formatRoleSetting(loc, gs.RequiredRoleID)),
+			field("settings.date_format", formatDateFormatSetting(loc, gs.EuropeanDateFormat)),
+			field("settings.time_format", formatTimeFormatSetting(loc, gs.Use24hTime)),
+			field("settings.language", formatLanguageSetting(loc, gs.Language)),
+			field("settings.leap_day", formatLeapDaySetting(loc, gs.LeapDayPolicy)),
+			field("settings.catchup", formatCatchupSetting(loc, gs.CatchupHours)),
+			field("settings.announcements", formatAnnouncementModeSetting(loc, gs.AnnouncementMode)),
+			field("settings.weekly_digest", formatWeeklyDigestSetting(loc, gs.WeeklyDigestDay, gs.WeeklyDigestHour)),
+			field("settings.setup_complete", formatBool(loc, gs.SetupComplete)),
 		},
 	}
 
 	if gs.MessageWithYear != "" {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:  "Message (with age)",
+			Name:  i18n.T(loc, "settings.message_with_year"),
 			Value: "```" + gs.MessageWithYear + "```",
 		})
 	}
 	if gs.MessageWithoutYear != "" {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:  "Message (without age)",
+			Name:  i18n.T(loc, "settings.message_without_year"),
 			Value: "```" + gs.MessageWithoutYear + "```",
 		})
 	}
@@ -696,21 +903,22 @@ func (b *Bot) handleBdsetSettings(s *discordgo.Session, i *discordgo.Interaction
 
 // handleBdsetStop clears all settings
 func (b *Bot) handleBdsetStop(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loc := b.interactionLocale(i)
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 		Type: discordgo.InteractionResponseChannelMessageWithSource,
 		Data: &discordgo.InteractionResponseData{
-			Content: "⚠️ Are you sure you want to clear all birthday settings? This will stop birthday messages and role assignments.",
+			Content: i18n.T(loc, "stop.confirm"),
 			Flags:   discordgo.MessageFlagsEphemeral,
 			Components: []discordgo.MessageComponent{
 				discordgo.ActionsRow{
 					Components: []discordgo.MessageComponent{
 						discordgo.Button{
-							Label:    "Yes, clear settings",
+							Label:    i18n.T(loc, "stop.button"),
 							Style:    discordgo.DangerButton,
 							CustomID: "bdset_stop_confirm",
 						},
 						discordgo.Button{
-							Label:    "Cancel",
+							Label:    i18n.T(loc, "button.cancel"),
 							Style:    discordgo.SecondaryButton,
 							CustomID: "bdset_stop_cancel",
 						},
@@ -723,17 +931,18 @@ func (b *Bot) handleBdsetStop(s *discordgo.Session, i *discordgo.InteractionCrea
 
 // handleBdsetInteractive starts the interactive setup wizard
 func (b *Bot) handleBdsetInteractive(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loc := b.interactionLocale(i)
 	modal := discordgo.InteractionResponseData{
 		CustomID: "bdset_interactive_modal",
-		Title:    "Birthday Bot Setup",
+		Title:    i18n.T(loc, "setup.title"),
 		Components: []discordgo.MessageComponent{
 			discordgo.ActionsRow{
 				Components: []discordgo.MessageComponent{
 					discordgo.TextInput{
 						CustomID:    "msg_with_year",
-						Label:       "Message with age ({mention}, {name}, {new_age})",
+						Label:       i18n.T(loc, "setup.message_with_year"),
 						Style:       discordgo.TextInputParagraph,
-						Placeholder: "{mention} has turned {new_age}, happy birthday!",
+						Placeholder: i18n.T(loc, "setup.example_with_year"),
 						Required:    true,
 						MaxLength:   500,
 					},
@@ -743,9 +952,9 @@ func (b *Bot) handleBdsetInteractive(s *discordgo.Session, i *discordgo.Interact
 				Components: []discordgo.MessageComponent{
 					discordgo.TextInput{
 						CustomID:    "msg_without_year",
-						Label:       "Message without age ({mention}, {name})",
+						Label:       i18n.T(loc, "setup.message_without_year"),
 						Style:       discordgo.TextInputParagraph,
-						Placeholder: "Happy birthday {mention}!",
+						Placeholder: i18n.T(loc, "setup.example_without_year"),
 						Required:    true,
 						MaxLength:   500,
 					},
@@ -755,7 +964,7 @@ func (b *Bot) handleBdsetInteractive(s *discordgo.Session, i *discordgo.Interact
 				Components: []discordgo.MessageComponent{
 					discordgo.TextInput{
 						CustomID:    "time_hour",
-						Label:       "Announcement hour (0-23)",
+						Label:       i18n.T(loc, "setup.hour"),
 						Style:       discordgo.TextInputShort,
 						Placeholder: "0",
 						Required:    true,
@@ -767,12 +976,12 @@ func (b *Bot) handleBdsetInteractive(s *discordgo.Session, i *discordgo.Interact
 				Components: []discordgo.MessageComponent{
 					discordgo.TextInput{
 						CustomID:    "date_format",
-						Label:       "European date format DD/MM? (yes/no)",
+						Label:       i18n.T(loc, "setup.date_format"),
 						Style:       discordgo.TextInputShort,
-						Placeholder: "no",
+						Placeholder: i18n.T(loc, "value.no"),
 						Required:    false,
-						MaxLength:   3,
-						Value:       "no",
+						MaxLength:   5,
+						Value:       i18n.T(loc, "value.no"),
 					},
 				},
 			},
@@ -780,12 +989,12 @@ func (b *Bot) handleBdsetInteractive(s *discordgo.Session, i *discordgo.Interact
 				Components: []discordgo.MessageComponent{
 					discordgo.TextInput{
 						CustomID:    "time_format",
-						Label:       "24-hour time? (yes/no)",
+						Label:       i18n.T(loc, "setup.time_format"),
 						Style:       discordgo.TextInputShort,
-						Placeholder: "no",
+						Placeholder: i18n.T(loc, "value.no"),
 						Required:    false,
-						MaxLength:   3,
-						Value:       "no",
+						MaxLength:   5,
+						Value:       i18n.T(loc, "value.no"),
 					},
 				},
 			},
@@ -804,16 +1013,13 @@ func (b *Bot) handleBdsetDateFormat(s *discordgo.Session, i *discordgo.Interacti
 	european := opts[0].BoolValue()
 
 	ctx := context.Background()
+	loc := b.interactionLocale(i)
 	if err := b.repo.UpdateGuildEuropeanDateFormat(ctx, i.GuildID, european); err != nil {
-		respondError(s, i, "Failed to update setting")
+		respondError(s, i, i18n.T(loc, "error.update_setting"))
 		return
 	}
 
-	if european {
-		respondEphemeral(s, i, "✅ Date format set to European style (DD/MM/YYYY)")
-	} else {
-		respondEphemeral(s, i, "✅ Date format set to American style (MM/DD/YYYY)")
-	}
+	respondEphemeral(s, i, i18n.T(loc, "dateformat.success", formatDateFormatSetting(loc, european)))
 }
 
 // handleBdsetTimeFormat sets 24-hour time format preference
@@ -822,110 +1028,367 @@ func (b *Bot) handleBdsetTimeFormat(s *discordgo.Session, i *discordgo.Interacti
 	use24h := opts[0].BoolValue()
 
 	ctx := context.Background()
+	loc := b.interactionLocale(i)
 	if err := b.repo.UpdateGuildUse24hTime(ctx, i.GuildID, use24h); err != nil {
-		respondError(s, i, "Failed to update setting")
+		respondError(s, i, i18n.T(loc, "error.update_setting"))
 		return
 	}
 
-	if use24h {
-		respondEphemeral(s, i, "✅ Time format set to 24-hour (e.g., 14:00)")
+	respondEphemeral(s, i, i18n.T(loc, "timeformat.success", formatTimeFormatSetting(loc, use24h)))
+}
+
+// handleBdsetLanguage sets the language of the bot's replies and announcements in the guild
+func (b *Bot) handleBdsetLanguage(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options[0].Options
+	language := opts[0].StringValue()
+
+	if language == languageAuto {
+		language = ""
+	} else if _, ok := i18n.Match(language); !ok {
+		respondError(s, i, i18n.T(b.interactionLocale(i), "language.invalid"))
+		return
+	}
+
+	ctx := context.Background()
+	if err := b.repo.UpdateGuildLanguage(ctx, i.GuildID, language); err != nil {
+		respondError(s, i, i18n.T(b.interactionLocale(i), "error.update_setting"))
+		return
+	}
+
+	// Confirm in the new language
+	loc := b.interactionLocale(i)
+	respondEphemeral(s, i, i18n.T(loc, "language.success", formatLanguageSetting(loc, language)))
+}
+
+// handleBdsetLeapDay sets when Feb 29 birthdays are celebrated in common years
+func (b *Bot) handleBdsetLeapDay(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options[0].Options
+	policy := opts[0].StringValue()
+	loc := b.interactionLocale(i)
+
+	switch policy {
+	case timezone.LeapDayFeb28, timezone.LeapDayMar1, timezone.LeapDayLeapOnly:
+	default:
+		respondError(s, i, i18n.T(loc, "leapday.invalid"))
+		return
+	}
+
+	ctx := context.Background()
+	if err := b.repo.UpdateGuildLeapDayPolicy(ctx, i.GuildID, policy); err != nil {
+		respondError(s, i, i18n.T(loc, "error.update_setting"))
+		return
+	}
+
+	respondEphemeral(s, i, i18n.T(loc, "leapday.success", formatLeapDaySetting(loc, policy)))
+}
+
+// handleBdsetDigest shows or changes the announcement mode and the weekly digest schedule
+func (b *Bot) handleBdsetDigest(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := subcommandOptions(i)
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+
+	gs, err := b.repo.GetGuildSettings(ctx, i.GuildID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		gs = &database.GuildSettings{AnnouncementMode: database.AnnouncementModeIndividual, WeeklyDigestHour: 9}
+	} else if err != nil {
+		respondError(s, i, i18n.T(loc, "settings.fetch_failed"))
+		return
+	}
+
+	for _, opt := range opts {
+		switch opt.Name {
+		case "mode":
+			gs.AnnouncementMode = opt.StringValue()
+		case "weekly_day":
+			if day := int(opt.IntValue()); day < 0 {
+				gs.WeeklyDigestDay = nil
+			} else {
+				gs.WeeklyDigestDay = &day
+			}
+		case "weekly_hour":
+			gs.WeeklyDigestHour = int(opt.IntValue())
+		}
+	}
+
+	if len(opts) > 0 {
+		if err := b.repo.UpdateGuildDigestSettings(ctx, i.GuildID, gs.AnnouncementMode, gs.WeeklyDigestDay, gs.WeeklyDigestHour); err != nil {
+			respondError(s, i, i18n.T(loc, "error.update_setting"))
+			return
+		}
+	}
+
+	msg := i18n.T(loc, "digest.mode", formatAnnouncementModeSetting(loc, gs.AnnouncementMode))
+	if gs.AnnouncementMode == database.AnnouncementModeDigest {
+		msg += "\n" + i18n.T(loc, "digest.posted_at", gs.TimeUTC, gs.DefaultTimezone)
+	}
+	msg += "\n" + i18n.T(loc, "digest.weekly", formatWeeklyDigestSetting(loc, gs.WeeklyDigestDay, gs.WeeklyDigestHour))
+	if len(opts) > 0 {
+		msg = i18n.T(loc, "common.updated") + "\n" + msg
+	}
+	respondEphemeral(s, i, msg)
+}
+
+// handleBdsetEmbed switches between plain text and embed announcements, or opens the embed designer
+// when run without options
+func (b *Bot) handleBdsetEmbed(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := subcommandOptions(i)
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+
+	e, err := b.guildAnnouncementEmbed(ctx, i.GuildID)
+	if err != nil {
+		respondError(s, i, i18n.T(loc, "embed.get_failed"))
+		return
+	}
+
+	if len(opts) == 0 {
+		s.InteractionRespond(i.Interaction, embedDesignerModal(loc, e))
+		return
+	}
+
+	for _, opt := range opts {
+		switch opt.Name {
+		case "style":
+			e.Enabled = opt.StringValue() == "embed"
+		case "show_avatar":
+			e.ShowAvatar = opt.BoolValue()
+		}
+	}
+
+	if err := b.repo.SetAnnouncementEmbed(ctx, e); err != nil {
+		respondError(s, i, i18n.T(loc, "embed.update_failed"))
+		return
+	}
+	b.respondEmbedPreview(s, i, e, i18n.T(loc, "common.updated"))
+}
+
+// handleBdsetHistory lists recent birthday announcements
+func (b *Bot) handleBdsetHistory(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options[0].Options
+
+	var userID *string
+	for _, opt := range opts {
+		if opt.Name == "user" {
+			id := opt.UserValue(s).ID
+			userID = &id
+		}
+	}
+
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+	history, err := b.repo.GetAnnouncementHistory(ctx, i.GuildID, userID, 20)
+	if err != nil {
+		respondError(s, i, i18n.T(loc, "history.failed"))
+		return
+	}
+
+	if len(history) == 0 {
+		respondEphemeral(s, i, i18n.T(loc, "history.none"))
+		return
+	}
+
+	var lines []string
+	for _, a := range history {
+		line := fmt.Sprintf("**%d** · <@%s> · <t:%d:f>", a.BirthdayYear, a.UserID, a.AnnouncedAt.Unix())
+		if a.MessageID != nil && a.ChannelID != "" {
+			line += fmt.Sprintf(" · [%s](https://discord.com/channels/%s/%s/%s)", i18n.T(loc, "history.jump"), a.GuildID, a.ChannelID, *a.MessageID)
+		}
+		lines = append(lines, line)
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(loc, "history.title"),
+		Color:       0x00D9FF,
+		Description: strings.Join(lines, "\n"),
+	}
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{embed},
+			Flags:  discordgo.MessageFlagsEphemeral,
+		},
+	})
+}
+
+// handleBdsetCalendar enables, rotates or disables the guild's calendar feed
+func (b *Bot) handleBdsetCalendar(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options[0].Options
+	action := opts[0].StringValue()
+
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+	if action == "disable" {
+		if err := b.repo.SetCalendarToken(ctx, i.GuildID, nil); err != nil {
+			respondError(s, i, i18n.T(loc, "feed.update_failed"))
+			return
+		}
+		respondEphemeral(s, i, i18n.T(loc, "feed.disabled"))
+		return
+	}
+
+	if b.calendarFeedURL(i.GuildID, "") == "" {
+		respondError(s, i, i18n.T(loc, "feed.no_http"))
+		return
+	}
+
+	token, err := b.repo.GetCalendarToken(ctx, i.GuildID)
+	if err != nil {
+		respondError(s, i, i18n.T(loc, "feed.get_failed"))
+		return
+	}
+
+	if token == nil || action == "rotate" {
+		newToken := newCalendarToken()
+		if err := b.repo.SetCalendarToken(ctx, i.GuildID, &newToken); err != nil {
+			respondError(s, i, i18n.T(loc, "feed.update_failed"))
+			return
+		}
+		token = &newToken
+	}
+
+	msg := i18n.T(loc, "feed.success", b.calendarFeedURL(i.GuildID, *token))
+	if action == "rotate" {
+		msg += " " + i18n.T(loc, "feed.rotated")
+	}
+	respondEphemeral(s, i, msg)
+}
+
+// handleBdsetExport sends the guild's birthdays and settings as a JSON or CSV attachment
+func (b *Bot) handleBdsetExport(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	opts := i.ApplicationCommandData().Options[0].Options
+
+	format := "json"
+	for _, opt := range opts {
+		if opt.Name == "format" {
+			format = opt.StringValue()
+		}
+	}
+
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+	gs, err := b.repo.GetGuildSettings(ctx, i.GuildID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			respondError(s, i, i18n.T(loc, "settings.fetch_failed"))
+			return
+		}
+		gs = nil
+	}
+
+	birthdays, err := b.repo.GetAllGuildBirthdays(ctx, i.GuildID)
+	if err != nil {
+		respondError(s, i, i18n.T(loc, "error.fetch_birthdays"))
+		return
+	}
+
+	export := newGuildExport(i.GuildID, gs, birthdays, b.clock.Now())
+
+	var buf bytes.Buffer
+	contentType := "application/json"
+	if format == "csv" {
+		contentType = "text/csv"
+		err = writeExportCSV(&buf, export)
 	} else {
-		respondEphemeral(s, i, "✅ Time format set to 12-hour (e.g., 2:00 PM)")
+		err = writeExportJSON(&buf, export)
+	}
+	if err != nil {
+		slog.Error("Failed to encode export", "guild_id", i.GuildID, "format", format, "error", err)
+		respondError(s, i, i18n.T(loc, "export.failed"))
+		return
+	}
+
+	filename := fmt.Sprintf("birthdays-%s-%s.%s", i.GuildID, export.ExportedAt.Format("2006-01-02"), format)
+	slog.Info("Exported guild birthdays", "guild_id", i.GuildID, "format", format, "count", len(birthdays))
+
+	content := i18n.T(loc, "export.success", len(birthdays))
+	if format == "csv" {
+		content += " " + i18n.T(loc, "export.csv_note")
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Content: content,
+			Files: []*discordgo.File{{
+				Name:        filename,
+				ContentType: contentType,
+				Reader:      &buf,
+			}},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		slog.Error("Failed to send export", "guild_id", i.GuildID, "error", err)
 	}
 }
 
-// handleBdsetImport imports birthday data from RedBot Birthday cog JSON
+// handleBdsetImport imports birthdays from any format in the importer registry, detected or chosen explicitly.
+// With dry_run it previews the changes and waits for Confirm/Cancel before writing anything.
 func (b *Bot) handleBdsetImport(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	loc := b.interactionLocale(i)
+
 	// Check if user is the bot owner (application owner)
 	app, err := s.Application("@me")
 	if err != nil {
 		slog.Error("Failed to get application info", "error", err)
-		respondError(s, i, "Failed to verify permissions")
+		respondError(s, i, i18n.T(loc, "import.verify_failed"))
 		return
 	}
 
 	if i.Member.User.ID != app.Owner.ID {
-		respondError(s, i, "This command is only available to the bot owner")
+		respondError(s, i, i18n.T(loc, "import.owner_only"))
 		return
 	}
 
 	opts := i.ApplicationCommandData().Options[0].Options
 
-	// Get attachment ID from option
-	attachmentID := opts[0].Value.(string)
+	var attachmentID, format string
+	var dryRun bool
+	for _, opt := range opts {
+		switch opt.Name {
+		case "file":
+			attachmentID = opt.Value.(string)
+		case "format":
+			format = opt.StringValue()
+		case "dry_run":
+			dryRun = opt.BoolValue()
+		}
+	}
 
 	// Get attachment from resolved data
 	attachment, ok := i.ApplicationCommandData().Resolved.Attachments[attachmentID]
 	if !ok {
-		respondError(s, i, "Could not find the attached file")
+		respondError(s, i, i18n.T(loc, "import.no_file"))
 		return
 	}
 
-	// Verify it's a JSON file
-	if attachment.ContentType != "application/json" && !strings.HasSuffix(attachment.Filename, ".json") {
-		respondError(s, i, "Please attach a JSON file")
+	// Verify it's a text file; the importers work out the actual format
+	isText := strings.HasPrefix(attachment.ContentType, "text/") || strings.HasPrefix(attachment.ContentType, "application/json")
+	switch strings.ToLower(path.Ext(attachment.Filename)) {
+	case ".json", ".csv", ".tsv", ".txt", ".ics":
+		isText = true
+	}
+	if !isText {
+		respondError(s, i, i18n.T(loc, "import.wrong_type"))
 		return
 	}
 
-	slog.Info("Starting birthday import", "guild_id", i.GuildID, "filename", attachment.Filename, "size", attachment.Size)
+	slog.Info("Starting birthday import", "guild_id", i.GuildID, "filename", attachment.Filename, "size", attachment.Size, "format", format, "dry_run", dryRun)
 
 	// Download the file
 	resp, err := http.Get(attachment.URL)
 	if err != nil {
 		slog.Error("Failed to download file", "error", err)
-		respondError(s, i, "Failed to download file: "+err.Error())
+		respondError(s, i, i18n.T(loc, "import.download_failed", err))
 		return
 	}
 	defer resp.Body.Close()
 
-	jsonData, err := io.ReadAll(resp.Body)
+	fileData, err := io.ReadAll(resp.Body)
 	if err != nil {
 		slog.Error("Failed to read file", "error", err)
-		respondError(s, i, "Failed to read file: "+err.Error())
-		return
-	}
-
-	// Parse the JSON - RedBot cog format
-	var cogData map[string]json.RawMessage
-	if err := json.Unmarshal(jsonData, &cogData); err != nil {
-		slog.Error("Failed to parse JSON", "error", err)
-		respondError(s, i, "Failed to parse JSON: "+err.Error())
-		return
-	}
-
-	// The top level has a cog ID, we need to find the data inside it
-	var rootData struct {
-		Global json.RawMessage `json:"GLOBAL"`
-		Guild  map[string]struct {
-			TimeUTC            int    `json:"time_utc_s"`
-			MessageWithYear    string `json:"message_w_year"`
-			MessageWithoutYear string `json:"message_wo_year"`
-			ChannelID          int64  `json:"channel_id"`
-			RoleID             int64  `json:"role_id"`
-			SetupState         int    `json:"setup_state"`
-			RequireRole        int64  `json:"require_role"`
-			AllowRoleMention   bool   `json:"allow_role_mention"`
-		} `json:"GUILD"`
-		Member map[string]map[string]struct {
-			Birthday struct {
-				Year  *int `json:"year"`
-				Month int  `json:"month"`
-				Day   int  `json:"day"`
-			} `json:"birthday"`
-		} `json:"MEMBER"`
-	}
-
-	// Try to find the cog data inside
-	var parsedRoot bool
-	for _, v := range cogData {
-		if err := json.Unmarshal(v, &rootData); err == nil && (rootData.Guild != nil || rootData.Member != nil) {
-			parsedRoot = true
-			break
-		}
-	}
-
-	if !parsedRoot {
-		respondError(s, i, "Could not find valid birthday cog data in JSON")
+		respondError(s, i, i18n.T(loc, "import.read_failed", err))
 		return
 	}
 
@@ -938,75 +1401,60 @@ func (b *Bot) handleBdsetImport(s *discordgo.Session, i *discordgo.InteractionCr
 		defaultTZ = gs.DefaultTimezone
 	}
 
-	var importedCount int
-	var errorCount int
-
-	// Import member birthdays for this guild
-	if rootData.Member != nil {
-		for guildID, members := range rootData.Member {
-			// Only import for the current guild
-			if guildID != i.GuildID {
-				continue
-			}
-
-			for userID, data := range members {
-				var year *int
-				if data.Birthday.Year != nil && *data.Birthday.Year > 0 {
-					year = data.Birthday.Year
-				}
-
-				mb := &database.MemberBirthday{
-					GuildID:  i.GuildID,
-					UserID:   userID,
-					Month:    data.Birthday.Month,
-					Day:      data.Birthday.Day,
-					Year:     year,
-					Timezone: defaultTZ, // Use server's default timezone
-				}
-
-				if err := b.repo.SetMemberBirthday(ctx, mb); err != nil {
-					slog.Warn("Failed to import birthday", "user_id", userID, "error", err)
-					errorCount++
-				} else {
-					importedCount++
-					slog.Debug("Imported birthday", "user_id", userID, "month", data.Birthday.Month, "day", data.Birthday.Day)
-				}
-			}
-		}
+	ic := importContext{GuildID: i.GuildID, Existing: gs}
+	ic.Dates = b.dateOptions(ic.formatSettings(), defaultTZ)
+	plan, err := parseImportFile(fileData, format, ic)
+	if err != nil {
+		slog.Error("Failed to parse import file", "error", err)
+		respondError(s, i, i18n.T(loc, "import.parse_failed", err))
+		return
 	}
 
-	// Import guild settings if they exist for this guild
-	if rootData.Guild != nil {
-		if guildConfig, exists := rootData.Guild[i.GuildID]; exists {
-			channelID := strconv.FormatInt(guildConfig.ChannelID, 10)
-			roleID := strconv.FormatInt(guildConfig.RoleID, 10)
-
-			importedGS := &database.GuildSettings{
-				GuildID:            i.GuildID,
-				ChannelID:          &channelID,
-				RoleID:             &roleID,
-				TimeUTC:            guildConfig.TimeUTC,
-				MessageWithYear:    guildConfig.MessageWithYear,
-				MessageWithoutYear: guildConfig.MessageWithoutYear,
-				AllowRoleMention:   guildConfig.AllowRoleMention,
-				DefaultTimezone:    defaultTZ,
-				SetupComplete:      guildConfig.SetupState >= 5,
-			}
-
-			if guildConfig.RequireRole > 0 {
-				reqRole := strconv.FormatInt(guildConfig.RequireRole, 10)
-				importedGS.RequiredRoleID = &reqRole
-			}
+	existing, err := b.repo.GetAllGuildBirthdays(ctx, i.GuildID)
+	if err != nil {
+		respondError(s, i, i18n.T(loc, "error.fetch_birthdays"))
+		return
+	}
+	diff := diffImport(plan, existing, defaultTZ)
 
-			if err := b.repo.UpsertGuildSettings(ctx, importedGS); err != nil {
-				slog.Error("Failed to import guild settings", "error", err)
-			} else {
-				slog.Info("Imported guild settings", "guild_id", i.GuildID)
-			}
-		}
+	if !dryRun {
+		imported, failed, settingsResult := b.applyImport(ctx, loc, plan, diff)
+		slog.Info("Birthday import complete", "guild_id", i.GuildID, "source", plan.Source, "imported", imported, "errors", failed)
+		respondEphemeral(s, i, formatImportResult(loc, plan, diff, imported, failed, settingsResult))
+		return
 	}
 
-	respondEphemeral(s, i, fmt.Sprintf("✅ Import complete!\n\n**Birthdays imported:** %d\n**Errors:** %d\n**Timezone used:** %s", importedCount, errorCount, defaultTZ))
+	id := b.storePendingImport(&pendingImport{
+		GuildID:   i.GuildID,
+		UserID:    i.Member.User.ID,
+		Plan:      plan,
+		Diff:      diff,
+		CreatedAt: b.clock.Now(),
+	})
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{importPreviewEmbed(loc, plan, diff)},
+			Flags:  discordgo.MessageFlagsEphemeral,
+			Components: []discordgo.MessageComponent{
+				discordgo.ActionsRow{
+					Components: []discordgo.MessageComponent{
+						discordgo.Button{
+							Label:    i18n.T(loc, "import.confirm_button"),
+							Style:    discordgo.SuccessButton,
+							CustomID: importConfirmPrefix + id,
+						},
+						discordgo.Button{
+							Label:    i18n.T(loc, "button.cancel"),
+							Style:    discordgo.SecondaryButton,
+							CustomID: importCancelPrefix + id,
+						},
+					},
+				},
+			},
+		},
+	})
 }
 
 // checkSetupComplete checks if all required settings are configured
@@ -1050,39 +1498,78 @@ func respondError(s *discordgo.Session, i *discordgo.InteractionCreate, message
 	}
 }
 
-func formatChannelSetting(channelID *string) string {
+func formatChannelSetting(loc i18n.Locale, channelID *string) string {
 	if channelID == nil {
-		return "Not set"
+		return i18n.T(loc, "value.not_set")
 	}
 	return fmt.Sprintf("<#%s>", *channelID)
 }
 
-func formatRoleSetting(roleID *string) string {
+func formatRoleSetting(loc i18n.Locale, roleID *string) string {
 	if roleID == nil {
-		return "Not set"
+		return i18n.T(loc, "value.not_set")
 	}
 	return fmt.Sprintf("<@&%s>", *roleID)
 }
 
-func formatBool(b bool) string {
+func formatBool(loc i18n.Locale, b bool) string {
 	if b {
-		return "✅ Enabled"
+		return i18n.T(loc, "value.enabled")
 	}
-	return "❌ Disabled"
+	return i18n.T(loc, "value.disabled")
 }
 
-func formatDateFormatSetting(european bool) string {
+func formatDateFormatSetting(loc i18n.Locale, european bool) string {
 	if european {
-		return "DD/MM/YYYY (European)"
+		return i18n.T(loc, "value.date_european")
 	}
-	return "MM/DD/YYYY (American)"
+	return i18n.T(loc, "value.date_american")
 }
 
-func formatTimeFormatSetting(use24h bool) string {
+func formatTimeFormatSetting(loc i18n.Locale, use24h bool) string {
 	if use24h {
-		return "24-hour (14:00)"
+		return i18n.T(loc, "value.time_24h")
+	}
+	return i18n.T(loc, "value.time_12h")
+}
+
+func formatLanguageSetting(loc i18n.Locale, language string) string {
+	if l, ok := i18n.Match(language); ok {
+		return i18n.T(l, "language.name")
+	}
+	return i18n.T(loc, "value.language_auto")
+}
+
+func formatCatchupSetting(loc i18n.Locale, hours int) string {
+	if hours == 0 {
+		return i18n.T(loc, "value.disabled")
+	}
+	return i18n.T(loc, "value.hours", hours)
+}
+
+func formatAnnouncementModeSetting(loc i18n.Locale, mode string) string {
+	if mode == database.AnnouncementModeDigest {
+		return i18n.T(loc, "value.mode_digest")
+	}
+	return i18n.T(loc, "value.mode_individual")
+}
+
+func formatWeeklyDigestSetting(loc i18n.Locale, day *int, hour int) string {
+	if day == nil {
+		return i18n.T(loc, "value.disabled")
+	}
+	return i18n.T(loc, "value.weekly_digest", i18n.WeekdayName(loc, *day), hour)
+}
+
+func formatLeapDaySetting(loc i18n.Locale, policy string) string {
+	switch policy {
+	case timezone.LeapDayMar1:
+		return i18n.T(loc, "value.leap_mar1")
+	case timezone.LeapDayLeapOnly:
+		return i18n.T(loc, "value.leap_only")
+	default:
+		return i18n.T(loc, "value.leap_feb28")
 	}
-	return "12-hour (2:00 PM)"
 }
 
 // handleBdsetAdmin handles /bdset admin subcommand group
@@ -1103,6 +1590,185 @@ func (b *Bot) handleBdsetAdmin(s *discordgo.Session, i *discordgo.InteractionCre
 	}
 }
 
+// handleBdsetWebhook routes /bdset webhook subcommands
+func (b *Bot) handleBdsetWebhook(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if len(i.ApplicationCommandData().Options[0].Options) == 0 {
+		return
+	}
+
+	sub := i.ApplicationCommandData().Options[0].Options[0]
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+
+	switch sub.Name {
+	case "add":
+		target := strings.TrimSpace(sub.Options[0].StringValue())
+		if err := validateWebhookURL(target); err != nil {
+			respondError(s, i, i18n.T(loc, "webhook.invalid_url", err))
+			return
+		}
+		webhooks, err := b.repo.GetGuildWebhooks(ctx, i.GuildID)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "webhook.fetch_failed"))
+			return
+		}
+		if len(webhooks) >= maxGuildWebhooks {
+			respondError(s, i, i18n.T(loc, "webhook.limit", maxGuildWebhooks))
+			return
+		}
+		secret, err := b.webhookSecret(ctx, i.GuildID, false)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "webhook.secret_create_failed"))
+			return
+		}
+		id, err := b.repo.AddWebhook(ctx, &database.Webhook{GuildID: i.GuildID, URL: target, CreatedBy: i.Member.User.ID})
+		if err != nil {
+			slog.Error("Failed to add webhook", "guild_id", i.GuildID, "error", err)
+			respondError(s, i, i18n.T(loc, "webhook.add_failed"))
+			return
+		}
+		respondEphemeral(s, i, i18n.T(loc, "webhook.added", id, target, secret))
+
+	case "remove":
+		id := sub.Options[0].IntValue()
+		deleted, err := b.repo.DeleteWebhook(ctx, i.GuildID, id)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "webhook.remove_failed"))
+			return
+		}
+		if !deleted {
+			respondError(s, i, i18n.T(loc, "webhook.not_found", id))
+			return
+		}
+		respondEphemeral(s, i, i18n.T(loc, "webhook.removed", id))
+
+	case "list":
+		webhooks, err := b.repo.GetGuildWebhooks(ctx, i.GuildID)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "webhook.fetch_failed"))
+			return
+		}
+		if len(webhooks) == 0 {
+			respondEphemeral(s, i, i18n.T(loc, "webhook.none"))
+			return
+		}
+		var lines []string
+		for _, wh := range webhooks {
+			lines = append(lines, i18n.T(loc, "webhook.line", wh.WebhookID, wh.URL, wh.CreatedBy, wh.CreatedAt.Unix()))
+		}
+		respondEphemeral(s, i, i18n.T(loc, "webhook.list_title")+"\n"+strings.Join(lines, "\n"))
+
+	case "secret":
+		rotate := false
+		for _, opt := range sub.Options {
+			if opt.Name == "rotate" {
+				rotate = opt.BoolValue()
+			}
+		}
+		secret, err := b.webhookSecret(ctx, i.GuildID, rotate)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "webhook.secret_get_failed"))
+			return
+		}
+		msg := i18n.T(loc, "webhook.secret", secret)
+		if rotate {
+			msg = i18n.T(loc, "webhook.secret_rotated", secret)
+		}
+		respondEphemeral(s, i, msg)
+
+	case "test":
+		webhooks, err := b.repo.GetGuildWebhooks(ctx, i.GuildID)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "webhook.fetch_failed"))
+			return
+		}
+		if len(webhooks) == 0 {
+			respondError(s, i, i18n.T(loc, "webhook.none_configured"))
+			return
+		}
+		b.fireWebhook(i.GuildID, webhookEventPing, map[string]string{"triggered_by": i.Member.User.ID})
+		respondEphemeral(s, i, i18n.T(loc, "webhook.ping_sent", len(webhooks)))
+
+	case "log":
+		deliveries, err := b.repo.GetWebhookDeliveries(ctx, i.GuildID, 15)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "webhook.log_failed"))
+			return
+		}
+		if len(deliveries) == 0 {
+			respondEphemeral(s, i, i18n.T(loc, "webhook.log_none"))
+			return
+		}
+		var lines []string
+		for _, d := range deliveries {
+			lines = append(lines, formatWebhookDelivery(loc, d))
+		}
+		respondEphemeral(s, i, i18n.T(loc, "webhook.log_title")+"\n"+strings.Join(lines, "\n"))
+	}
+}
+
+// handleBdsetAPIKey routes /bdset apikey subcommands
+func (b *Bot) handleBdsetAPIKey(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if len(i.ApplicationCommandData().Options[0].Options) == 0 {
+		return
+	}
+
+	sub := i.ApplicationCommandData().Options[0].Options[0]
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+
+	switch sub.Name {
+	case "create":
+		name := sub.Options[0].StringValue()
+		key, keyID, err := b.createAPIKey(ctx, i.GuildID, name, i.Member.User.ID)
+		if err != nil {
+			slog.Error("Failed to create API key", "guild_id", i.GuildID, "error", err)
+			respondError(s, i, i18n.T(loc, "apikey.create_failed"))
+			return
+		}
+		msg := i18n.T(loc, "apikey.created", keyID, name, key)
+		if b.config == nil || b.config.HTTPAddr == "" {
+			msg += "\n" + i18n.T(loc, "apikey.no_http")
+		}
+		respondEphemeral(s, i, msg)
+
+	case "list":
+		keys, err := b.repo.GetAPIKeys(ctx, i.GuildID)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "apikey.fetch_failed"))
+			return
+		}
+		if len(keys) == 0 {
+			respondEphemeral(s, i, i18n.T(loc, "apikey.none"))
+			return
+		}
+		var lines []string
+		for _, k := range keys {
+			line := i18n.T(loc, "apikey.line", k.KeyID, k.Name, k.CreatedBy, k.CreatedAt.Unix())
+			if k.LastUsedAt != nil {
+				line += " · " + i18n.T(loc, "apikey.last_used", k.LastUsedAt.Unix())
+			} else {
+				line += " · " + i18n.T(loc, "apikey.never_used")
+			}
+			lines = append(lines, line)
+		}
+		respondEphemeral(s, i, i18n.T(loc, "apikey.list_title")+"\n"+strings.Join(lines, "\n"))
+
+	case "revoke":
+		keyID := strings.TrimSpace(sub.Options[0].StringValue())
+		deleted, err := b.repo.DeleteAPIKey(ctx, i.GuildID, keyID)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "apikey.revoke_failed"))
+			return
+		}
+		if !deleted {
+			respondError(s, i, i18n.T(loc, "apikey.not_found", keyID))
+			return
+		}
+		respondEphemeral(s, i, i18n.T(loc, "apikey.revoked", keyID))
+	}
+}
+
 // handleBdsetAdminAdd adds a user or role as a bot admin
 func (b *Bot) handleBdsetAdminAdd(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	opts := i.ApplicationCommandData().Options[0].Options[0].Options
@@ -1117,8 +1783,9 @@ func (b *Bot) handleBdsetAdminAdd(s *discordgo.Session, i *discordgo.Interaction
 		}
 	}
 
+	loc := b.interactionLocale(i)
 	if userID == "" && roleID == "" {
-		respondError(s, i, "Please specify a user or role to add as admin")
+		respondError(s, i, i18n.T(loc, "admin.add_missing"))
 		return
 	}
 
@@ -1126,18 +1793,18 @@ func (b *Bot) handleBdsetAdminAdd(s *discordgo.Session, i *discordgo.Interaction
 
 	if userID != "" {
 		if err := b.repo.AddBotAdmin(ctx, i.GuildID, userID, "user", i.Member.User.ID); err != nil {
-			respondError(s, i, "Failed to add user as admin")
+			respondError(s, i, i18n.T(loc, "admin.add_user_failed"))
 			return
 		}
-		respondEphemeral(s, i, fmt.Sprintf("✅ <@%s> has been added as a bot admin", userID))
+		respondEphemeral(s, i, i18n.T(loc, "admin.user_added", userID))
 	}
 
 	if roleID != "" {
 		if err := b.repo.AddBotAdmin(ctx, i.GuildID, roleID, "role", i.Member.User.ID); err != nil {
-			respondError(s, i, "Failed to add role as admin")
+			respondError(s, i, i18n.T(loc, "admin.add_role_failed"))
 			return
 		}
-		respondEphemeral(s, i, fmt.Sprintf("✅ <@&%s> has been added as a bot admin role", roleID))
+		respondEphemeral(s, i, i18n.T(loc, "admin.role_added", roleID))
 	}
 }
 
@@ -1155,8 +1822,9 @@ func (b *Bot) handleBdsetAdminRemove(s *discordgo.Session, i *discordgo.Interact
 		}
 	}
 
+	loc := b.interactionLocale(i)
 	if userID == "" && roleID == "" {
-		respondError(s, i, "Please specify a user or role to remove from admins")
+		respondError(s, i, i18n.T(loc, "admin.remove_missing"))
 		return
 	}
 
@@ -1164,32 +1832,33 @@ func (b *Bot) handleBdsetAdminRemove(s *discordgo.Session, i *discordgo.Interact
 
 	if userID != "" {
 		if err := b.repo.RemoveBotAdmin(ctx, i.GuildID, userID, "user"); err != nil {
-			respondError(s, i, "Failed to remove user from admins")
+			respondError(s, i, i18n.T(loc, "admin.remove_user_failed"))
 			return
 		}
-		respondEphemeral(s, i, fmt.Sprintf("✅ <@%s> has been removed from bot admins", userID))
+		respondEphemeral(s, i, i18n.T(loc, "admin.user_removed", userID))
 	}
 
 	if roleID != "" {
 		if err := b.repo.RemoveBotAdmin(ctx, i.GuildID, roleID, "role"); err != nil {
-			respondError(s, i, "Failed to remove role from admins")
+			respondError(s, i, i18n.T(loc, "admin.remove_role_failed"))
 			return
 		}
-		respondEphemeral(s, i, fmt.Sprintf("✅ <@&%s> has been removed from bot admin roles", roleID))
+		respondEphemeral(s, i, i18n.T(loc, "admin.role_removed", roleID))
 	}
 }
 
 // handleBdsetAdminList lists all bot admins for the guild
 func (b *Bot) handleBdsetAdminList(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	ctx := context.Background()
+	loc := b.interactionLocale(i)
 	admins, err := b.repo.GetBotAdmins(ctx, i.GuildID)
 	if err != nil {
-		respondError(s, i, "Failed to fetch bot admins")
+		respondError(s, i, i18n.T(loc, "admin.fetch_failed"))
 		return
 	}
 
 	if len(admins) == 0 {
-		respondEphemeral(s, i, "No bot admins configured. Only users with Manage Server permission can use admin commands.")
+		respondEphemeral(s, i, i18n.T(loc, "admin.none"))
 		return
 	}
 
@@ -1203,26 +1872,26 @@ func (b *Bot) handleBdsetAdminList(s *discordgo.Session, i *discordgo.Interactio
 	}
 
 	embed := &discordgo.MessageEmbed{
-		Title: "🔐 Bot Admins",
+		Title: i18n.T(loc, "admin.title"),
 		Color: 0x00D9FF,
 	}
 
 	if len(users) > 0 {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:  "Users",
+			Name:  i18n.T(loc, "admin.users"),
 			Value: strings.Join(users, "\n"),
 		})
 	}
 
 	if len(roles) > 0 {
 		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
-			Name:  "Roles",
+			Name:  i18n.T(loc, "admin.roles"),
 			Value: strings.Join(roles, "\n"),
 		})
 	}
 
 	embed.Footer = &discordgo.MessageEmbedFooter{
-		Text: "Users with Manage Server permission always have admin access",
+		Text: i18n.T(loc, "admin.footer"),
 	}
 
 	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
@@ -0,0 +1,162 @@
+package bot
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// loopStaleAfter is how long after its last completed run the hourly birthday loop is considered stuck
+const loopStaleAfter = 2 * time.Hour
+
+// readyCheckTimeout bounds each readiness check
+const readyCheckTimeout = 2 * time.Second
+
+// HTTPHandler returns the routes served by the optional HTTP server
+func (b *Bot) HTTPHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", b.handleHealthz)
+	mux.HandleFunc("GET /readyz", b.handleReadyz)
+	mux.HandleFunc("GET /calendar/{guild}/{token}", b.handleCalendarFeed)
+	mux.HandleFunc("GET /api/v1/guilds/{guild}/birthdays/upcoming", b.requireAPIKey(b.handleAPIUpcoming))
+	mux.HandleFunc("GET /api/v1/guilds/{guild}/settings", b.requireAPIKey(b.handleAPISettings))
+	mux.HandleFunc("GET /api/v1/guilds/{guild}/announcements", b.requireAPIKey(b.handleAPIAnnouncements))
+	return mux
+}
+
+// MetricsHandler returns the Prometheus metrics route, served on its own internal address so the
+// public HTTP server doesn't expose it
+func (b *Bot) MetricsHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /metrics", b.handleMetrics)
+	return mux
+}
+
+// handleHealthz reports that the process is alive
+func (b *Bot) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write([]byte("ok\n"))
+}
+
+// readinessCheck is a named dependency check for /readyz
+type readinessCheck struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// readinessChecks returns the checks that must all pass for the bot to be ready
+func (b *Bot) readinessChecks() []readinessCheck {
+	return []readinessCheck{
+		{Name: "discord", Check: func(context.Context) error { return b.checkDiscordSession() }},
+		{Name: "database", Check: b.checkDatabase},
+		{Name: "birthday_loop", Check: func(context.Context) error { return b.checkBirthdayLoop(b.clock.Now()) }},
+	}
+}
+
+// checkDiscordSession reports whether the gateway connection is up
+func (b *Bot) checkDiscordSession() error {
+	if b.session == nil {
+		return errors.New("no Discord session")
+	}
+	b.session.RLock()
+	defer b.session.RUnlock()
+	if !b.session.DataReady {
+		return errors.New("gateway not connected")
+	}
+	return nil
+}
+
+// checkDatabase pings the connection pool
+func (b *Bot) checkDatabase(ctx context.Context) error {
+	if b.pool == nil {
+		return errors.New("no database pool")
+	}
+	return b.pool.Ping(ctx)
+}
+
+// checkBirthdayLoop reports whether the birthday loop has completed a run recently
+func (b *Bot) checkBirthdayLoop(now time.Time) error {
+	last := b.metrics.lastSuccess()
+	if last.IsZero() {
+		return errors.New("no completed run yet")
+	}
+	if age := now.Sub(last); age > loopStaleAfter {
+		return fmt.Errorf("last completed run was %s ago", age.Round(time.Second))
+	}
+	return nil
+}
+
+// readinessResponse is the JSON body of /readyz
+type readinessResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks"`
+}
+
+// handleReadyz reports whether Discord, the database and the birthday loop are all healthy
+func (b *Bot) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	resp := readinessResponse{Status: "ok", Checks: make(map[string]string)}
+	for _, c := range b.readinessChecks() {
+		ctx, cancel := context.WithTimeout(r.Context(), readyCheckTimeout)
+		err := c.Check(ctx)
+		cancel()
+		if err != nil {
+			resp.Status = "unavailable"
+			resp.Checks[c.Name] = err.Error()
+			continue
+		}
+		resp.Checks[c.Name] = "ok"
+	}
+
+	status := http.StatusOK
+	if resp.Status != "ok" {
+		slog.Debug("Readiness check failed", "checks", resp.Checks)
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// handleMetrics serves the bot's metrics in the Prometheus text format
+func (b *Bot) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := b.metrics.writeTo(w); err != nil {
+		slog.Debug("Failed to write metrics", "error", err)
+	}
+}
+
+// handleCalendarFeed serves a guild's birthday calendar to holders of its secret token
+func (b *Bot) handleCalendarFeed(w http.ResponseWriter, r *http.Request) {
+	guildID := r.PathValue("guild")
+	token := strings.TrimSuffix(r.PathValue("token"), ".ics")
+
+	stored, err := b.repo.GetCalendarToken(r.Context(), guildID)
+	if err != nil {
+		slog.Error("Failed to get calendar token", "guild_id", guildID, "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+	// Unknown guilds, disabled feeds and wrong tokens all look the same
+	if stored == nil || subtle.ConstantTimeCompare([]byte(*stored), []byte(token)) != 1 {
+		http.NotFound(w, r)
+		return
+	}
+
+	data, count, err := b.buildGuildCalendar(r.Context(), guildID)
+	if err != nil {
+		slog.Error("Failed to build calendar feed", "guild_id", guildID, "error", err)
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	slog.Debug("Served calendar feed", "guild_id", guildID, "birthdays", count)
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Write(data)
+}
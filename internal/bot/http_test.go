@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestHealthz(t *testing.T) {
+	b := newTestBot(newFakeDiscord(), newFakeStore(), clock.NewFake(testNow))
+	rec := httptest.NewRecorder()
+	b.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status = %d, want 200", rec.Code)
+	}
+}
+
+func TestReadyzUnavailable(t *testing.T) {
+	b := newTestBot(newFakeDiscord(), newFakeStore(), clock.NewFake(testNow))
+	rec := httptest.NewRecorder()
+	b.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", rec.Code)
+	}
+	var resp readinessResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"discord", "database", "birthday_loop"} {
+		if resp.Checks[name] == "" || resp.Checks[name] == "ok" {
+			t.Errorf("check %s = %q, want a failure", name, resp.Checks[name])
+		}
+	}
+}
+
+func TestCheckDiscordSession(t *testing.T) {
+	b := &Bot{session: &discordgo.Session{}}
+	if b.checkDiscordSession() == nil {
+		t.Error("disconnected session reported as ready")
+	}
+	b.session.DataReady = true
+	if err := b.checkDiscordSession(); err != nil {
+		t.Errorf("connected session: %v", err)
+	}
+}
+
+func TestCheckBirthdayLoop(t *testing.T) {
+	clk := clock.NewFake(testNow)
+	b := newTestBot(newFakeDiscord(), newFakeStore(), clk)
+	if b.checkBirthdayLoop(clk.Now()) == nil {
+		t.Fatal("loop reported healthy before its first run")
+	}
+
+	b.processBirthdays()
+	if err := b.checkBirthdayLoop(clk.Now()); err != nil {
+		t.Fatalf("after a run: %v", err)
+	}
+
+	clk.Advance(loopStaleAfter + time.Minute)
+	if b.checkBirthdayLoop(clk.Now()) == nil {
+		t.Error("loop reported healthy after missing its runs")
+	}
+}
+
+func TestMetrics(t *testing.T) {
+	now := testNow
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = testGuildSettings(now.Hour())
+	store.birthdays[testGuild] = []database.MemberBirthday{{
+		GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Timezone: "UTC",
+	}}
+	client.addMember(testGuild, testUser, "alice")
+
+	b := newTestBot(client, store, clock.NewFake(now))
+	b.processBirthdays()
+	b.metrics.commandHandled("birthday set")
+	b.metrics.commandHandled("birthday set")
+	b.metrics.roleRemoveFailed()
+
+	rec := httptest.NewRecorder()
+	b.HTTPHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("public handler served /metrics with status %d, want 404", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	b.MetricsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rec.Body.String()
+
+	for _, want := range []string{
+		"cyan_birthdays_announcements_sent_total 1\n",
+		"cyan_birthdays_role_add_failures_total 0\n",
+		"cyan_birthdays_role_remove_failures_total 1\n",
+		`cyan_birthdays_commands_handled_total{command="birthday set"} 2` + "\n",
+		`cyan_birthdays_loop_duration_seconds_bucket{le="+Inf"} 1` + "\n",
+		"cyan_birthdays_loop_duration_seconds_count 1\n",
+		"# TYPE cyan_birthdays_loop_duration_seconds histogram\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics missing %q", want)
+		}
+	}
+	if !strings.Contains(body, "cyan_birthdays_loop_last_success_timestamp_seconds "+strconv.FormatInt(now.Unix(), 10)) {
+		t.Error("metrics missing the last loop success time")
+	}
+}
+
+func TestCommandPath(t *testing.T) {
+	data := discordgo.ApplicationCommandInteractionData{
+		Name: "bdset",
+		Options: []*discordgo.ApplicationCommandInteractionDataOption{{
+			Name: "time",
+			Type: discordgo.ApplicationCommandOptionSubCommand,
+			Options: []*discordgo.ApplicationCommandInteractionDataOption{
+				{Name: "hour", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(9)},
+			},
+		}},
+	}
+	if got := commandPath(data); got != "bdset time" {
+		t.Errorf("commandPath = %q, want %q", got, "bdset time")
+	}
+}
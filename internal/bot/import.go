@@ -0,0 +1,383 @@
+package bot
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/dateparse"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+	"github.com/bwmarrin/discordgo"
+)
+
+// pendingImportTTL is how long a dry-run import can be confirmed; it matches the interaction token lifetime
+const pendingImportTTL = 15 * time.Minute
+
+// Custom ID prefixes for the dry-run Confirm/Cancel buttons, followed by the pending import ID
+const (
+	importConfirmPrefix = "bdset_import_confirm:"
+	importCancelPrefix  = "bdset_import_cancel:"
+)
+
+// importPlan is a parsed import file, ready to be previewed or applied
+type importPlan struct {
+	Source       string
+	Birthdays    []database.MemberBirthday // Timezone is empty when the file didn't specify one
+	Invalid      []importRowError
+	Preferences  map[string]importPreferences // by user ID, for formats that carry member preferences
+	Settings     *database.GuildSettings      // nil when the file has no settings for this guild
+	SettingsNote string                       // message key for why settings are not being imported, if they aren't
+
+	dates dateparse.Options // rules each birthday is validated against
+}
+
+// importPreferences are a member's calendar and DM preferences from an import, nil where the file has none
+type importPreferences struct {
+	CalendarOptOut *bool
+	DMGreeting     *bool
+	DMReminder     *bool
+}
+
+// differs reports whether applying p would change the preferences stored on mb
+func (p importPreferences) differs(mb database.MemberBirthday) bool {
+	return (p.CalendarOptOut != nil && *p.CalendarOptOut != mb.CalendarOptOut) ||
+		(p.DMGreeting != nil && *p.DMGreeting != mb.DMGreeting) ||
+		(p.DMReminder != nil && *p.DMReminder != mb.DMReminder)
+}
+
+// importRowError describes a row that could not be imported
+type importRowError struct {
+	Row    int // 0 for the file's guild settings
+	UserID string
+	Reason string
+}
+
+// importDiff classifies an import's birthdays against those already stored
+type importDiff struct {
+	New       []database.MemberBirthday
+	Changed   []database.MemberBirthday
+	Unchanged []database.MemberBirthday
+}
+
+// pendingImport is a dry-run import waiting for Confirm or Cancel
+type pendingImport struct {
+	GuildID   string
+	UserID    string
+	Plan      *importPlan
+	Diff      importDiff
+	CreatedAt time.Time
+}
+
+// addBirthday validates a parsed row and records it as importable or invalid
+func (p *importPlan) addBirthday(row int, mb database.MemberBirthday) {
+	if err := validateImportBirthday(mb, p.dates); err != nil {
+		p.Invalid = append(p.Invalid, importRowError{Row: row, UserID: mb.UserID, Reason: err.Error()})
+		return
+	}
+	p.Birthdays = append(p.Birthdays, mb)
+}
+
+// validateImportBirthday checks that an imported birthday could have been entered by hand, using the
+// same date rules as /birthday set
+func validateImportBirthday(mb database.MemberBirthday, opts dateparse.Options) error {
+	if _, err := strconv.ParseUint(mb.UserID, 10, 64); err != nil {
+		return fmt.Errorf("invalid user ID %q", mb.UserID)
+	}
+	if mb.Month < 1 || mb.Month > 12 {
+		return fmt.Errorf("invalid month %d", mb.Month)
+	}
+	d := dateparse.Date{Month: mb.Month, Day: mb.Day, Year: mb.Year}
+	if err := dateparse.Validate(d, opts); err != nil {
+		return fmt.Errorf("invalid date %s: %w", d, err)
+	}
+	if mb.Timezone != "" && !timezone.ValidateTimezone(mb.Timezone) {
+		return fmt.Errorf("invalid timezone %q", mb.Timezone)
+	}
+	return nil
+}
+
+// setSettings records imported guild settings, or if any of them are invalid, why they will be skipped
+func (p *importPlan) setSettings(gs *database.GuildSettings) {
+	errs := validateImportSettings(gs)
+	if len(errs) == 0 {
+		p.Settings = gs
+		return
+	}
+	for _, err := range errs {
+		p.Invalid = append(p.Invalid, importRowError{Reason: err.Error()})
+	}
+	p.SettingsNote = "import.settings_invalid"
+}
+
+// validateImportSettings checks imported guild settings against the same rules as the /bdset commands
+func validateImportSettings(gs *database.GuildSettings) []error {
+	var errs []error
+	if gs.TimeUTC < 0 || gs.TimeUTC > 23 {
+		errs = append(errs, fmt.Errorf("invalid announcement hour %d", gs.TimeUTC))
+	}
+	if err := validateTemplate(gs.MessageWithYear, true); err != nil {
+		errs = append(errs, fmt.Errorf("invalid message with year: %w", err))
+	}
+	if err := validateTemplate(gs.MessageWithoutYear, false); err != nil {
+		errs = append(errs, fmt.Errorf("invalid message without year: %w", err))
+	}
+	if !timezone.ValidateTimezone(gs.DefaultTimezone) {
+		errs = append(errs, fmt.Errorf("invalid default timezone %q", gs.DefaultTimezone))
+	}
+	switch gs.LeapDayPolicy {
+	case timezone.LeapDayFeb28, timezone.LeapDayMar1, timezone.LeapDayLeapOnly:
+	default:
+		errs = append(errs, fmt.Errorf("invalid leap day policy %q", gs.LeapDayPolicy))
+	}
+	if gs.CatchupHours < 0 || gs.CatchupHours > 23 {
+		errs = append(errs, fmt.Errorf("invalid catch-up window of %d hours", gs.CatchupHours))
+	}
+	switch gs.AnnouncementMode {
+	case database.AnnouncementModeIndividual, database.AnnouncementModeDigest:
+	default:
+		errs = append(errs, fmt.Errorf("invalid announcement mode %q", gs.AnnouncementMode))
+	}
+	if day := gs.WeeklyDigestDay; day != nil && (*day < 0 || *day > 6) {
+		errs = append(errs, fmt.Errorf("invalid weekly digest day %d", *day))
+	}
+	if gs.WeeklyDigestHour < 0 || gs.WeeklyDigestHour > 23 {
+		errs = append(errs, fmt.Errorf("invalid weekly digest hour %d", gs.WeeklyDigestHour))
+	}
+	if gs.Language != "" {
+		if loc, ok := i18n.Match(gs.Language); !ok || string(loc) != gs.Language {
+			errs = append(errs, fmt.Errorf("invalid language %q", gs.Language))
+		}
+	}
+	return errs
+}
+
+// diffImport compares an import against the guild's stored birthdays. Rows without a timezone
+// keep the member's current one, or get defaultTZ if they are new.
+func diffImport(plan *importPlan, existing []database.MemberBirthday, defaultTZ string) importDiff {
+	current := make(map[string]database.MemberBirthday, len(existing))
+	for _, mb := range existing {
+		current[mb.UserID] = mb
+	}
+
+	var diff importDiff
+	for _, mb := range plan.Birthdays {
+		old, ok := current[mb.UserID]
+		if mb.Timezone == "" {
+			mb.Timezone = defaultTZ
+			if ok {
+				mb.Timezone = old.Timezone
+			}
+		}
+
+		switch {
+		case !ok:
+			diff.New = append(diff.New, mb)
+		case old.Month != mb.Month || old.Day != mb.Day || !sameYear(old.Year, mb.Year) || old.Timezone != mb.Timezone,
+			plan.Preferences[mb.UserID].differs(old):
+			diff.Changed = append(diff.Changed, mb)
+		default:
+			diff.Unchanged = append(diff.Unchanged, mb)
+		}
+	}
+	return diff
+}
+
+// applyImportPreferences stores the preferences an import included for a member's birthday
+func (b *Bot) applyImportPreferences(ctx context.Context, mb database.MemberBirthday, p importPreferences) error {
+	if p.CalendarOptOut != nil {
+		if _, err := b.repo.SetCalendarOptOut(ctx, mb.GuildID, mb.UserID, *p.CalendarOptOut); err != nil {
+			return err
+		}
+	}
+	if p.DMGreeting != nil || p.DMReminder != nil {
+		if _, err := b.repo.SetMemberNotifications(ctx, mb.GuildID, mb.UserID, p.DMGreeting, p.DMReminder); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sameYear(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// applyImport writes new and changed birthdays, their preferences and any imported settings
+func (b *Bot) applyImport(ctx context.Context, loc i18n.Locale, plan *importPlan, diff importDiff) (imported, failed int, settingsResult string) {
+	for _, mb := range append(append([]database.MemberBirthday{}, diff.New...), diff.Changed...) {
+		err := b.repo.SetMemberBirthday(ctx, &mb)
+		if err == nil {
+			err = b.applyImportPreferences(ctx, mb, plan.Preferences[mb.UserID])
+		}
+		if err != nil {
+			slog.Warn("Failed to import birthday", "user_id", mb.UserID, "error", err)
+			failed++
+		} else {
+			imported++
+			slog.Debug("Imported birthday", "user_id", mb.UserID, "month", mb.Month, "day", mb.Day)
+		}
+	}
+
+	settingsResult = importSettingsSummary(loc, plan)
+	if plan.Settings != nil {
+		if err := b.repo.UpsertGuildSettings(ctx, plan.Settings); err != nil {
+			slog.Error("Failed to import guild settings", "error", err)
+			settingsResult = i18n.T(loc, "import.settings_failed")
+		} else {
+			slog.Info("Imported guild settings", "guild_id", plan.Settings.GuildID)
+			settingsResult = i18n.T(loc, "import.settings_restored")
+		}
+	}
+	return imported, failed, settingsResult
+}
+
+// importSettingsSummary describes what will happen to the guild settings
+func importSettingsSummary(loc i18n.Locale, plan *importPlan) string {
+	switch {
+	case plan.Settings != nil:
+		return i18n.T(loc, "import.settings_replaced")
+	case plan.SettingsNote != "":
+		return i18n.T(loc, plan.SettingsNote)
+	default:
+		return i18n.T(loc, "import.settings_not_included")
+	}
+}
+
+// importPreviewEmbed summarizes a dry-run import
+func importPreviewEmbed(loc i18n.Locale, plan *importPlan, diff importDiff) *discordgo.MessageEmbed {
+	field := func(key string, value string) *discordgo.MessageEmbedField {
+		return &discordgo.MessageEmbedField{Name: i18n.T(loc, key), Value: value, Inline: true}
+	}
+	embed := &discordgo.MessageEmbed{
+		Title:       i18n.T(loc, "import.preview_title"),
+		Color:       0x00D9FF,
+		Description: i18n.T(loc, "import.preview_description", plan.Source),
+		Fields: []*discordgo.MessageEmbedField{
+			field("import.new", strconv.Itoa(len(diff.New))),
+			field("import.changed", strconv.Itoa(len(diff.Changed))),
+			field("import.unchanged", strconv.Itoa(len(diff.Unchanged))),
+			field("import.invalid", strconv.Itoa(len(plan.Invalid))),
+			field("import.settings", importSettingsSummary(loc, plan)),
+		},
+	}
+
+	if len(plan.Invalid) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:  i18n.T(loc, "import.invalid_rows"),
+			Value: formatImportErrors(loc, plan.Invalid, 10),
+		})
+	}
+	return embed
+}
+
+// formatImportErrors lists up to limit row errors
+func formatImportErrors(loc i18n.Locale, rowErrors []importRowError, limit int) string {
+	var lines []string
+	for idx, re := range rowErrors {
+		if idx == limit {
+			lines = append(lines, i18n.T(loc, "import.more_errors", len(rowErrors)-limit))
+			break
+		}
+		line := i18n.T(loc, "import.settings")
+		if re.Row > 0 {
+			line = i18n.T(loc, "import.row", re.Row)
+		}
+		if re.UserID != "" {
+			line += fmt.Sprintf(" (`%s`)", re.UserID)
+		}
+		lines = append(lines, line+": "+re.Reason)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// storePendingImport keeps a dry-run import until it is confirmed, cancelled or expires, returning its ID
+func (b *Bot) storePendingImport(p *pendingImport) string {
+	buf := make([]byte, 8)
+	rand.Read(buf)
+	id := hex.EncodeToString(buf)
+
+	b.importsMu.Lock()
+	defer b.importsMu.Unlock()
+
+	if b.pendingImports == nil {
+		b.pendingImports = make(map[string]*pendingImport)
+	}
+	for key, old := range b.pendingImports {
+		if b.clock.Now().Sub(old.CreatedAt) > pendingImportTTL {
+			delete(b.pendingImports, key)
+		}
+	}
+	b.pendingImports[id] = p
+	return id
+}
+
+// takePendingImport removes and returns a pending import if it exists, hasn't expired and belongs to this user
+func (b *Bot) takePendingImport(id, guildID, userID string) (*pendingImport, bool) {
+	b.importsMu.Lock()
+	defer b.importsMu.Unlock()
+
+	p, ok := b.pendingImports[id]
+	if !ok || p.GuildID != guildID || p.UserID != userID {
+		return nil, false
+	}
+	delete(b.pendingImports, id)
+	if b.clock.Now().Sub(p.CreatedAt) > pendingImportTTL {
+		return nil, false
+	}
+	return p, true
+}
+
+// handleImportConfirm applies a previewed import
+func (b *Bot) handleImportConfirm(s *discordgo.Session, i *discordgo.InteractionCreate, id string) {
+	loc := b.interactionLocale(i)
+	p, ok := b.takePendingImport(id, i.GuildID, i.Member.User.ID)
+	if !ok {
+		updateComponentMessage(s, i, "❌ "+i18n.T(loc, "import.expired"))
+		return
+	}
+
+	imported, failed, settingsResult := b.applyImport(context.Background(), loc, p.Plan, p.Diff)
+	slog.Info("Applied confirmed import", "guild_id", i.GuildID, "source", p.Plan.Source, "imported", imported, "errors", failed)
+
+	updateComponentMessage(s, i, formatImportResult(loc, p.Plan, p.Diff, imported, failed, settingsResult))
+}
+
+// handleImportCancel discards a previewed import
+func (b *Bot) handleImportCancel(s *discordgo.Session, i *discordgo.InteractionCreate, id string) {
+	b.takePendingImport(id, i.GuildID, i.Member.User.ID)
+	updateComponentMessage(s, i, i18n.T(b.interactionLocale(i), "import.cancelled"))
+}
+
+// formatImportResult summarizes an applied import
+func formatImportResult(loc i18n.Locale, plan *importPlan, diff importDiff, imported, failed int, settingsResult string) string {
+	msg := i18n.T(loc, "import.result",
+		plan.Source, imported, len(diff.New), len(diff.Changed), len(diff.Unchanged), len(plan.Invalid), failed, settingsResult,
+	)
+	if len(plan.Invalid) > 0 {
+		msg += "\n\n" + formatImportErrors(loc, plan.Invalid, 10)
+	}
+	return msg
+}
+
+// updateComponentMessage replaces the message a button belongs to and removes its buttons
+func updateComponentMessage(s *discordgo.Session, i *discordgo.InteractionCreate, content string) {
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    content,
+			Embeds:     []*discordgo.MessageEmbed{},
+			Components: []discordgo.MessageComponent{},
+		},
+	}); err != nil {
+		slog.Error("Failed to update message", "error", err)
+	}
+}
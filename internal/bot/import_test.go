@@ -0,0 +1,98 @@
+package bot
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+)
+
+func TestExportCSVRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeExportCSV(&buf, newGuildExport(testGuild, nil, testExportBirthdays(), testNow)); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := parseImportFile(buf.Bytes(), "", importContext{GuildID: testGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Invalid) != 0 {
+		t.Fatalf("unexpected invalid rows: %+v", plan.Invalid)
+	}
+	if !reflect.DeepEqual(plan.Birthdays, testExportBirthdays()) {
+		t.Errorf("round trip:\n got: %+v\nwant: %+v", plan.Birthdays, testExportBirthdays())
+	}
+}
+
+func TestDiffImport(t *testing.T) {
+	existing := []database.MemberBirthday{
+		{UserID: "100", Month: 3, Day: 14, Year: intPtr(1990), Timezone: "Europe/Berlin"},
+		{UserID: "200", Month: 5, Day: 1, Timezone: "Asia/Tokyo"},
+		{UserID: "300", Month: 7, Day: 4, Timezone: "UTC"},
+		{UserID: "500", Month: 9, Day: 9, Timezone: "UTC", DMGreeting: true},
+		{UserID: "600", Month: 9, Day: 9, Timezone: "UTC", CalendarOptOut: true},
+	}
+	plan := &importPlan{
+		Birthdays: []database.MemberBirthday{
+			{UserID: "100", Month: 3, Day: 14, Year: intPtr(1990), Timezone: "Europe/Berlin"}, // unchanged
+			{UserID: "200", Month: 5, Day: 1},                                                 // no timezone keeps Asia/Tokyo
+			{UserID: "300", Month: 7, Day: 4, Year: intPtr(2001), Timezone: "UTC"},            // year added
+			{UserID: "400", Month: 1, Day: 1},                                                 // new, gets default timezone
+			{UserID: "500", Month: 9, Day: 9, Timezone: "UTC"},                                // greeting turned off
+			{UserID: "600", Month: 9, Day: 9, Timezone: "UTC"},                                // same preferences
+		},
+		Preferences: map[string]importPreferences{
+			"500": {DMGreeting: boolPtr(false)},
+			"600": {CalendarOptOut: boolPtr(true)},
+		},
+	}
+
+	diff := diffImport(plan, existing, "America/Chicago")
+
+	ids := func(birthdays []database.MemberBirthday) []string {
+		var out []string
+		for _, mb := range birthdays {
+			out = append(out, mb.UserID+"@"+mb.Timezone)
+		}
+		return out
+	}
+	if got, want := ids(diff.New), []string{"400@America/Chicago"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("new = %v, want %v", got, want)
+	}
+	if got, want := ids(diff.Changed), []string{"300@UTC", "500@UTC"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changed = %v, want %v", got, want)
+	}
+	if got, want := ids(diff.Unchanged), []string{"100@Europe/Berlin", "200@Asia/Tokyo", "600@UTC"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("unchanged = %v, want %v", got, want)
+	}
+}
+
+func TestPendingImport(t *testing.T) {
+	clk := clock.NewFake(testNow)
+	b := newTestBot(newFakeDiscord(), newFakeStore(), clk)
+
+	newPending := func() *pendingImport {
+		return &pendingImport{GuildID: testGuild, UserID: testUser, Plan: &importPlan{}, CreatedAt: clk.Now()}
+	}
+
+	id := b.storePendingImport(newPending())
+	if _, ok := b.takePendingImport(id, testGuild, "someone-else"); ok {
+		t.Error("another user must not confirm the import")
+	}
+	if _, ok := b.takePendingImport(id, testGuild, testUser); !ok {
+		t.Error("owner should be able to confirm the import")
+	}
+	if _, ok := b.takePendingImport(id, testGuild, testUser); ok {
+		t.Error("an import must only be applied once")
+	}
+
+	id = b.storePendingImport(newPending())
+	clk.Advance(pendingImportTTL + time.Minute)
+	if _, ok := b.takePendingImport(id, testGuild, testUser); ok {
+		t.Error("expired import should not be confirmable")
+	}
+}
@@ -0,0 +1,283 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/dateparse"
+	"github.com/bwmarrin/discordgo"
+)
+
+// importContext is what an importer knows about the guild being imported into
+type importContext struct {
+	GuildID  string
+	Existing *database.GuildSettings // nil if the guild has no settings yet
+	Dates    dateparse.Options       // today and the age range, the same rules /birthday set uses
+}
+
+// dateOptions returns the rules imported birthdays must follow, reading ambiguous dates in the guild's format
+func (ic importContext) dateOptions() dateparse.Options {
+	opts := ic.Dates
+	opts.DayFirst = ic.formatSettings().EuropeanDateFormat
+	return opts
+}
+
+// formatSettings returns the guild's date format preferences for ambiguous dates
+func (ic importContext) formatSettings() FormatSettings {
+	if ic.Existing == nil {
+		return FormatSettings{}
+	}
+	return FormatSettings{
+		EuropeanDateFormat: ic.Existing.EuropeanDateFormat,
+		Use24hTime:         ic.Existing.Use24hTime,
+	}
+}
+
+// birthdayImporter parses one file format into an import plan
+type birthdayImporter interface {
+	// Name is the value of the /bdset import format option
+	Name() string
+	// Label describes the format to users
+	Label() string
+	// Detect reports whether data looks like this format
+	Detect(data []byte) bool
+	// Parse converts data into birthdays, recording unusable rows as invalid rather than failing
+	Parse(data []byte, ic importContext) (*importPlan, error)
+}
+
+// importers lists the supported formats in detection order, most specific first.
+// The last entry is the fallback when nothing else matches.
+var importers = []birthdayImporter{
+	nativeImporter{},
+	redBotImporter{},
+	icalImporter{},
+	sheetsImporter{},
+	csvImporter{},
+}
+
+// findImporter looks up an importer by its format option name
+func findImporter(name string) (birthdayImporter, bool) {
+	for _, imp := range importers {
+		if imp.Name() == name {
+			return imp, true
+		}
+	}
+	return nil, false
+}
+
+// detectImporter picks the first importer that recognizes data
+func detectImporter(data []byte) birthdayImporter {
+	for _, imp := range importers {
+		if imp.Detect(data) {
+			return imp
+		}
+	}
+	return importers[len(importers)-1]
+}
+
+// parseImportFile parses data with the named importer, or detects the format when format is empty or "auto"
+func parseImportFile(data []byte, format string, ic importContext) (*importPlan, error) {
+	var imp birthdayImporter
+	if format == "" || format == "auto" {
+		imp = detectImporter(data)
+	} else {
+		var ok bool
+		if imp, ok = findImporter(format); !ok {
+			return nil, fmt.Errorf("unknown import format %q", format)
+		}
+	}
+
+	plan, err := imp.Parse(data, ic)
+	if err != nil {
+		return nil, err
+	}
+	plan.Source = imp.Label()
+	return plan, nil
+}
+
+// importFormatChoices builds the /bdset import format option choices from the registry
+func importFormatChoices() []*discordgo.ApplicationCommandOptionChoice {
+	choices := []*discordgo.ApplicationCommandOptionChoice{{Name: "Detect automatically", Value: "auto"}}
+	for _, imp := range importers {
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{Name: imp.Label(), Value: imp.Name()})
+	}
+	return choices
+}
+
+// snowflakePattern matches a Discord ID anywhere in a string
+var snowflakePattern = regexp.MustCompile(`\d{17,20}`)
+
+// findUserID extracts a Discord user ID from an ID, a mention like <@123> or free text
+func findUserID(s string) string {
+	s = strings.TrimSpace(s)
+	if _, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return s
+	}
+	return snowflakePattern.FindString(s)
+}
+
+// nativeImporter reads the bot's own /bdset export JSON
+type nativeImporter struct{}
+
+func (nativeImporter) Name() string  { return "native" }
+func (nativeImporter) Label() string { return "Cyan Birthdays export" }
+
+func (nativeImporter) Detect(data []byte) bool {
+	_, ok, _ := parseGuildExport(data)
+	return ok
+}
+
+func (nativeImporter) Parse(data []byte, ic importContext) (*importPlan, error) {
+	export, ok, err := parseGuildExport(data)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("not a Cyan Birthdays export")
+	}
+
+	plan := &importPlan{Preferences: export.memberPreferences(), dates: ic.dateOptions()}
+	for idx, mb := range export.memberBirthdays(ic.GuildID) {
+		plan.addBirthday(idx+1, mb)
+	}
+
+	// Settings the file leaves out keep their current values, or the defaults for a new guild
+	base := ic.Existing
+	if base == nil {
+		base = database.DefaultGuildSettings(ic.GuildID)
+	}
+	settings, err := export.guildSettings(ic.GuildID, base)
+	switch {
+	case err != nil:
+		return nil, err
+	case settings == nil:
+	case export.GuildID != ic.GuildID:
+		// Channel and role IDs only make sense in the guild they were exported from
+		plan.SettingsNote = "import.settings_other_server"
+	default:
+		plan.setSettings(settings)
+	}
+	return plan, nil
+}
+
+// redBotData is the data stored by the RedBot Birthday cog, nested under a cog ID
+type redBotData struct {
+	Global json.RawMessage `json:"GLOBAL"`
+	Guild  map[string]struct {
+		TimeUTC            int    `json:"time_utc_s"` // seconds after midnight UTC
+		MessageWithYear    string `json:"message_w_year"`
+		MessageWithoutYear string `json:"message_wo_year"`
+		ChannelID          int64  `json:"channel_id"`
+		RoleID             int64  `json:"role_id"`
+		SetupState         int    `json:"setup_state"`
+		RequireRole        int64  `json:"require_role"`
+		AllowRoleMention   bool   `json:"allow_role_mention"`
+	} `json:"GUILD"`
+	Member map[string]map[string]struct {
+		Birthday struct {
+			Year  *int `json:"year"`
+			Month int  `json:"month"`
+			Day   int  `json:"day"`
+		} `json:"birthday"`
+	} `json:"MEMBER"`
+}
+
+// findRedBotData looks for the cog data inside the top-level cog ID object
+func findRedBotData(data []byte) (*redBotData, bool) {
+	var cogData map[string]json.RawMessage
+	if err := json.Unmarshal(data, &cogData); err != nil {
+		return nil, false
+	}
+	for _, v := range cogData {
+		var rootData redBotData
+		if err := json.Unmarshal(v, &rootData); err == nil && (rootData.Guild != nil || rootData.Member != nil) {
+			return &rootData, true
+		}
+	}
+	return nil, false
+}
+
+// redBotImporter reads RedBot Birthday cog JSON. Birthdays get no timezone since the cog has none.
+type redBotImporter struct{}
+
+func (redBotImporter) Name() string  { return "redbot" }
+func (redBotImporter) Label() string { return "RedBot Birthday cog" }
+
+func (redBotImporter) Detect(data []byte) bool {
+	_, ok := findRedBotData(data)
+	return ok
+}
+
+func (redBotImporter) Parse(data []byte, ic importContext) (*importPlan, error) {
+	rootData, ok := findRedBotData(data)
+	if !ok {
+		return nil, errors.New("could not find valid birthday cog data in JSON")
+	}
+
+	plan := &importPlan{dates: ic.dateOptions()}
+
+	// Only import members of the current guild, in a stable order so row numbers are meaningful
+	members := rootData.Member[ic.GuildID]
+	userIDs := make([]string, 0, len(members))
+	for userID := range members {
+		userIDs = append(userIDs, userID)
+	}
+	sort.Strings(userIDs)
+
+	for idx, userID := range userIDs {
+		data := members[userID]
+		var year *int
+		if data.Birthday.Year != nil && *data.Birthday.Year > 0 {
+			year = data.Birthday.Year
+		}
+		plan.addBirthday(idx+1, database.MemberBirthday{
+			GuildID: ic.GuildID,
+			UserID:  userID,
+			Month:   data.Birthday.Month,
+			Day:     data.Birthday.Day,
+			Year:    year,
+		})
+	}
+
+	// Import guild settings if they exist for this guild
+	if guildConfig, exists := rootData.Guild[ic.GuildID]; exists {
+		channelID := strconv.FormatInt(guildConfig.ChannelID, 10)
+		roleID := strconv.FormatInt(guildConfig.RoleID, 10)
+
+		// Keep settings the RedBot cog doesn't know about, or use the defaults for a new guild
+		importedGS := database.DefaultGuildSettings(ic.GuildID)
+		if ic.Existing != nil {
+			current := *ic.Existing
+			importedGS = &current
+		}
+		importedGS.ChannelID = &channelID
+		importedGS.RoleID = &roleID
+		importedGS.TimeUTC = guildConfig.TimeUTC / 3600
+		importedGS.MessageWithYear = guildConfig.MessageWithYear
+		importedGS.MessageWithoutYear = guildConfig.MessageWithoutYear
+		importedGS.AllowRoleMention = guildConfig.AllowRoleMention
+		importedGS.RequiredRoleID = nil
+		importedGS.SetupComplete = guildConfig.SetupState >= 5
+
+		if guildConfig.RequireRole > 0 {
+			reqRole := strconv.FormatInt(guildConfig.RequireRole, 10)
+			importedGS.RequiredRoleID = &reqRole
+		}
+
+		plan.setSettings(importedGS)
+	}
+
+	return plan, nil
+}
+
+// looksLikeJSON reports whether data starts like a JSON object
+func looksLikeJSON(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
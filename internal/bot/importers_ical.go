@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+)
+
+// icalUserIDProperty carries the Discord user ID on events in calendars we generate
+const icalUserIDProperty = "X-DISCORD-USER-ID"
+
+// icalNoYear is the placeholder birth year some calendar apps use when the year is unknown
+const icalNoYear = 1604
+
+// icalImporter reads yearly events from an iCalendar (.ics) file. The Discord user ID comes from
+// X-DISCORD-USER-ID, or the first Discord ID found in the UID, URL, DESCRIPTION or SUMMARY.
+type icalImporter struct{}
+
+func (icalImporter) Name() string  { return "ical" }
+func (icalImporter) Label() string { return "iCalendar" }
+
+func (icalImporter) Detect(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("BEGIN:VCALENDAR"))
+}
+
+// icalProperty is a single unfolded content line
+type icalProperty struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+func (icalImporter) Parse(data []byte, ic importContext) (*importPlan, error) {
+	props, err := parseICalProperties(data)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &importPlan{dates: ic.dateOptions()}
+	var event map[string]icalProperty
+	row := 0
+	for _, prop := range props {
+		switch {
+		case prop.Name == "BEGIN" && prop.Value == "VEVENT":
+			event = make(map[string]icalProperty)
+			row++
+		case prop.Name == "END" && prop.Value == "VEVENT":
+			if event != nil {
+				addICalEvent(plan, row, event, ic.GuildID)
+			}
+			event = nil
+		case event != nil:
+			// Keep the first occurrence, like the rest of the importers
+			if _, ok := event[prop.Name]; !ok {
+				event[prop.Name] = prop
+			}
+		}
+	}
+
+	if row == 0 {
+		return nil, fmt.Errorf("calendar has no events")
+	}
+	return plan, nil
+}
+
+// addICalEvent converts one VEVENT into a birthday row
+func addICalEvent(plan *importPlan, row int, event map[string]icalProperty, guildID string) {
+	userID := strings.TrimSpace(event[icalUserIDProperty].Value)
+	if userID == "" {
+		for _, name := range []string{"UID", "URL", "DESCRIPTION", "SUMMARY"} {
+			if userID = findUserID(event[name].Value); userID != "" {
+				break
+			}
+		}
+	}
+	if userID == "" {
+		plan.Invalid = append(plan.Invalid, importRowError{Row: row, Reason: fmt.Sprintf("no Discord user ID in event %q", event["SUMMARY"].Value)})
+		return
+	}
+
+	if !strings.Contains(strings.ToUpper(event["RRULE"].Value), "FREQ=YEARLY") {
+		plan.Invalid = append(plan.Invalid, importRowError{Row: row, UserID: userID, Reason: "not a yearly event"})
+		return
+	}
+
+	start, ok := event["DTSTART"]
+	if !ok {
+		plan.Invalid = append(plan.Invalid, importRowError{Row: row, UserID: userID, Reason: "missing DTSTART"})
+		return
+	}
+	// DTSTART is YYYYMMDD, optionally followed by a time
+	date, _, _ := strings.Cut(start.Value, "T")
+	if len(date) != 8 {
+		plan.Invalid = append(plan.Invalid, importRowError{Row: row, UserID: userID, Reason: fmt.Sprintf("invalid DTSTART %q", start.Value)})
+		return
+	}
+	y, err1 := strconv.Atoi(date[0:4])
+	m, err2 := strconv.Atoi(date[4:6])
+	d, err3 := strconv.Atoi(date[6:8])
+	if err1 != nil || err2 != nil || err3 != nil {
+		plan.Invalid = append(plan.Invalid, importRowError{Row: row, UserID: userID, Reason: fmt.Sprintf("invalid DTSTART %q", start.Value)})
+		return
+	}
+
+	var year *int
+	if _, omit := event["X-APPLE-OMIT-YEAR"]; !omit && y != icalNoYear {
+		year = &y
+	}
+
+	mb := database.MemberBirthday{GuildID: guildID, UserID: userID, Month: m, Day: d, Year: year}
+	if tzid := start.Params["TZID"]; timezone.ValidateTimezone(tzid) {
+		mb.Timezone = tzid
+	}
+	plan.addBirthday(row, mb)
+}
+
+// parseICalProperties unfolds continuation lines and splits each line into name, parameters and value
+func parseICalProperties(data []byte) ([]icalProperty, error) {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	props := make([]icalProperty, 0, len(lines))
+	for _, line := range lines {
+		head, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(head, ";")
+		prop := icalProperty{
+			Name:   strings.ToUpper(parts[0]),
+			Params: make(map[string]string),
+			Value:  unescapeICalText(value),
+		}
+		for _, param := range parts[1:] {
+			if k, v, ok := strings.Cut(param, "="); ok {
+				prop.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+			}
+		}
+		props = append(props, prop)
+	}
+	return props, nil
+}
+
+// unescapeICalText reverses iCalendar TEXT escaping
+func unescapeICalText(s string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
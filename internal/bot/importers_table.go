@@ -0,0 +1,280 @@
+package bot
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/dateparse"
+)
+
+// headerSearchRows is how many leading rows may be titles or notes before the header row
+const headerSearchRows = 10
+
+// tableColumnAliases maps normalized header names to the birthday field they hold
+var tableColumnAliases = map[string]string{
+	"userid": "user", "user": "user", "id": "user", "discordid": "user", "discorduserid": "user",
+	"memberid": "user", "member": "user", "discord": "user", "snowflake": "user",
+	"date": "date", "birthday": "date", "birthdate": "date", "dateofbirth": "date", "dob": "date", "bday": "date",
+	"month": "month", "birthmonth": "month",
+	"day": "day", "birthdayday": "day",
+	"year": "year", "birthyear": "year",
+	"timezone": "timezone", "tz": "timezone", "timezonename": "timezone", "zone": "timezone",
+}
+
+// tableLayout holds the column index of each birthday field, or -1 if the table doesn't have it
+type tableLayout struct {
+	User, Date, Month, Day, Year, Timezone int
+}
+
+// positionalLayout is used for tables without a header: user_id, date, timezone
+var positionalLayout = tableLayout{User: 0, Date: 1, Month: -1, Day: -1, Year: -1, Timezone: 2}
+
+// csvImporter reads comma or semicolon separated tables, including our own CSV export
+type csvImporter struct{}
+
+func (csvImporter) Name() string  { return "csv" }
+func (csvImporter) Label() string { return "CSV" }
+
+func (csvImporter) Detect(data []byte) bool {
+	return !looksLikeJSON(data)
+}
+
+func (csvImporter) Parse(data []byte, ic importContext) (*importPlan, error) {
+	delimiter := ','
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	if bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(",")) {
+		delimiter = ';'
+	}
+	return parseBirthdayTable(data, delimiter, ic)
+}
+
+// sheetsImporter reads tab separated tables as copied or downloaded from Google Sheets or Excel,
+// which may have title rows above the header and dates in the guild's display format
+type sheetsImporter struct{}
+
+func (sheetsImporter) Name() string  { return "sheets" }
+func (sheetsImporter) Label() string { return "Spreadsheet (tab separated)" }
+
+func (sheetsImporter) Detect(data []byte) bool {
+	firstLine, _, _ := bytes.Cut(bytes.TrimLeft(data, "\r\n"), []byte("\n"))
+	return !looksLikeJSON(data) && bytes.ContainsRune(firstLine, '\t')
+}
+
+func (sheetsImporter) Parse(data []byte, ic importContext) (*importPlan, error) {
+	return parseBirthdayTable(data, '\t', ic)
+}
+
+// parseBirthdayTable parses delimited rows into an import plan. Columns are found from a header row
+// within the first few rows; without one, rows are read as user_id, date, timezone.
+func parseBirthdayTable(data []byte, delimiter rune, ic importContext) (*importPlan, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = delimiter
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	r.LazyQuotes = true
+
+	var records [][]string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	layout, start := positionalLayout, 0
+	for idx := 0; idx < len(records) && idx < headerSearchRows; idx++ {
+		if l, ok := headerLayout(records[idx]); ok {
+			layout, start = l, idx+1
+			break
+		}
+	}
+
+	plan := &importPlan{dates: ic.dateOptions()}
+	for idx := start; idx < len(records); idx++ {
+		row := idx + 1
+		record := records[idx]
+		if isBlankRecord(record) {
+			continue
+		}
+
+		rawUser := tableField(record, layout.User)
+		userID := findUserID(rawUser)
+		if userID == "" {
+			userID = rawUser
+		}
+
+		month, day, year, err := tableDate(record, layout, plan.dates)
+		if err != nil {
+			plan.Invalid = append(plan.Invalid, importRowError{Row: row, UserID: userID, Reason: err.Error()})
+			continue
+		}
+
+		plan.addBirthday(row, database.MemberBirthday{
+			GuildID:  ic.GuildID,
+			UserID:   userID,
+			Month:    month,
+			Day:      day,
+			Year:     year,
+			Timezone: tableField(record, layout.Timezone),
+		})
+	}
+	return plan, nil
+}
+
+// headerLayout recognizes a header row; it needs a user column and either a date or month and day columns
+func headerLayout(record []string) (tableLayout, bool) {
+	layout := tableLayout{User: -1, Date: -1, Month: -1, Day: -1, Year: -1, Timezone: -1}
+	for idx, name := range record {
+		var col *int
+		switch tableColumnAliases[normalizeHeader(name)] {
+		case "user":
+			col = &layout.User
+		case "date":
+			col = &layout.Date
+		case "month":
+			col = &layout.Month
+		case "day":
+			col = &layout.Day
+		case "year":
+			col = &layout.Year
+		case "timezone":
+			col = &layout.Timezone
+		default:
+			continue
+		}
+		if *col == -1 {
+			*col = idx
+		}
+	}
+
+	ok := layout.User >= 0 && (layout.Date >= 0 || (layout.Month >= 0 && layout.Day >= 0))
+	return layout, ok
+}
+
+// normalizeHeader lowercases a header and drops everything but letters, so "Discord ID" matches "discordid"
+func normalizeHeader(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, s)
+}
+
+// tableField returns a trimmed column value, or "" if the row is too short or the column is absent
+func tableField(record []string, col int) string {
+	if col < 0 || col >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[col])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, field := range record {
+		if strings.TrimSpace(field) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// tableDate reads a row's birthday from either a date column or separate month/day/year columns
+func tableDate(record []string, layout tableLayout, opts dateparse.Options) (month, day int, year *int, err error) {
+	if layout.Date >= 0 {
+		if s := tableField(record, layout.Date); s != "" {
+			return parseTableDate(s, opts)
+		}
+		if layout.Month < 0 {
+			return 0, 0, nil, fmt.Errorf("missing date")
+		}
+	}
+
+	monthStr, dayStr := tableField(record, layout.Month), tableField(record, layout.Day)
+	if monthStr == "" || dayStr == "" {
+		return 0, 0, nil, fmt.Errorf("missing month or day")
+	}
+	month, err = parseMonth(monthStr)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	day, err = strconv.Atoi(dayStr)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid day %q", dayStr)
+	}
+	if yearStr := tableField(record, layout.Year); yearStr != "" && yearStr != "0" {
+		y, err := strconv.Atoi(yearStr)
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("invalid year %q", yearStr)
+		}
+		year = &y
+	}
+	return month, day, year, nil
+}
+
+// parseTableDate accepts the export's ISO dates first, then anything a user could type into /birthday set
+func parseTableDate(s string, opts dateparse.Options) (month, day int, year *int, err error) {
+	if month, day, year, err := parseImportDate(s); err == nil && dateparse.Validate(dateparse.Date{Month: month, Day: day, Year: year}, opts) == nil {
+		return month, day, year, nil
+	}
+	d, err := preferredDate(dateparse.Parse(s, opts))
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid date %q", s)
+	}
+	return d.Month, d.Day, d.Year, nil
+}
+
+// parseMonth accepts a month number or an English month name or abbreviation
+func parseMonth(s string) (int, error) {
+	if m, err := strconv.Atoi(s); err == nil {
+		return m, nil
+	}
+	lower := strings.ToLower(s)
+	if len(lower) >= 3 {
+		for m := time.January; m <= time.December; m++ {
+			if strings.HasPrefix(strings.ToLower(m.String()), lower) {
+				return int(m), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("invalid month %q", s)
+}
+
+// parseImportDate parses YYYY-MM-DD, MM-DD or --MM-DD
+func parseImportDate(s string) (month, day int, year *int, err error) {
+	parts := strings.Split(strings.TrimPrefix(s, "--"), "-")
+	if len(parts) == 3 {
+		y, err := strconv.Atoi(parts[0])
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("invalid date %q", s)
+		}
+		year = &y
+		parts = parts[1:]
+	}
+	if len(parts) != 2 {
+		return 0, 0, nil, fmt.Errorf("invalid date %q, expected YYYY-MM-DD or MM-DD", s)
+	}
+
+	month, err = strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid date %q", s)
+	}
+	day, err = strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("invalid date %q", s)
+	}
+	return month, day, year, nil
+}
@@ -0,0 +1,317 @@
+package bot
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/dateparse"
+)
+
+const testICal = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:birthday-100000000000000001@example.com\r\n" +
+	"SUMMARY:Alice's birthday\r\n" +
+	"DTSTART;VALUE=DATE:19900314\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:abc\r\n" +
+	"X-DISCORD-USER-ID:100000000000000002\r\n" +
+	"DTSTART;TZID=Europe/Berlin:16040229T000000\r\n" +
+	"RRULE:FREQ=YEARLY;BYMONTH=2\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:abc2\r\n" +
+	"DESCRIPTION:Birthday of https://discord.com/users/1000000000000000\r\n" +
+	" 03\r\n" +
+	"DTSTART;VALUE=DATE:20011225\r\n" +
+	"X-APPLE-OMIT-YEAR:2001\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:meeting\r\n" +
+	"SUMMARY:Team meeting\r\n" +
+	"DTSTART:20260101T100000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:100000000000000004\r\n" +
+	"SUMMARY:Weekly sync\r\n" +
+	"DTSTART:20260101T100000Z\r\n" +
+	"RRULE:FREQ=WEEKLY\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestDetectImporter(t *testing.T) {
+	var native bytes.Buffer
+	if err := writeExportJSON(&native, newGuildExport(testGuild, nil, testExportBirthdays(), testNow)); err != nil {
+		t.Fatal(err)
+	}
+
+	redbot := `{"1234": {"MEMBER": {"` + testGuild + `": {"100": {"birthday": {"year": 1990, "month": 3, "day": 14}}}}}}`
+
+	tests := []struct {
+		name       string
+		data       string
+		wantSource string
+		wantCount  int
+	}{
+		{"native export", native.String(), "Cyan Birthdays export", 2},
+		{"RedBot cog", redbot, "RedBot Birthday cog", 1},
+		{"iCalendar", testICal, "iCalendar", 3},
+		{"spreadsheet", "Discord ID\tBirthday\n100\t3/14/1990\n", "Spreadsheet (tab separated)", 1},
+		{"CSV", "user_id,date,timezone\n100,1990-03-14,Europe/Berlin\n", "CSV", 1},
+		{"headerless CSV", "100,03-14\n", "CSV", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := parseImportFile([]byte(tt.data), "auto", importContext{GuildID: testGuild})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if plan.Source != tt.wantSource || len(plan.Birthdays) != tt.wantCount {
+				t.Errorf("got %s with %d birthdays (%+v), want %s with %d", plan.Source, len(plan.Birthdays), plan.Invalid, tt.wantSource, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestParseImportFileExplicitFormat(t *testing.T) {
+	// A tab separated file forced through the CSV importer is a single column per row
+	plan, err := parseImportFile([]byte("100\t03-14\n"), "csv", importContext{GuildID: testGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Source != "CSV" || len(plan.Birthdays) != 0 || len(plan.Invalid) != 1 {
+		t.Errorf("got %s with %d birthdays and %d invalid rows", plan.Source, len(plan.Birthdays), len(plan.Invalid))
+	}
+
+	if _, err := parseImportFile([]byte("{}"), "native", importContext{GuildID: testGuild}); err == nil {
+		t.Error("expected error forcing a non-export file through the native importer")
+	}
+	if _, err := parseImportFile([]byte("x"), "nope", importContext{GuildID: testGuild}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestImportSettingsValidation(t *testing.T) {
+	native := `{"format": "cyan-birthdays", "version": 1, "guild_id": "` + testGuild + `",
+		"settings": {"announcement_hour": 25, "message_without_year": "Happy {new_age}th {mention}!",
+			"leap_day_policy": "never", "language": "xx"},
+		"birthdays": [{"user_id": "100", "month": 3, "day": 14, "timezone": "UTC"}]}`
+	plan, err := parseImportFile([]byte(native), "native", importContext{GuildID: testGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Settings != nil || plan.SettingsNote != "import.settings_invalid" {
+		t.Errorf("settings = %+v (note %q), want them skipped as invalid", plan.Settings, plan.SettingsNote)
+	}
+	if len(plan.Birthdays) != 1 || len(plan.Invalid) != 4 {
+		t.Errorf("got %d birthdays and invalid %+v, want 1 birthday and 4 invalid settings", len(plan.Birthdays), plan.Invalid)
+	}
+	if msg := formatImportErrors("en", plan.Invalid, 10); !strings.Contains(msg, "Settings: invalid announcement hour 25") {
+		t.Errorf("errors = %q, want the invalid hour listed under Settings", msg)
+	}
+
+	// The RedBot cog stores the announcement time in seconds
+	redbot := `{"1234": {"GUILD": {"` + testGuild + `": {"time_utc_s": 32400, "channel_id": 1, "role_id": 2,
+		"message_w_year": "{mention} is {new_age}!", "message_wo_year": "Happy birthday {mention}!"}}}}`
+	plan, err = parseImportFile([]byte(redbot), "redbot", importContext{GuildID: testGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Settings == nil || plan.Settings.TimeUTC != 9 || len(plan.Invalid) != 0 {
+		t.Fatalf("settings = %+v, invalid %+v, want valid settings announcing at 9", plan.Settings, plan.Invalid)
+	}
+
+	redbot = strings.Replace(redbot, "Happy birthday", "Happy {new_age}th birthday", 1)
+	plan, err = parseImportFile([]byte(redbot), "redbot", importContext{GuildID: testGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan.Settings != nil || len(plan.Invalid) != 1 {
+		t.Errorf("settings = %+v, invalid %+v, want the message without year rejected", plan.Settings, plan.Invalid)
+	}
+}
+
+func TestImportFormatChoicesCoverRegistry(t *testing.T) {
+	choices := importFormatChoices()
+	if len(choices) != len(importers)+1 {
+		t.Fatalf("got %d choices for %d importers", len(choices), len(importers))
+	}
+	for _, c := range choices[1:] {
+		if _, ok := findImporter(c.Value.(string)); !ok {
+			t.Errorf("choice %q has no importer", c.Value)
+		}
+	}
+}
+
+func TestCSVImporter(t *testing.T) {
+	data := "user_id,date,timezone\n" +
+		"100,1990-03-14,Europe/Berlin\n" +
+		"200,02-29\n" +
+		"300,--12-25,\n" +
+		"400,02-30,UTC\n" +
+		"500,1990-03-14,Mars/Olympus\n" +
+		"not-a-user,01-01,UTC\n" +
+		"600,March 14,UTC\n" +
+		"700\n" +
+		"\n"
+
+	plan, err := csvImporter{}.Parse([]byte(data), importContext{GuildID: testGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantBirthdays := []database.MemberBirthday{
+		{GuildID: testGuild, UserID: "100", Month: 3, Day: 14, Year: intPtr(1990), Timezone: "Europe/Berlin"},
+		{GuildID: testGuild, UserID: "200", Month: 2, Day: 29},
+		{GuildID: testGuild, UserID: "300", Month: 12, Day: 25},
+		{GuildID: testGuild, UserID: "600", Month: 3, Day: 14, Timezone: "UTC"},
+	}
+	if !reflect.DeepEqual(plan.Birthdays, wantBirthdays) {
+		t.Errorf("birthdays:\n got: %+v\nwant: %+v", plan.Birthdays, wantBirthdays)
+	}
+
+	var invalidRows []int
+	for _, re := range plan.Invalid {
+		invalidRows = append(invalidRows, re.Row)
+	}
+	if want := []int{5, 6, 7, 9}; !reflect.DeepEqual(invalidRows, want) {
+		t.Errorf("invalid rows = %v, want %v (%+v)", invalidRows, want, plan.Invalid)
+	}
+}
+
+func TestImportDateRules(t *testing.T) {
+	data := "user_id,month,day,year\n" +
+		"100,2,29,2023\n" + // not a leap year
+		"200,1,1,2027\n" + // in the future
+		"300,5,5,2020\n" + // younger than the minimum age
+		"400,5,5,1800\n" + // older than the maximum age
+		"500,2,29,2008\n" +
+		"600,5,5,2010\n"
+	ic := importContext{GuildID: testGuild, Dates: dateparse.Options{Today: testNow, MinAge: 13, MaxAge: 100}}
+
+	plan, err := csvImporter{}.Parse([]byte(data), ic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var invalidRows []int
+	for _, re := range plan.Invalid {
+		invalidRows = append(invalidRows, re.Row)
+	}
+	if want := []int{2, 3, 4, 5}; !reflect.DeepEqual(invalidRows, want) {
+		t.Errorf("invalid rows = %v, want %v (%+v)", invalidRows, want, plan.Invalid)
+	}
+
+	// Date columns follow the same rules, whether they hold an ISO date or free text
+	plan, err = csvImporter{}.Parse([]byte("user_id,date\n100,2023-02-29\n200,January 1 2027\n300,2010-05-05\n"), ic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Birthdays) != 1 || plan.Birthdays[0].UserID != "300" || len(plan.Invalid) != 2 {
+		t.Errorf("birthdays = %+v, invalid = %+v, want only user 300 imported", plan.Birthdays, plan.Invalid)
+	}
+}
+
+func TestCSVImporterOtherBotLayouts(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		gs   *database.GuildSettings
+		want []database.MemberBirthday
+	}{
+		{
+			name: "semicolon separated with mentions",
+			data: "Member;Username;Birthday;TZ\n<@100000000000000001>;alice;14.03.1990;Asia/Tokyo\n",
+			gs:   &database.GuildSettings{EuropeanDateFormat: true},
+			want: []database.MemberBirthday{
+				{GuildID: testGuild, UserID: "100000000000000001", Month: 3, Day: 14, Year: intPtr(1990), Timezone: "Asia/Tokyo"},
+			},
+		},
+		{
+			name: "separate month and day columns",
+			data: "discord_id,name,month,day,year\n100,bob,December,7,\n200,carol,2,29,2000\n",
+			want: []database.MemberBirthday{
+				{GuildID: testGuild, UserID: "100", Month: 12, Day: 7},
+				{GuildID: testGuild, UserID: "200", Month: 2, Day: 29, Year: intPtr(2000)},
+			},
+		},
+		{
+			name: "US dates follow the guild format",
+			data: "User ID,Date of Birth\n100,03/04\n",
+			want: []database.MemberBirthday{
+				{GuildID: testGuild, UserID: "100", Month: 3, Day: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := csvImporter{}.Parse([]byte(tt.data), importContext{GuildID: testGuild, Existing: tt.gs})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(plan.Invalid) != 0 {
+				t.Errorf("unexpected invalid rows: %+v", plan.Invalid)
+			}
+			if !reflect.DeepEqual(plan.Birthdays, tt.want) {
+				t.Errorf("birthdays:\n got: %+v\nwant: %+v", plan.Birthdays, tt.want)
+			}
+		})
+	}
+}
+
+func TestSheetsImporter(t *testing.T) {
+	data := "Server birthdays\t\t\n" +
+		"\t\t\n" +
+		"Name\tDiscord ID\tBirthday\tTime Zone\n" +
+		"Alice\t100\tMarch 14, 1990\tEurope/London\n" +
+		"Bob\t\t7 December\t\n" +
+		"Carol\t300\t\t\n"
+
+	plan, err := sheetsImporter{}.Parse([]byte(data), importContext{GuildID: testGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []database.MemberBirthday{
+		{GuildID: testGuild, UserID: "100", Month: 3, Day: 14, Year: intPtr(1990), Timezone: "Europe/London"},
+	}
+	if !reflect.DeepEqual(plan.Birthdays, want) {
+		t.Errorf("birthdays:\n got: %+v\nwant: %+v", plan.Birthdays, want)
+	}
+	if len(plan.Invalid) != 2 || plan.Invalid[0].Row != 5 || plan.Invalid[1].Row != 6 {
+		t.Errorf("invalid rows = %+v, want rows 5 and 6", plan.Invalid)
+	}
+}
+
+func TestICalImporter(t *testing.T) {
+	plan, err := icalImporter{}.Parse([]byte(testICal), importContext{GuildID: testGuild})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []database.MemberBirthday{
+		{GuildID: testGuild, UserID: "100000000000000001", Month: 3, Day: 14, Year: intPtr(1990)},
+		{GuildID: testGuild, UserID: "100000000000000002", Month: 2, Day: 29, Timezone: "Europe/Berlin"},
+		{GuildID: testGuild, UserID: "100000000000000003", Month: 12, Day: 25},
+	}
+	if !reflect.DeepEqual(plan.Birthdays, want) {
+		t.Errorf("birthdays:\n got: %+v\nwant: %+v", plan.Birthdays, want)
+	}
+
+	if len(plan.Invalid) != 2 {
+		t.Fatalf("invalid rows = %+v, want 2", plan.Invalid)
+	}
+	if plan.Invalid[0].Row != 4 || !strings.Contains(plan.Invalid[0].Reason, "no Discord user ID") {
+		t.Errorf("event without a user: %+v", plan.Invalid[0])
+	}
+	if plan.Invalid[1].Row != 5 || plan.Invalid[1].Reason != "not a yearly event" {
+		t.Errorf("weekly event: %+v", plan.Invalid[1])
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"strings"
 
 	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/dateparse"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
 	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
 	"github.com/bwmarrin/discordgo"
 )
@@ -36,7 +38,7 @@ func (b *Bot) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionC
 	// Handle timezone autocomplete
 	if focused.Name == "timezone" {
 		query := focused.StringValue()
-		results := timezone.SearchTimezones(query)
+		results := timezone.SearchTimezones(b.clock, query)
 
 		// Get format settings for the guild
 		ctx := context.Background()
@@ -44,7 +46,7 @@ func (b *Bot) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionC
 
 		choices := make([]*discordgo.ApplicationCommandOptionChoice, 0, len(results))
 		for _, tz := range results {
-			label := timezone.FormatTimezoneChoice(tz, formatSettings.Use24hTime)
+			label := timezone.FormatTimezoneChoice(b.clock, tz, formatSettings.Use24hTime)
 			// Discord has a 100 character limit for choice names
 			if len(label) > 100 {
 				label = label[:97] + "..."
@@ -77,6 +79,8 @@ func (b *Bot) handleModalSubmit(s *discordgo.Session, i *discordgo.InteractionCr
 		b.handleMsgWithoutYearModal(s, i)
 	case data.CustomID == "bdset_interactive_modal":
 		b.handleInteractiveModal(s, i)
+	case data.CustomID == embedModalID:
+		b.handleEmbedModal(s, i)
 	}
 }
 
@@ -99,25 +103,22 @@ func (b *Bot) handleBirthdaySetModal(s *discordgo.Session, i *discordgo.Interact
 	}
 
 	ctx := context.Background()
-	formatSettings := b.GetFormatSettings(ctx, i.GuildID)
-
-	// Parse the date with format settings
-	month, day, year, err := ParseDateWithSettings(dateStr, formatSettings)
-	if err != nil {
-		formatHint := "MM/DD"
-		if formatSettings.EuropeanDateFormat {
-			formatHint = "DD/MM"
-		}
-		respondError(s, i, fmt.Sprintf("Invalid date format. Use formats like: %s, September 24, or %s/2002", formatHint, formatHint))
-		return
-	}
+	formatSettings := b.interactionFormatSettings(ctx, i)
+	loc := formatSettings.Locale
 
 	// Validate timezone
 	if tzStr == "" {
 		tzStr = "UTC"
 	}
 	if !timezone.ValidateTimezone(tzStr) {
-		respondError(s, i, fmt.Sprintf("Invalid timezone: %s. Use IANA format like America/New_York", tzStr))
+		respondError(s, i, i18n.T(loc, "error.invalid_timezone_iana", tzStr))
+		return
+	}
+
+	// Parse the date with format settings, reading "today" in the member's timezone
+	date, err := b.parseMemberDate(dateStr, formatSettings, tzStr)
+	if err != nil {
+		respondError(s, i, b.dateErrorMessage(err, formatSettings))
 		return
 	}
 
@@ -125,27 +126,19 @@ func (b *Bot) handleBirthdaySetModal(s *discordgo.Session, i *discordgo.Interact
 	mb := &database.MemberBirthday{
 		GuildID:  i.GuildID,
 		UserID:   i.Member.User.ID,
-		Month:    month,
-		Day:      day,
-		Year:     year,
+		Month:    date.Month,
+		Day:      date.Day,
+		Year:     date.Year,
 		Timezone: tzStr,
 	}
 
 	if err := b.repo.SetMemberBirthday(ctx, mb); err != nil {
 		slog.Error("Failed to save birthday", "error", err)
-		respondError(s, i, "Failed to save your birthday")
+		respondError(s, i, i18n.T(loc, "birthday.set.failed"))
 		return
 	}
 
-	// Format confirmation using guild settings
-	dateDisplay := FormatDate(month, day, year, formatSettings)
-	currentTime, _ := timezone.GetCurrentTime(tzStr)
-	timeDisplay := FormatTime(currentTime, formatSettings)
-
-	respondEphemeral(s, i, fmt.Sprintf(
-		"🎂 Your birthday has been set to **%s**!\nTimezone: %s (current time: %s)",
-		dateDisplay, tzStr, timeDisplay,
-	))
+	respondEphemeral(s, i, b.birthdaySetMessage(mb, formatSettings))
 }
 
 // handleMsgWithYearModal processes message with year modal
@@ -163,17 +156,23 @@ func (b *Bot) handleMsgWithYearModal(s *discordgo.Session, i *discordgo.Interact
 		}
 	}
 
+	loc := b.interactionLocale(i)
+	if err := validateTemplate(message, true); err != nil {
+		respondError(s, i, i18n.T(loc, "message.invalid", err))
+		return
+	}
+
 	ctx := context.Background()
 	if err := b.repo.UpdateGuildMessageWithYear(ctx, i.GuildID, message); err != nil {
-		respondError(s, i, "Failed to update message")
+		respondError(s, i, i18n.T(loc, "message.update_failed"))
 		return
 	}
 
 	b.checkSetupComplete(ctx, i.GuildID)
 
 	// Show preview
-	preview := formatMessage(message, i.Member.User.Username, i.Member.User.ID, intPtr(25))
-	respondEphemeral(s, i, fmt.Sprintf("✅ Message updated! Preview:\n> %s", preview))
+	preview := b.previewMessage(ctx, i.GuildID, i.Member, message, intPtr(25))
+	respondEphemeral(s, i, i18n.T(loc, "message.updated", preview))
 }
 
 // handleMsgWithoutYearModal processes message without year modal
@@ -191,17 +190,23 @@ func (b *Bot) handleMsgWithoutYearModal(s *discordgo.Session, i *discordgo.Inter
 		}
 	}
 
+	loc := b.interactionLocale(i)
+	if err := validateTemplate(message, false); err != nil {
+		respondError(s, i, i18n.T(loc, "message.invalid", err))
+		return
+	}
+
 	ctx := context.Background()
 	if err := b.repo.UpdateGuildMessageWithoutYear(ctx, i.GuildID, message); err != nil {
-		respondError(s, i, "Failed to update message")
+		respondError(s, i, i18n.T(loc, "message.update_failed"))
 		return
 	}
 
 	b.checkSetupComplete(ctx, i.GuildID)
 
 	// Show preview
-	preview := formatMessage(message, i.Member.User.Username, i.Member.User.ID, nil)
-	respondEphemeral(s, i, fmt.Sprintf("✅ Message updated! Preview:\n> %s", preview))
+	preview := b.previewMessage(ctx, i.GuildID, i.Member, message, nil)
+	respondEphemeral(s, i, i18n.T(loc, "message.updated", preview))
 }
 
 // handleInteractiveModal processes the interactive setup modal
@@ -228,88 +233,101 @@ func (b *Bot) handleInteractiveModal(s *discordgo.Session, i *discordgo.Interact
 		}
 	}
 
+	loc := b.interactionLocale(i)
+	if err := validateTemplate(msgWithYear, true); err != nil {
+		respondError(s, i, i18n.T(loc, "setup.invalid_with_year", err))
+		return
+	}
+	if err := validateTemplate(msgWithoutYear, false); err != nil {
+		respondError(s, i, i18n.T(loc, "setup.invalid_without_year", err))
+		return
+	}
+
 	// Parse hour
 	hour, err := strconv.Atoi(timeStr)
 	if err != nil || hour < 0 || hour > 23 {
-		respondError(s, i, "Invalid hour. Please enter a number between 0 and 23.")
+		respondError(s, i, i18n.T(loc, "setup.invalid_hour"))
 		return
 	}
 
 	// Parse boolean values
-	europeanDate := strings.ToLower(strings.TrimSpace(dateFormatStr)) == "yes"
-	use24hTime := strings.ToLower(strings.TrimSpace(timeFormatStr)) == "yes"
+	europeanDate := isYes(loc, dateFormatStr)
+	use24hTime := isYes(loc, timeFormatStr)
 
 	ctx := context.Background()
 
 	// Update messages
 	if err := b.repo.UpdateGuildMessageWithYear(ctx, i.GuildID, msgWithYear); err != nil {
-		respondError(s, i, "Failed to update settings")
+		respondError(s, i, i18n.T(loc, "setup.failed"))
 		return
 	}
 	if err := b.repo.UpdateGuildMessageWithoutYear(ctx, i.GuildID, msgWithoutYear); err != nil {
-		respondError(s, i, "Failed to update settings")
+		respondError(s, i, i18n.T(loc, "setup.failed"))
 		return
 	}
 	if err := b.repo.UpdateGuildTime(ctx, i.GuildID, hour); err != nil {
-		respondError(s, i, "Failed to update settings")
+		respondError(s, i, i18n.T(loc, "setup.failed"))
 		return
 	}
 	if err := b.repo.UpdateGuildEuropeanDateFormat(ctx, i.GuildID, europeanDate); err != nil {
-		respondError(s, i, "Failed to update settings")
+		respondError(s, i, i18n.T(loc, "setup.failed"))
 		return
 	}
 	if err := b.repo.UpdateGuildUse24hTime(ctx, i.GuildID, use24hTime); err != nil {
-		respondError(s, i, "Failed to update settings")
+		respondError(s, i, i18n.T(loc, "setup.failed"))
 		return
 	}
 
 	b.checkSetupComplete(ctx, i.GuildID)
 
-	dateFormatDisplay := "MM/DD/YYYY"
-	if europeanDate {
-		dateFormatDisplay = "DD/MM/YYYY"
-	}
-	timeFormatDisplay := "12-hour"
-	if use24hTime {
-		timeFormatDisplay = "24-hour"
-	}
-
-	respondEphemeral(s, i, fmt.Sprintf(
-		"✅ Settings saved!\n\n"+
-			"**Announcement hour:** %02d:00\n"+
-			"**Date format:** %s\n"+
-			"**Time format:** %s\n\n"+
-			"Now set the channel and role:\n"+
-			"• `/bdset channel #channel`\n"+
-			"• `/bdset role @role`",
-		hour, dateFormatDisplay, timeFormatDisplay,
-	))
+	respondEphemeral(s, i, i18n.T(loc, "setup.success",
+		hour, formatDateFormatSetting(loc, europeanDate), formatTimeFormatSetting(loc, use24hTime)))
+}
+
+// isYes reports whether a yes/no answer means yes, in English or the locale's language
+func isYes(loc i18n.Locale, answer string) bool {
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "yes" || answer == strings.ToLower(i18n.T(loc, "value.yes"))
 }
 
 // handleComponent handles button and select menu interactions
 func (b *Bot) handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	customID := i.MessageComponentData().CustomID
 
+	if id, ok := strings.CutPrefix(customID, importConfirmPrefix); ok {
+		b.handleImportConfirm(s, i, id)
+		return
+	}
+	if id, ok := strings.CutPrefix(customID, importCancelPrefix); ok {
+		b.handleImportCancel(s, i, id)
+		return
+	}
+	if value, ok := strings.CutPrefix(customID, birthdayPickPrefix); ok {
+		b.handleBirthdayPick(s, i, value)
+		return
+	}
+
+	loc := b.interactionLocale(i)
 	switch customID {
 	case "birthday_remove_confirm":
 		ctx := context.Background()
 		if err := b.repo.DeleteMemberBirthday(ctx, i.GuildID, i.Member.User.ID); err != nil {
-			respondError(s, i, "Failed to remove birthday")
+			respondError(s, i, i18n.T(loc, "birthday.remove.failed"))
 			return
 		}
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseUpdateMessage,
 			Data: &discordgo.InteractionResponseData{
-				Content:    "✅ Your birthday has been removed.",
+				Content:    i18n.T(loc, "birthday.remove.success"),
 				Components: []discordgo.MessageComponent{},
 			},
 		})
 
-	case "birthday_remove_cancel":
+	case "birthday_remove_cancel", "birthday_set_cancel":
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseUpdateMessage,
 			Data: &discordgo.InteractionResponseData{
-				Content:    "Cancelled.",
+				Content:    i18n.T(loc, "common.cancelled"),
 				Components: []discordgo.MessageComponent{},
 			},
 		})
@@ -317,13 +335,13 @@ func (b *Bot) handleComponent(s *discordgo.Session, i *discordgo.InteractionCrea
 	case "bdset_stop_confirm":
 		ctx := context.Background()
 		if err := b.repo.ClearGuildSettings(ctx, i.GuildID); err != nil {
-			respondError(s, i, "Failed to clear settings")
+			respondError(s, i, i18n.T(loc, "stop.failed"))
 			return
 		}
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseUpdateMessage,
 			Data: &discordgo.InteractionResponseData{
-				Content:    "✅ All birthday settings have been cleared.",
+				Content:    i18n.T(loc, "stop.success"),
 				Components: []discordgo.MessageComponent{},
 			},
 		})
@@ -332,24 +350,108 @@ func (b *Bot) handleComponent(s *discordgo.Session, i *discordgo.InteractionCrea
 		s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
 			Type: discordgo.InteractionResponseUpdateMessage,
 			Data: &discordgo.InteractionResponseData{
-				Content:    "Cancelled.",
+				Content:    i18n.T(loc, "common.cancelled"),
 				Components: []discordgo.MessageComponent{},
 			},
 		})
+
+	case embedEditButtonID:
+		e, err := b.guildAnnouncementEmbed(context.Background(), i.GuildID)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "embed.get_failed"))
+			return
+		}
+		s.InteractionRespond(i.Interaction, embedDesignerModal(loc, e))
 	}
 }
 
-// formatMessage replaces placeholders in a birthday message
-func formatMessage(template, name, userID string, age *int) string {
-	result := template
-	result = strings.ReplaceAll(result, "{mention}", "<@"+userID+">")
-	result = strings.ReplaceAll(result, "{name}", name)
-	if age != nil {
-		result = strings.ReplaceAll(result, "{new_age}", strconv.Itoa(*age))
+// birthdayPickPrefix starts the custom ID of the buttons offering each reading of an ambiguous date
+const birthdayPickPrefix = "birthday_set_pick:"
+
+// birthdayPickID encodes a reading of an ambiguous date and the member's timezone as a button ID, as
+// "birthday_set_pick:month:day:year:timezone" with year 0 if none was given
+func birthdayPickID(d dateparse.Date, tz string) string {
+	year := 0
+	if d.Year != nil {
+		year = *d.Year
 	}
-	return result
+	return fmt.Sprintf("%s%d:%d:%d:%s", birthdayPickPrefix, d.Month, d.Day, year, tz)
+}
+
+// parseBirthdayPick decodes the part of a birthdayPickID after the prefix
+func parseBirthdayPick(value string) (dateparse.Date, string, error) {
+	parts := strings.SplitN(value, ":", 4)
+	if len(parts) != 4 {
+		return dateparse.Date{}, "", fmt.Errorf("malformed birthday pick %q", value)
+	}
+	var nums [3]int
+	for n := range nums {
+		v, err := strconv.Atoi(parts[n])
+		if err != nil {
+			return dateparse.Date{}, "", fmt.Errorf("malformed birthday pick %q", value)
+		}
+		nums[n] = v
+	}
+	d := dateparse.Date{Month: nums[0], Day: nums[1]}
+	if nums[2] != 0 {
+		d.Year = &nums[2]
+	}
+	return d, parts[3], nil
+}
+
+// handleBirthdayPick saves the reading of an ambiguous date the member picked in /birthday set
+func (b *Bot) handleBirthdayPick(s *discordgo.Session, i *discordgo.InteractionCreate, value string) {
+	ctx := context.Background()
+	formatSettings := b.interactionFormatSettings(ctx, i)
+	loc := formatSettings.Locale
+
+	date, tz, err := parseBirthdayPick(value)
+	if err != nil {
+		slog.Warn("Invalid birthday pick", "error", err)
+		respondError(s, i, i18n.T(loc, "birthday.set.failed"))
+		return
+	}
+
+	// The custom ID comes back from the client, so check it like a typed date
+	if !timezone.ValidateTimezone(tz) {
+		respondError(s, i, i18n.T(loc, "error.invalid_timezone_iana", tz))
+		return
+	}
+	if err := dateparse.Validate(date, b.dateOptions(formatSettings, tz)); err != nil {
+		respondError(s, i, b.dateErrorMessage(&dateparse.InvalidDateError{Input: value, Date: date, Err: err}, formatSettings))
+		return
+	}
+
+	mb := &database.MemberBirthday{
+		GuildID:  i.GuildID,
+		UserID:   i.Member.User.ID,
+		Month:    date.Month,
+		Day:      date.Day,
+		Year:     date.Year,
+		Timezone: tz,
+	}
+
+	if err := b.repo.SetMemberBirthday(ctx, mb); err != nil {
+		slog.Error("Failed to save birthday", "error", err)
+		respondError(s, i, i18n.T(loc, "birthday.set.failed"))
+		return
+	}
+
+	slog.Info("Birthday saved successfully", "guildID", mb.GuildID, "userID", mb.UserID)
+
+	s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseUpdateMessage,
+		Data: &discordgo.InteractionResponseData{
+			Content:    b.birthdaySetMessage(mb, formatSettings),
+			Components: []discordgo.MessageComponent{},
+		},
+	})
 }
 
 func intPtr(i int) *int {
 	return &i
 }
+
+func boolPtr(v bool) *bool {
+	return &v
+}
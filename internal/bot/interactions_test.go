@@ -0,0 +1,95 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/config"
+	"github.com/Johnnycyan/cyan-birthdays/internal/dateparse"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestBirthdayPickRoundTrip(t *testing.T) {
+	year := 2002
+	for _, want := range []dateparse.Date{{Month: 4, Day: 3, Year: &year}, {Month: 3, Day: 4}} {
+		id := birthdayPickID(want, "America/Argentina/Buenos_Aires")
+		value, ok := strings.CutPrefix(id, birthdayPickPrefix)
+		if !ok {
+			t.Fatalf("birthdayPickID = %q, want prefix %q", id, birthdayPickPrefix)
+		}
+		if len(id) > 100 {
+			t.Errorf("custom ID %q is longer than Discord allows", id)
+		}
+
+		got, tz, err := parseBirthdayPick(value)
+		if err != nil {
+			t.Fatalf("parseBirthdayPick(%q) error: %v", value, err)
+		}
+		if got.String() != want.String() || tz != "America/Argentina/Buenos_Aires" {
+			t.Errorf("parseBirthdayPick(%q) = %s, %s, want %s", value, got, tz, want)
+		}
+	}
+
+	for _, value := range []string{"", "3:4", "x:4:0:UTC", "3:4:y:UTC"} {
+		if _, _, err := parseBirthdayPick(value); err == nil {
+			t.Errorf("parseBirthdayPick(%q) succeeded, want an error", value)
+		}
+	}
+}
+
+func TestAmbiguousDateResponse(t *testing.T) {
+	_, err := dateparse.Parse("3/4", dateparse.Options{DayFirst: true, Today: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)})
+	ambiguous, ok := err.(*dateparse.AmbiguousError)
+	if !ok {
+		t.Fatalf("Parse(3/4) error = %v, want an AmbiguousError", err)
+	}
+
+	fs := FormatSettings{EuropeanDateFormat: true, Locale: i18n.English}
+	resp := ambiguousDateResponse(ambiguous, "UTC", fs)
+	if resp.Data.Flags != discordgo.MessageFlagsEphemeral {
+		t.Error("the prompt should only be shown to the member")
+	}
+
+	row := resp.Data.Components[0].(discordgo.ActionsRow)
+	var labels []string
+	for _, c := range row.Components {
+		labels = append(labels, c.(discordgo.Button).Label)
+	}
+	if got := strings.Join(labels, " | "); got != "3 April | 4 March | Cancel" {
+		t.Errorf("buttons = %s", got)
+	}
+}
+
+func TestDateErrorMessage(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC))
+	b := newTestBot(newFakeDiscord(), newFakeStore(), clk)
+	b.config = &config.Config{MinAge: 13}
+	us := FormatSettings{Locale: i18n.English}
+	de := FormatSettings{EuropeanDateFormat: true, Locale: i18n.German}
+
+	tests := []struct {
+		input string
+		fs    FormatSettings
+		want  string
+	}{
+		{"4/31", us, "April only has 30 days"},
+		{"31.4.", de, "Der April hat nur 30 Tage"},
+		{"2/29/2023", us, "2023 wasn't a leap year, so it had no February 29"},
+		{"1/1/2027", us, "**January 1, 2027** is in the future. Please enter the date you were born."},
+		{"2020-05-05", us, "A birth year of 2020 doesn't look right. It must give an age between 13 and 120, or you can leave the year out."},
+		{"hello", us, invalidDateMessage(us)},
+	}
+
+	for _, tt := range tests {
+		_, err := b.parseMemberDate(tt.input, tt.fs, "UTC")
+		if err == nil {
+			t.Fatalf("parseMemberDate(%q) succeeded, want an error", tt.input)
+		}
+		if got := b.dateErrorMessage(err, tt.fs); got != tt.want {
+			t.Errorf("dateErrorMessage for %q = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/Johnnycyan/cyan-birthdays/internal/database"
 	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
-	"github.com/bwmarrin/discordgo"
 )
 
 // startBirthdayLoop runs the hourly birthday check
@@ -17,33 +16,19 @@ func (b *Bot) startBirthdayLoop() {
 	// Run immediately on start
 	b.processBirthdays()
 
-	// Calculate time until next hour
-	now := time.Now()
-	nextHour := now.Truncate(time.Hour).Add(time.Hour)
-	timeToNextHour := time.Until(nextHour)
-
-	slog.Info("Scheduling next birthday check", "next_check", nextHour.Format("15:04:05"), "wait_duration", timeToNextHour.String())
-
-	// Wait until the top of the next hour
-	select {
-	case <-b.stopCh:
-		slog.Info("Birthday loop stopped before first scheduled run")
-		return
-	case <-time.After(timeToNextHour):
-		b.processBirthdays()
-	}
+	// Then run at the top of every hour on the bot's clock
+	for {
+		now := b.clock.Now()
+		nextHour := now.Truncate(time.Hour).Add(time.Hour)
+		timeToNextHour := nextHour.Sub(now)
 
-	// Then run every hour at the top of the hour
-	ticker := time.NewTicker(time.Hour)
-	defer ticker.Stop()
+		slog.Debug("Scheduling next birthday check", "next_check", nextHour.Format("15:04:05"), "wait_duration", timeToNextHour.String())
 
-	for {
 		select {
 		case <-b.stopCh:
 			slog.Info("Birthday loop stopped")
 			return
-		case t := <-ticker.C:
-			slog.Debug("Birthday ticker fired", "time", t.Format("15:04:05"))
+		case <-b.clock.After(timeToNextHour):
 			b.processBirthdays()
 		}
 	}
@@ -54,16 +39,25 @@ func (b *Bot) processBirthdays() {
 	b.processMu.Lock()
 	defer b.processMu.Unlock()
 
+	start := time.Now()
+	defer func() { b.metrics.observeLoop(time.Since(start)) }()
+
 	ctx := context.Background()
-	now := time.Now()
+	now := b.clock.Now()
 
 	slog.Info("Processing birthdays", "current_time_utc", now.UTC().Format("2006-01-02 15:04:05"))
 
 	// First, cleanup any expired birthday roles across all guilds
-	b.cleanupExpiredBirthdayRoles(ctx)
+	b.cleanupExpiredBirthdayRoles(ctx, now)
+
+	// Find out when we last completed so announcements missed while offline can be caught up
+	lastRun, err := b.store.GetLastProcessedAt(ctx)
+	if err != nil {
+		slog.Warn("Failed to get last processing time, skipping catch-up", "error", err)
+	}
 
 	// Get all guilds with setup complete
-	guilds, err := b.repo.GetAllSetupGuilds(ctx)
+	guilds, err := b.store.GetAllSetupGuilds(ctx)
 	if err != nil {
 		slog.Error("Failed to get guilds for birthday processing", "error", err)
 		return
@@ -71,46 +65,91 @@ func (b *Bot) processBirthdays() {
 
 	slog.Debug("Processing guilds", "guild_count", len(guilds))
 
+	// Fetch only birthdays that fall on today's local date, across all guilds in one query
+	candidates, err := b.store.GetBirthdayCandidates(ctx, now)
+	if err != nil {
+		slog.Error("Failed to get birthday candidates", "error", err)
+		return
+	}
+
+	byGuild := make(map[string][]database.MemberBirthday)
+	for _, bd := range candidates {
+		byGuild[bd.GuildID] = append(byGuild[bd.GuildID], bd)
+	}
+
 	for _, gs := range guilds {
-		b.processGuildBirthdays(ctx, gs)
+		b.processGuildBirthdays(ctx, gs, byGuild[gs.GuildID], now, lastRun)
 	}
 
-	slog.Debug("Birthday processing complete")
-}
+	// Remind members who opted in the day before their birthday
+	b.processBirthdayReminders(ctx, guilds, now, lastRun)
+	b.processFollowReminders(ctx, guilds, now, lastRun)
 
-// processGuildBirthdays processes birthdays for a single guild
-func (b *Bot) processGuildBirthdays(ctx context.Context, gs database.GuildSettings) {
-	if gs.ChannelID == nil || gs.RoleID == nil {
-		slog.Debug("Guild missing channel or role", "guild_id", gs.GuildID, "has_channel", gs.ChannelID != nil, "has_role", gs.RoleID != nil)
-		return
+	if err := b.store.SetLastProcessedAt(ctx, now); err != nil {
+		slog.Error("Failed to record last processing time", "error", err)
 	}
+	b.metrics.loopSucceeded(now)
 
-	slog.Debug("Processing guild birthdays", "guild_id", gs.GuildID, "announcement_hour", gs.TimeUTC, "default_tz", gs.DefaultTimezone)
+	slog.Debug("Birthday processing complete")
+}
 
-	// Get all birthdays for this guild
-	birthdays, err := b.repo.GetAllGuildBirthdays(ctx, gs.GuildID)
-	if err != nil {
-		slog.Error("Failed to get birthdays for guild", "guild_id", gs.GuildID, "error", err)
+// processGuildBirthdays processes a single guild's birthday candidates
+func (b *Bot) processGuildBirthdays(ctx context.Context, gs database.GuildSettings, birthdays []database.MemberBirthday, now time.Time, lastRun *time.Time) {
+	if gs.ChannelID == nil {
+		slog.Debug("Guild missing channel", "guild_id", gs.GuildID)
 		return
 	}
 
-	slog.Debug("Found birthdays in guild", "guild_id", gs.GuildID, "count", len(birthdays))
+	slog.Debug("Processing guild birthdays", "guild_id", gs.GuildID, "announcement_hour", gs.TimeUTC, "default_tz", gs.DefaultTimezone, "candidates", len(birthdays))
+
+	// Digests only need the channel; individual announcements also need the birthday role
+	switch {
+	case gs.AnnouncementMode == database.AnnouncementModeDigest:
+		b.processDailyDigest(ctx, gs, now, lastRun)
+	case gs.RoleID == nil:
+		slog.Debug("Guild missing role", "guild_id", gs.GuildID)
+	default:
+		birthdaysToday := countBirthdaysToday(now, gs, birthdays)
+		for _, bd := range birthdays {
+			b.processMemberBirthday(ctx, gs, bd, birthdaysToday, now, lastRun)
+		}
+	}
+	b.processWeeklyDigest(ctx, gs, now, lastRun)
+}
 
+// countBirthdaysToday counts the candidates whose birthday is today in their own timezone
+func countBirthdaysToday(now time.Time, gs database.GuildSettings, birthdays []database.MemberBirthday) int {
+	count := 0
 	for _, bd := range birthdays {
-		b.processMemberBirthday(ctx, gs, bd)
+		if ok, err := timezone.IsBirthdayToday(now, bd.Month, bd.Day, gs.LeapDayPolicy, memberTimezone(bd)); err == nil && ok {
+			count++
+		}
+	}
+	return count
+}
+
+// memberTimezone returns the timezone a member's birthday is celebrated in, falling back to UTC
+func memberTimezone(bd database.MemberBirthday) string {
+	if _, err := time.LoadLocation(bd.Timezone); err != nil || bd.Timezone == "" {
+		return "UTC"
 	}
+	return bd.Timezone
 }
 
-// processMemberBirthday checks if a member should be announced
-func (b *Bot) processMemberBirthday(ctx context.Context, gs database.GuildSettings, bd database.MemberBirthday) {
+// processMemberBirthday checks if a member should be announced. birthdaysToday is the number of the
+// guild's members celebrating today, for the {birthday_count_today} placeholder.
+func (b *Bot) processMemberBirthday(ctx context.Context, gs database.GuildSettings, bd database.MemberBirthday, birthdaysToday int, now time.Time, lastRun *time.Time) {
 	// slog.Debug("Checking member birthday", "guild_id", gs.GuildID, "user_id", bd.UserID, "month", bd.Month, "day", bd.Day, "timezone", bd.Timezone)
 
-	// Check if it's their birthday in their timezone
-	isBirthday, err := timezone.IsBirthdayToday(bd.Month, bd.Day, bd.Timezone)
+	// Check if it's their birthday in their timezone, or in UTC if theirs can't be loaded
+	tz := memberTimezone(bd)
+	if tz != bd.Timezone {
+		slog.Warn("Invalid birthday timezone, using UTC", "user_id", bd.UserID, "timezone", bd.Timezone)
+	}
+	isBirthday, err := timezone.IsBirthdayToday(now, bd.Month, bd.Day, gs.LeapDayPolicy, tz)
 	if err != nil {
-		slog.Warn("Failed to check birthday timezone", "user_id", bd.UserID, "timezone", bd.Timezone, "error", err)
-		// Fall back to UTC
-		isBirthday, _ = timezone.IsBirthdayToday(bd.Month, bd.Day, "UTC")
+		slog.Warn("Failed to check birthday", "user_id", bd.UserID, "timezone", tz, "error", err)
+		return
 	}
 
 	if isBirthday {
@@ -121,121 +160,149 @@ func (b *Bot) processMemberBirthday(ctx context.Context, gs database.GuildSettin
 		return
 	}
 
-	// Check if current hour in user's timezone matches the announcement hour
-	shouldAnnounce, err := timezone.ShouldAnnounce(gs.TimeUTC, bd.Timezone)
+	// Check if the announcement hour in user's timezone is now, or was missed while the bot was offline
+	announcementTime, err := timezone.AnnouncementTime(now, gs.TimeUTC, tz)
 	if err != nil {
 		slog.Warn("Failed to check announcement time", "user_id", bd.UserID, "error", err)
 		return
 	}
+	shouldAnnounce, late := isAnnouncementDue(announcementTime, now, lastRun, gs.CatchupHours)
 
-	slog.Debug("Announcement check", "user_id", bd.UserID, "should_announce", shouldAnnounce, "configured_hour", gs.TimeUTC, "user_tz", bd.Timezone)
+	slog.Debug("Announcement check", "user_id", bd.UserID, "should_announce", shouldAnnounce, "late", late, "configured_hour", gs.TimeUTC, "user_tz", tz)
 
 	if !shouldAnnounce {
 		return
 	}
 
-	slog.Info("Processing birthday announcement", "guild_id", gs.GuildID, "user_id", bd.UserID)
+	if late {
+		slog.Info("Catching up missed birthday announcement", "guild_id", gs.GuildID, "user_id", bd.UserID, "scheduled_at", announcementTime.UTC())
+	} else {
+		slog.Info("Processing birthday announcement", "guild_id", gs.GuildID, "user_id", bd.UserID)
+	}
 
-	// Get the member
-	member, err := b.session.GuildMember(gs.GuildID, bd.UserID)
+	// The announcement history is the source of truth for "already announced this year"
+	birthdayYear := announcementTime.Year()
+	announced, err := b.store.HasAnnouncement(ctx, gs.GuildID, bd.UserID, birthdayYear)
 	if err != nil {
-		slog.Debug("Member not found", "guild_id", gs.GuildID, "user_id", bd.UserID)
+		slog.Warn("Failed to check announcement history, skipping", "user_id", bd.UserID, "error", err)
 		return
 	}
-
-	// Check required role
-	if gs.RequiredRoleID != nil {
-		hasRole := false
-		for _, roleID := range member.Roles {
-			if roleID == *gs.RequiredRoleID {
-				hasRole = true
-				break
-			}
-		}
-		if !hasRole {
-			slog.Debug("Member missing required role", "user_id", bd.UserID, "required_role", *gs.RequiredRoleID)
-			return
-		}
+	if announced {
+		slog.Debug("Birthday already announced this year, skipping", "user_id", bd.UserID, "year", birthdayYear)
+		return
 	}
 
-	// Check if already has birthday role
-	hasRole := false
-	for _, roleID := range member.Roles {
-		if roleID == *gs.RoleID {
-			hasRole = true
-			break
-		}
+	// Get the member and check the required role
+	member, ok := b.eligibleMember(gs, bd.UserID)
+	if !ok {
+		return
 	}
 
-	// Add database check for idempotency
-	hasActiveRole, err := b.repo.HasActiveBirthdayRole(ctx, gs.GuildID, bd.UserID)
-	if err != nil {
-		slog.Warn("Failed to check active birthday role from DB", "error", err)
+	// Send announcement
+	var age *int
+	if bd.Year != nil && *bd.Year > 0 {
+		age = intPtr(birthdayYear - *bd.Year)
 	}
-
-	if hasRole || hasActiveRole {
-		// Already announced today
-		slog.Debug("Member already has birthday role or active DB record, skipping", "user_id", bd.UserID)
+	template, templateID := b.chooseMessage(ctx, gs, bd.UserID, age)
+	settings := guildFormatSettings(gs)
+	date := FormatDate(int(announcementTime.Month()), announcementTime.Day(), nil, settings)
+	data := memberTemplateData(settings.Locale, member, age, b.guildName(gs.GuildID), date, birthdaysToday, now)
+	sent, err := b.client.ChannelMessageSendComplex(*gs.ChannelID, newAnnouncementMessage(gs, template, b.announcementEmbed(ctx, gs.GuildID), member, data))
+	if err != nil {
+		slog.Error("Failed to send birthday message", "guild_id", gs.GuildID, "channel_id", *gs.ChannelID, "error", err)
 		return
 	}
+	slog.Info("Sent birthday announcement", "guild_id", gs.GuildID, "user_id", bd.UserID)
+
+	// Only give the role once the announcement is out, so a failed send leaves nothing behind.
+	// It expires 24h after today's announcement time in the user's timezone, even when sent late.
+	expiresAt := announcementTime.Add(24 * time.Hour).UTC()
+	b.addBirthdayRole(ctx, gs, bd.UserID, expiresAt)
+
+	b.birthdayAnnounced(ctx, gs, bd, birthdayYear, sent.ID, templateID, expiresAt, late, now)
+}
 
-	// Add birthday role
-	if err := b.session.GuildMemberRoleAdd(gs.GuildID, bd.UserID, *gs.RoleID); err != nil {
-		slog.Error("Failed to add birthday role", "guild_id", gs.GuildID, "user_id", bd.UserID, "error", err)
+// addBirthdayRole gives a member the birthday role and records when it expires
+func (b *Bot) addBirthdayRole(ctx context.Context, gs database.GuildSettings, userID string, expiresAt time.Time) {
+	if err := b.client.GuildMemberRoleAdd(gs.GuildID, userID, *gs.RoleID); err != nil {
+		slog.Error("Failed to add birthday role", "guild_id", gs.GuildID, "user_id", userID, "error", err)
+		b.metrics.roleAddFailed()
 		return
 	}
-	slog.Info("Added birthday role", "guild_id", gs.GuildID, "user_id", bd.UserID)
+	slog.Info("Added birthday role", "guild_id", gs.GuildID, "user_id", userID)
 
-	// Calculate expiration time: announcement hour + 24h in user's timezone
-	loc, err := time.LoadLocation(bd.Timezone)
-	if err != nil {
-		loc = time.UTC
+	slog.Debug("Setting birthday role expiration", "user_id", userID, "expires_at", expiresAt)
+	if err := b.store.SetActiveBirthdayRole(ctx, gs.GuildID, userID, expiresAt); err != nil {
+		slog.Error("Failed to record birthday role expiration", "error", err)
 	}
-	now := time.Now().In(loc)
-	// Get today's announcement time in user's timezone
-	announcementTime := time.Date(now.Year(), now.Month(), now.Day(), gs.TimeUTC, 0, 0, 0, loc)
-	// If we're past the announcement hour (bot started late), the base is still today's announcement time
-	// Add 24 hours for expiration
-	expiresAt := announcementTime.Add(24 * time.Hour).UTC()
+}
 
-	slog.Debug("Setting birthday role expiration", "user_id", bd.UserID, "expires_at", expiresAt)
-	if err := b.repo.SetActiveBirthdayRole(ctx, gs.GuildID, bd.UserID, expiresAt); err != nil {
-		slog.Error("Failed to record birthday role expiration", "error", err)
+// birthdayAnnounced records a sent announcement, notifies webhooks and greets the member if they opted in
+func (b *Bot) birthdayAnnounced(ctx context.Context, gs database.GuildSettings, bd database.MemberBirthday, birthdayYear int, messageID string, templateID *int64, expiresAt time.Time, late bool, now time.Time) {
+	b.metrics.announcementSent()
+
+	if err := b.store.RecordAnnouncement(ctx, &database.Announcement{
+		GuildID:      gs.GuildID,
+		UserID:       bd.UserID,
+		BirthdayYear: birthdayYear,
+		ChannelID:    *gs.ChannelID,
+		MessageID:    &messageID,
+		TemplateID:   templateID,
+		AnnouncedAt:  now,
+	}); err != nil {
+		slog.Error("Failed to record birthday announcement", "guild_id", gs.GuildID, "user_id", bd.UserID, "error", err)
 	}
 
-	// Send announcement
-	var message string
+	data := webhookAnnouncedData{
+		UserID:        bd.UserID,
+		BirthdayYear:  birthdayYear,
+		ChannelID:     *gs.ChannelID,
+		MessageID:     messageID,
+		Late:          late,
+		RoleExpiresAt: expiresAt,
+	}
 	if bd.Year != nil && *bd.Year > 0 {
-		// Calculate age
-		age := now.Year() - *bd.Year
-		message = formatMessage(gs.MessageWithYear, member.User.Username, bd.UserID, &age)
-	} else {
-		message = formatMessage(gs.MessageWithoutYear, member.User.Username, bd.UserID, nil)
+		age := birthdayYear - *bd.Year
+		data.Age = &age
+	}
+	b.fireWebhook(gs.GuildID, webhookEventAnnounced, data)
+
+	if bd.DMGreeting {
+		b.sendBirthdayDM(ctx, database.DMNotification{
+			GuildID:      gs.GuildID,
+			UserID:       bd.UserID,
+			RecipientID:  bd.UserID,
+			Kind:         dmKindGreeting,
+			BirthdayYear: birthdayYear,
+		}, b.greetingDM(gs, data.Age))
 	}
+}
 
-	allowedMentions := &discordgo.MessageAllowedMentions{
-		Users: []string{bd.UserID},
+// isAnnouncementDue reports whether an announcement scheduled at announcementTime should be sent now.
+// It is due during its own hour, or later (late) when the last completed run was before it and
+// it is still within the guild's catch-up window.
+func isAnnouncementDue(announcementTime, now time.Time, lastRun *time.Time, catchupHours int) (due, late bool) {
+	elapsed := now.Sub(announcementTime)
+	if elapsed < 0 {
+		return false, false
 	}
-	if gs.AllowRoleMention {
-		allowedMentions.Parse = []discordgo.AllowedMentionType{discordgo.AllowedMentionTypeRoles}
+	if elapsed < time.Hour {
+		return true, false
 	}
-
-	_, err = b.session.ChannelMessageSendComplex(*gs.ChannelID, &discordgo.MessageSend{
-		Content:         message,
-		AllowedMentions: allowedMentions,
-	})
-	if err != nil {
-		slog.Error("Failed to send birthday message", "guild_id", gs.GuildID, "channel_id", *gs.ChannelID, "error", err)
-	} else {
-		slog.Info("Sent birthday announcement", "guild_id", gs.GuildID, "user_id", bd.UserID)
+	if lastRun == nil || !lastRun.Before(announcementTime) {
+		return false, false
+	}
+	if elapsed > time.Duration(catchupHours)*time.Hour {
+		return false, false
 	}
+	return true, true
 }
 
 // cleanupExpiredBirthdayRoles removes birthday roles that have exceeded their 24h period
-func (b *Bot) cleanupExpiredBirthdayRoles(ctx context.Context) {
+func (b *Bot) cleanupExpiredBirthdayRoles(ctx context.Context, now time.Time) {
 	slog.Debug("Checking for expired birthday roles")
 
-	expiredRoles, err := b.repo.GetExpiredBirthdayRoles(ctx)
+	expiredRoles, err := b.store.GetExpiredBirthdayRoles(ctx, now)
 	if err != nil {
 		slog.Error("Failed to get expired birthday roles", "error", err)
 		return
@@ -250,28 +317,37 @@ func (b *Bot) cleanupExpiredBirthdayRoles(ctx context.Context) {
 
 	for _, ar := range expiredRoles {
 		// Get guild settings to find the role ID
-		gs, err := b.repo.GetGuildSettings(ctx, ar.GuildID)
+		gs, err := b.store.GetGuildSettings(ctx, ar.GuildID)
 		if err != nil {
 			slog.Warn("Failed to get guild settings for cleanup", "guild_id", ar.GuildID, "error", err)
 			// Still delete the record
-			b.repo.DeleteActiveBirthdayRole(ctx, ar.GuildID, ar.UserID)
+			b.store.DeleteActiveBirthdayRole(ctx, ar.GuildID, ar.UserID)
 			continue
 		}
 
 		if gs.RoleID == nil {
-			b.repo.DeleteActiveBirthdayRole(ctx, ar.GuildID, ar.UserID)
+			b.store.DeleteActiveBirthdayRole(ctx, ar.GuildID, ar.UserID)
 			continue
 		}
 
 		// Remove the role from the member
-		if err := b.session.GuildMemberRoleRemove(ar.GuildID, ar.UserID, *gs.RoleID); err != nil {
+		removed := true
+		if err := b.client.GuildMemberRoleRemove(ar.GuildID, ar.UserID, *gs.RoleID); err != nil {
 			slog.Warn("Failed to remove expired birthday role", "guild_id", ar.GuildID, "user_id", ar.UserID, "error", err)
+			b.metrics.roleRemoveFailed()
+			removed = false
 		} else {
 			slog.Info("Removed expired birthday role", "guild_id", ar.GuildID, "user_id", ar.UserID, "expired_at", ar.RoleExpiresAt)
 		}
+		b.fireWebhook(ar.GuildID, webhookEventRoleExpired, webhookRoleExpiredData{
+			UserID:      ar.UserID,
+			RoleID:      *gs.RoleID,
+			ExpiredAt:   ar.RoleExpiresAt.UTC(),
+			RoleRemoved: removed,
+		})
 
 		// Delete the record regardless of role removal success
-		if err := b.repo.DeleteActiveBirthdayRole(ctx, ar.GuildID, ar.UserID); err != nil {
+		if err := b.store.DeleteActiveBirthdayRole(ctx, ar.GuildID, ar.UserID); err != nil {
 			slog.Error("Failed to delete active birthday role record", "error", err)
 		}
 	}
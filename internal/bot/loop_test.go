@@ -0,0 +1,633 @@
+package bot
+
+import (
+	"cmp"
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+)
+
+const (
+	testGuild    = "guild1"
+	testChannel  = "chan1"
+	testRole     = "bday-role"
+	testRequired = "sub-role"
+	testUser     = "user1"
+)
+
+// testNow is a fixed mid-year instant used by tests that don't care about the date
+var testNow = time.Date(2026, time.June, 15, 9, 0, 0, 0, time.UTC)
+
+func strPtr(s string) *string { return &s }
+
+func testGuildSettings(hour int) database.GuildSettings {
+	return database.GuildSettings{
+		GuildID:            testGuild,
+		ChannelID:          strPtr(testChannel),
+		RoleID:             strPtr(testRole),
+		TimeUTC:            hour,
+		MessageWithYear:    "{mention} has turned {new_age}, happy birthday!",
+		MessageWithoutYear: "Happy birthday {mention}!",
+		DefaultTimezone:    "UTC",
+		SetupComplete:      true,
+	}
+}
+
+func TestBirthdayLoopAlignsToClock(t *testing.T) {
+	// Just before the top of the hour on the injected clock, so the first scheduled run is moments away
+	clk := clock.NewFake(time.Date(2026, time.June, 15, 9, 59, 59, 950_000_000, time.UTC))
+	b := newTestBot(newFakeDiscord(), newFakeStore(), clk)
+
+	runs := func() uint64 {
+		b.metrics.mu.Lock()
+		defer b.metrics.mu.Unlock()
+		return b.metrics.loopDurationCount
+	}
+
+	done := make(chan struct{})
+	go func() {
+		b.startBirthdayLoop()
+		close(done)
+	}()
+	defer func() {
+		close(b.stopCh)
+		<-done
+	}()
+
+	// Each step waits for the loop to be back on the clock before checking how often it ran
+	steps := []struct {
+		advance  time.Duration
+		wantRuns uint64
+	}{
+		{0, 1},                            // the start-up run
+		{50 * time.Millisecond, 2},        // 10:00
+		{59 * time.Minute, 2},             // 10:59
+		{time.Minute, 3},                  // 11:00
+		{2*time.Hour + 30*time.Minute, 4}, // 13:30, with the missed hours run once
+		{30 * time.Minute, 5},             // 14:00
+	}
+	for _, step := range steps {
+		clk.Advance(step.advance)
+		clk.BlockUntil(1)
+		if got := runs(); got != step.wantRuns {
+			t.Fatalf("after advancing to %s the loop ran %d times, want %d", clk.Now().Format("15:04:05.000"), got, step.wantRuns)
+		}
+	}
+}
+
+func TestProcessBirthdays(t *testing.T) {
+	now := testNow
+	tomorrow := now.AddDate(0, 0, 1)
+	otherHour := (now.Hour() + 1) % 24
+
+	tests := []struct {
+		name         string
+		month, day   int
+		year         *int
+		hour         int
+		timezone     string // UTC if empty
+		requiredRole *string
+		memberRoles  []string
+		noMember     bool
+		wantAnnounce bool
+		wantContent  string
+	}{
+		{
+			name:         "birthday today at announcement hour",
+			month:        int(now.Month()),
+			day:          now.Day(),
+			hour:         now.Hour(),
+			wantAnnounce: true,
+			wantContent:  "Happy birthday <@" + testUser + ">!",
+		},
+		{
+			name:         "birthday today with year includes age",
+			month:        int(now.Month()),
+			day:          now.Day(),
+			year:         intPtr(now.Year() - 30),
+			hour:         now.Hour(),
+			wantAnnounce: true,
+			wantContent:  "<@" + testUser + "> has turned 30, happy birthday!",
+		},
+		{
+			name:  "birthday tomorrow",
+			month: int(tomorrow.Month()),
+			day:   tomorrow.Day(),
+			hour:  now.Hour(),
+		},
+		{
+			name:  "birthday today outside announcement hour",
+			month: int(now.Month()),
+			day:   now.Day(),
+			hour:  otherHour,
+		},
+		{
+			name:         "member missing required role",
+			month:        int(now.Month()),
+			day:          now.Day(),
+			hour:         now.Hour(),
+			requiredRole: strPtr(testRequired),
+		},
+		{
+			name:         "member has required role",
+			month:        int(now.Month()),
+			day:          now.Day(),
+			hour:         now.Hour(),
+			requiredRole: strPtr(testRequired),
+			memberRoles:  []string{testRequired},
+			wantAnnounce: true,
+			wantContent:  "Happy birthday <@" + testUser + ">!",
+		},
+		{
+			name:         "member holding birthday role without history is announced",
+			month:        int(now.Month()),
+			day:          now.Day(),
+			hour:         now.Hour(),
+			memberRoles:  []string{testRole},
+			wantAnnounce: true,
+			wantContent:  "Happy birthday <@" + testUser + ">!",
+		},
+		{
+			name:         "invalid timezone is announced at the hour in UTC",
+			month:        int(now.Month()),
+			day:          now.Day(),
+			hour:         now.Hour(),
+			timezone:     "Not/AZone",
+			wantAnnounce: true,
+			wantContent:  "Happy birthday <@" + testUser + ">!",
+		},
+		{
+			name:     "member left the guild",
+			month:    int(now.Month()),
+			day:      now.Day(),
+			hour:     now.Hour(),
+			noMember: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDiscord()
+			store := newFakeStore()
+
+			gs := testGuildSettings(tt.hour)
+			gs.RequiredRoleID = tt.requiredRole
+			store.guilds[testGuild] = gs
+			store.birthdays[testGuild] = []database.MemberBirthday{{
+				GuildID:  testGuild,
+				UserID:   testUser,
+				Month:    tt.month,
+				Day:      tt.day,
+				Year:     tt.year,
+				Timezone: cmp.Or(tt.timezone, "UTC"),
+			}}
+			if !tt.noMember {
+				client.addMember(testGuild, testUser, "alice", tt.memberRoles...)
+			}
+
+			newTestBot(client, store, clock.NewFake(now)).processBirthdays()
+
+			if !tt.wantAnnounce {
+				if len(client.messages) != 0 || len(client.rolesAdded) != 0 {
+					t.Fatalf("expected no announcement, got %d messages and %d role adds", len(client.messages), len(client.rolesAdded))
+				}
+				return
+			}
+
+			if len(client.messages) != 1 {
+				t.Fatalf("expected 1 message, got %d", len(client.messages))
+			}
+			msg := client.messages[0]
+			if msg.ChannelID != testChannel {
+				t.Errorf("message sent to %q, want %q", msg.ChannelID, testChannel)
+			}
+			if msg.Message.Content != tt.wantContent {
+				t.Errorf("message content = %q, want %q", msg.Message.Content, tt.wantContent)
+			}
+			if !client.hasRole(testGuild, testUser, testRole) {
+				t.Error("birthday role was not added")
+			}
+			if ok, _ := store.HasActiveBirthdayRole(context.Background(), testGuild, testUser); !ok {
+				t.Error("active birthday role was not recorded")
+			}
+		})
+	}
+}
+
+func TestProcessBirthdaysIdempotent(t *testing.T) {
+	now := testNow
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = testGuildSettings(now.Hour())
+	store.birthdays[testGuild] = []database.MemberBirthday{{
+		GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Timezone: "UTC",
+	}}
+	client.addMember(testGuild, testUser, "alice")
+
+	b := newTestBot(client, store, clock.NewFake(now))
+	b.processBirthdays()
+	b.processBirthdays()
+
+	if len(client.messages) != 1 {
+		t.Fatalf("expected 1 message after two runs, got %d", len(client.messages))
+	}
+	if len(client.rolesAdded) != 1 {
+		t.Fatalf("expected 1 role add after two runs, got %d", len(client.rolesAdded))
+	}
+
+	// Removing the role and its expiry record must not trigger a second announcement
+	client.GuildMemberRoleRemove(testGuild, testUser, testRole)
+	store.DeleteActiveBirthdayRole(context.Background(), testGuild, testUser)
+	b.processBirthdays()
+	if len(client.messages) != 1 {
+		t.Fatalf("expected no re-announcement after manual role removal, got %d messages", len(client.messages))
+	}
+
+	if len(store.announcements) != 1 {
+		t.Fatalf("expected 1 recorded announcement, got %d", len(store.announcements))
+	}
+	a := store.announcements[0]
+	if a.BirthdayYear != now.Year() || a.ChannelID != testChannel || a.MessageID == nil || *a.MessageID != "msg1" {
+		t.Errorf("unexpected announcement record: %+v", a)
+	}
+}
+
+func TestProcessBirthdaysAnnouncedThisYear(t *testing.T) {
+	now := testNow
+	for _, tt := range []struct {
+		name         string
+		year         int
+		wantAnnounce bool
+	}{
+		{"announced earlier this year", now.Year(), false},
+		{"announced last year", now.Year() - 1, true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDiscord()
+			store := newFakeStore()
+			store.guilds[testGuild] = testGuildSettings(now.Hour())
+			store.birthdays[testGuild] = []database.MemberBirthday{{
+				GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Timezone: "UTC",
+			}}
+			store.announcements = []database.Announcement{{
+				GuildID: testGuild, UserID: testUser, BirthdayYear: tt.year, ChannelID: testChannel,
+			}}
+			client.addMember(testGuild, testUser, "alice")
+
+			newTestBot(client, store, clock.NewFake(now)).processBirthdays()
+
+			if got := len(client.messages) == 1; got != tt.wantAnnounce {
+				t.Fatalf("announced = %v, want %v", got, tt.wantAnnounce)
+			}
+		})
+	}
+}
+
+func TestProcessBirthdaysRoleAddFailure(t *testing.T) {
+	now := testNow
+	client := newFakeDiscord()
+	client.failRoleAdd = true
+	store := newFakeStore()
+	store.guilds[testGuild] = testGuildSettings(now.Hour())
+	store.birthdays[testGuild] = []database.MemberBirthday{{
+		GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Timezone: "UTC",
+	}}
+	client.addMember(testGuild, testUser, "alice")
+
+	newTestBot(client, store, clock.NewFake(now)).processBirthdays()
+
+	// The announcement still goes out; only the role is missing
+	if len(client.messages) != 1 || len(store.announcements) != 1 {
+		t.Fatalf("expected the announcement to be sent and recorded, got %d messages and %d records", len(client.messages), len(store.announcements))
+	}
+	if ok, _ := store.HasActiveBirthdayRole(context.Background(), testGuild, testUser); ok {
+		t.Fatal("active birthday role should not be recorded when role add fails")
+	}
+}
+
+func TestProcessBirthdaysSendFailure(t *testing.T) {
+	now := testNow
+	client := newFakeDiscord()
+	client.failSend = true
+	store := newFakeStore()
+	store.guilds[testGuild] = testGuildSettings(now.Hour())
+	store.birthdays[testGuild] = []database.MemberBirthday{{
+		GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Timezone: "UTC",
+	}}
+	client.addMember(testGuild, testUser, "alice")
+
+	newTestBot(client, store, clock.NewFake(now)).processBirthdays()
+
+	if len(client.rolesAdded) != 0 || client.hasRole(testGuild, testUser, testRole) {
+		t.Error("birthday role should not be added when the announcement fails")
+	}
+	if ok, _ := store.HasActiveBirthdayRole(context.Background(), testGuild, testUser); ok {
+		t.Error("active birthday role should not be recorded when the announcement fails")
+	}
+	if len(store.announcements) != 0 {
+		t.Errorf("expected no recorded announcement, got %d", len(store.announcements))
+	}
+}
+
+func TestCleanupExpiredBirthdayRoles(t *testing.T) {
+	tests := []struct {
+		name        string
+		expiresAt   time.Time
+		roleID      *string
+		wantRemoved bool
+		wantRecord  bool
+	}{
+		{
+			name:        "expired role is removed",
+			expiresAt:   testNow.Add(-time.Minute),
+			roleID:      strPtr(testRole),
+			wantRemoved: true,
+		},
+		{
+			name:       "active role is kept",
+			expiresAt:  testNow.Add(time.Hour),
+			roleID:     strPtr(testRole),
+			wantRecord: true,
+		},
+		{
+			name:      "expired record without configured role is dropped",
+			expiresAt: testNow.Add(-time.Minute),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDiscord()
+			store := newFakeStore()
+
+			gs := testGuildSettings(0)
+			gs.RoleID = tt.roleID
+			store.guilds[testGuild] = gs
+			client.addMember(testGuild, testUser, "alice", testRole)
+			store.SetActiveBirthdayRole(context.Background(), testGuild, testUser, tt.expiresAt)
+
+			newTestBot(client, store, clock.NewFake(testNow)).processBirthdays()
+
+			if got := len(client.rolesRemoved) == 1; got != tt.wantRemoved {
+				t.Errorf("role removed = %v, want %v", got, tt.wantRemoved)
+			}
+			if tt.wantRemoved && client.hasRole(testGuild, testUser, testRole) {
+				t.Error("member still holds the birthday role")
+			}
+			ok, _ := store.HasActiveBirthdayRole(context.Background(), testGuild, testUser)
+			if ok != tt.wantRecord {
+				t.Errorf("active record present = %v, want %v", ok, tt.wantRecord)
+			}
+		})
+	}
+}
+
+func TestProcessBirthdaysAllowedMentions(t *testing.T) {
+	now := testNow
+	for _, allow := range []bool{false, true} {
+		client := newFakeDiscord()
+		store := newFakeStore()
+		gs := testGuildSettings(now.Hour())
+		gs.AllowRoleMention = allow
+		store.guilds[testGuild] = gs
+		store.birthdays[testGuild] = []database.MemberBirthday{{
+			GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Timezone: "UTC",
+		}}
+		client.addMember(testGuild, testUser, "alice")
+
+		newTestBot(client, store, clock.NewFake(now)).processBirthdays()
+
+		if len(client.messages) != 1 {
+			t.Fatalf("allow=%v: expected 1 message, got %d", allow, len(client.messages))
+		}
+		am := client.messages[0].Message.AllowedMentions
+		if am == nil || strings.Join(am.Users, ",") != testUser {
+			t.Errorf("allow=%v: allowed mentions users = %+v", allow, am)
+		}
+		if got := len(am.Parse) > 0; got != allow {
+			t.Errorf("allow=%v: role mentions parsed = %v", allow, got)
+		}
+	}
+}
+
+func TestProcessBirthdaysHourByHour(t *testing.T) {
+	tests := []struct {
+		name         string
+		tz           string
+		month, day   int
+		year         *int
+		hour         int
+		start        time.Time
+		wantAnnounce time.Time
+		wantExpire   time.Time
+		wantContent  string
+	}{
+		{
+			name:         "spring forward in London",
+			tz:           "Europe/London",
+			month:        3,
+			day:          29,
+			start:        time.Date(2026, 3, 28, 12, 0, 0, 0, time.UTC),
+			wantAnnounce: time.Date(2026, 3, 29, 0, 0, 0, 0, time.UTC),
+			wantExpire:   time.Date(2026, 3, 30, 0, 0, 0, 0, time.UTC),
+			wantContent:  "Happy birthday <@" + testUser + ">!",
+		},
+		{
+			name:         "fall back in New York",
+			tz:           "America/New_York",
+			month:        11,
+			day:          1,
+			hour:         9,
+			start:        time.Date(2026, 10, 31, 12, 0, 0, 0, time.UTC),
+			wantAnnounce: time.Date(2026, 11, 1, 14, 0, 0, 0, time.UTC),
+			wantExpire:   time.Date(2026, 11, 2, 14, 0, 0, 0, time.UTC),
+			wantContent:  "Happy birthday <@" + testUser + ">!",
+		},
+		{
+			name:         "new year in Kiritimati",
+			tz:           "Pacific/Kiritimati",
+			month:        1,
+			day:          1,
+			year:         intPtr(2000),
+			start:        time.Date(2025, 12, 30, 12, 0, 0, 0, time.UTC),
+			wantAnnounce: time.Date(2025, 12, 31, 10, 0, 0, 0, time.UTC),
+			wantExpire:   time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC),
+			wantContent:  "<@" + testUser + "> has turned 26, happy birthday!",
+		},
+		{
+			name:         "23:00 on Feb 28 in Auckland",
+			tz:           "Pacific/Auckland",
+			month:        2,
+			day:          28,
+			hour:         23,
+			start:        time.Date(2026, 2, 27, 0, 0, 0, 0, time.UTC),
+			wantAnnounce: time.Date(2026, 2, 28, 10, 0, 0, 0, time.UTC),
+			wantExpire:   time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC),
+			wantContent:  "Happy birthday <@" + testUser + ">!",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDiscord()
+			store := newFakeStore()
+			store.guilds[testGuild] = testGuildSettings(tt.hour)
+			store.birthdays[testGuild] = []database.MemberBirthday{{
+				GuildID: testGuild, UserID: testUser, Month: tt.month, Day: tt.day, Year: tt.year, Timezone: tt.tz,
+			}}
+			client.addMember(testGuild, testUser, "alice")
+
+			clk := clock.NewFake(tt.start)
+			b := newTestBot(client, store, clk)
+
+			var announcedAt, expiredAt time.Time
+			for h := 0; h < 72; h++ {
+				b.processBirthdays()
+				if announcedAt.IsZero() && len(client.messages) > 0 {
+					announcedAt = clk.Now()
+				}
+				if expiredAt.IsZero() && len(client.rolesRemoved) > 0 {
+					expiredAt = clk.Now()
+				}
+				clk.Advance(time.Hour)
+			}
+
+			if len(client.messages) != 1 {
+				t.Fatalf("expected exactly 1 announcement over 72 hours, got %d", len(client.messages))
+			}
+			if !announcedAt.Equal(tt.wantAnnounce) {
+				t.Errorf("announced at %s, want %s", announcedAt.UTC(), tt.wantAnnounce)
+			}
+			if !expiredAt.Equal(tt.wantExpire) {
+				t.Errorf("role expired at %s, want %s", expiredAt.UTC(), tt.wantExpire)
+			}
+			if got := client.messages[0].Message.Content; got != tt.wantContent {
+				t.Errorf("message content = %q, want %q", got, tt.wantContent)
+			}
+		})
+	}
+}
+
+func TestProcessBirthdaysLeapDay(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       string
+		now          time.Time
+		wantAnnounce bool
+	}{
+		{"feb28 policy announces on Feb 28", "feb28", time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), true},
+		{"feb28 policy skips Mar 1", "feb28", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), false},
+		{"mar1 policy announces on Mar 1", "mar1", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), true},
+		{"mar1 policy skips Feb 28", "mar1", time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), false},
+		{"leaponly policy skips common years", "leaponly", time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), false},
+		{"leaponly policy announces on Feb 29", "leaponly", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), true},
+		{"mar1 policy does not double up in leap years", "mar1", time.Date(2028, 3, 1, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDiscord()
+			store := newFakeStore()
+			gs := testGuildSettings(0)
+			gs.LeapDayPolicy = tt.policy
+			store.guilds[testGuild] = gs
+			store.birthdays[testGuild] = []database.MemberBirthday{{
+				GuildID: testGuild, UserID: testUser, Month: 2, Day: 29, Year: intPtr(2004), Timezone: "UTC",
+			}}
+			client.addMember(testGuild, testUser, "alice")
+
+			newTestBot(client, store, clock.NewFake(tt.now)).processBirthdays()
+
+			if got := len(client.messages) == 1; got != tt.wantAnnounce {
+				t.Fatalf("announced = %v, want %v", got, tt.wantAnnounce)
+			}
+			if tt.wantAnnounce {
+				want := "<@" + testUser + "> has turned " + strconv.Itoa(tt.now.Year()-2004) + ", happy birthday!"
+				if got := client.messages[0].Message.Content; got != want {
+					t.Errorf("message content = %q, want %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessBirthdaysCatchUp(t *testing.T) {
+	now := time.Date(2026, 6, 15, 3, 0, 0, 0, time.UTC)
+	timePtr := func(t time.Time) *time.Time { return &t }
+
+	tests := []struct {
+		name         string
+		lastRun      *time.Time
+		catchupHours int
+		tz           string
+		wantAnnounce bool
+	}{
+		{"missed hour within window", timePtr(now.Add(-5 * time.Hour)), 6, "UTC", true},
+		{"missed hour outside window", timePtr(now.Add(-5 * time.Hour)), 2, "UTC", false},
+		{"catch-up disabled", timePtr(now.Add(-5 * time.Hour)), 0, "UTC", false},
+		{"no previous run", nil, 6, "UTC", false},
+		{"announcement hour already processed", timePtr(now.Add(-2 * time.Hour)), 6, "UTC", false},
+		{"missed hour on previous local day", timePtr(now.Add(-5 * time.Hour)), 6, "America/Los_Angeles", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDiscord()
+			store := newFakeStore()
+			gs := testGuildSettings(0)
+			gs.CatchupHours = tt.catchupHours
+			store.guilds[testGuild] = gs
+			store.birthdays[testGuild] = []database.MemberBirthday{{
+				GuildID: testGuild, UserID: testUser, Month: 6, Day: 15, Timezone: tt.tz,
+			}}
+			store.lastRun = tt.lastRun
+			client.addMember(testGuild, testUser, "alice")
+
+			newTestBot(client, store, clock.NewFake(now)).processBirthdays()
+
+			if got := len(client.messages) == 1; got != tt.wantAnnounce {
+				t.Fatalf("announced = %v, want %v", got, tt.wantAnnounce)
+			}
+			if store.lastRun == nil || !store.lastRun.Equal(now) {
+				t.Errorf("last run = %v, want %v", store.lastRun, now)
+			}
+			if tt.wantAnnounce {
+				ar := store.activeRoles[[2]string{testGuild, testUser}]
+				if want := time.Date(2026, 6, 16, 0, 0, 0, 0, time.UTC); !ar.RoleExpiresAt.Equal(want) {
+					t.Errorf("role expires at %s, want %s", ar.RoleExpiresAt, want)
+				}
+			}
+		})
+	}
+}
+
+func TestProcessBirthdaysSkippedDSTHour(t *testing.T) {
+	// 02:00 does not exist in New York on 2026-03-08; the announcement goes out at 03:00 EDT instead
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = testGuildSettings(2)
+	store.birthdays[testGuild] = []database.MemberBirthday{{
+		GuildID: testGuild, UserID: testUser, Month: 3, Day: 8, Timezone: "America/New_York",
+	}}
+	client.addMember(testGuild, testUser, "alice")
+
+	clk := clock.NewFake(time.Date(2026, 3, 8, 4, 0, 0, 0, time.UTC))
+	b := newTestBot(client, store, clk)
+	var announcedAt time.Time
+	for h := 0; h < 24; h++ {
+		b.processBirthdays()
+		if announcedAt.IsZero() && len(client.messages) > 0 {
+			announcedAt = clk.Now()
+		}
+		clk.Advance(time.Hour)
+	}
+
+	if want := time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC); !announcedAt.Equal(want) {
+		t.Errorf("announced at %s, want %s", announcedAt, want)
+	}
+}
@@ -0,0 +1,316 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Limits for a guild's message templates
+const (
+	maxGuildMessages      = 50
+	maxGuildMessageLength = 1000
+	maxGuildMessageWeight = 100
+)
+
+// Limits for /bdset messages list
+const (
+	maxMessageContent = 2000 // Discord's limit on a message's content
+	maxListedTemplate = 200  // characters of each template shown in the list
+)
+
+// defaultMessage returns the guild's single birthday message for a member with or without an age
+func defaultMessage(gs database.GuildSettings, age *int) string {
+	if age != nil {
+		return gs.MessageWithYear
+	}
+	return gs.MessageWithoutYear
+}
+
+// chooseMessage picks the template for a member's announcement: one of the guild's messages for
+// their audience, or the guild's default message if it has none. It returns the chosen guild
+// message's ID, or nil for the default.
+func (b *Bot) chooseMessage(ctx context.Context, gs database.GuildSettings, userID string, age *int) (string, *int64) {
+	messages, err := b.store.GetGuildMessages(ctx, gs.GuildID)
+	if err != nil {
+		slog.Warn("Failed to get guild messages, using the default message", "guild_id", gs.GuildID, "error", err)
+		return defaultMessage(gs, age), nil
+	}
+	if len(messages) == 0 {
+		return defaultMessage(gs, age), nil
+	}
+
+	last, err := b.store.GetLastAnnouncementTemplate(ctx, gs.GuildID, userID)
+	if err != nil {
+		slog.Warn("Failed to get last announcement message", "guild_id", gs.GuildID, "user_id", userID, "error", err)
+	}
+	m := pickGuildMessage(messages, age != nil, last, b.intN)
+	if m == nil {
+		return defaultMessage(gs, age), nil
+	}
+	return m.Template, &m.TemplateID
+}
+
+// pickGuildMessage picks a weighted random message for a member's audience, avoiding the one they
+// got last time when there is another to choose from. It returns nil if none apply.
+func pickGuildMessage(messages []database.GuildMessage, hasYear bool, lastID *int64, intN func(n int) int) *database.GuildMessage {
+	var candidates []database.GuildMessage
+	for _, m := range messages {
+		if messageAudienceMatches(m.Audience, hasYear) {
+			candidates = append(candidates, m)
+		}
+	}
+	if len(candidates) > 1 && lastID != nil {
+		fresh := candidates[:0:0]
+		for _, m := range candidates {
+			if m.TemplateID != *lastID {
+				fresh = append(fresh, m)
+			}
+		}
+		candidates = fresh
+	}
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, m := range candidates {
+		total += max(m.Weight, 1)
+	}
+	n := intN(total)
+	for i := range candidates {
+		n -= max(candidates[i].Weight, 1)
+		if n < 0 {
+			return &candidates[i]
+		}
+	}
+	return &candidates[len(candidates)-1]
+}
+
+// messageAudienceMatches reports whether a message for audience can be used for a member
+func messageAudienceMatches(audience string, hasYear bool) bool {
+	switch audience {
+	case database.MessageAudienceWithYear:
+		return hasYear
+	case database.MessageAudienceWithoutYear:
+		return !hasYear
+	default:
+		return true
+	}
+}
+
+// intN returns a random number in [0, n), from the test's source when one is set
+func (b *Bot) intN(n int) int {
+	if b.randIntN != nil {
+		return b.randIntN(n)
+	}
+	return rand.IntN(n)
+}
+
+// validateGuildMessage checks a new message template and returns the audience to store it with.
+// Without an explicit audience, messages showing the age are only used for members with a year.
+func validateGuildMessage(template, audience string) (string, error) {
+	if strings.TrimSpace(template) == "" {
+		return "", fmt.Errorf("the message can't be empty")
+	}
+	if len(template) > maxGuildMessageLength {
+		return "", fmt.Errorf("the message can be at most %d characters", maxGuildMessageLength)
+	}
+
+	if err := validateTemplate(template, audience != database.MessageAudienceWithoutYear); err != nil {
+		return "", err
+	}
+
+	usesAge := templateUsesAge(template)
+	if audience == "" {
+		if usesAge {
+			return database.MessageAudienceWithYear, nil
+		}
+		return database.MessageAudienceAny, nil
+	}
+	if usesAge && audience == database.MessageAudienceAny {
+		return "", fmt.Errorf("messages for everyone can only show the age inside {if new_age}...{end}")
+	}
+	return audience, nil
+}
+
+// formatMessageAudience describes which members a message is used for
+func formatMessageAudience(loc i18n.Locale, audience string) string {
+	switch audience {
+	case database.MessageAudienceWithYear:
+		return i18n.T(loc, "messages.audience_with_year")
+	case database.MessageAudienceWithoutYear:
+		return i18n.T(loc, "messages.audience_without_year")
+	default:
+		return i18n.T(loc, "messages.audience_everyone")
+	}
+}
+
+// previewGuildMessage renders a message template with the admin as the birthday member
+func (b *Bot) previewGuildMessage(ctx context.Context, m database.GuildMessage, member *discordgo.Member) string {
+	var age *int
+	if m.Audience != database.MessageAudienceWithoutYear {
+		age = intPtr(25)
+	}
+	return b.previewMessage(ctx, m.GuildID, member, m.Template, age)
+}
+
+// previewMessage renders a message with the admin as the birthday member
+func (b *Bot) previewMessage(ctx context.Context, guildID string, member *discordgo.Member, template string, age *int) string {
+	gs, err := b.repo.GetGuildSettings(ctx, guildID)
+	if err != nil {
+		gs = &database.GuildSettings{GuildID: guildID}
+	}
+	return renderTemplate(template, b.previewTemplateData(*gs, member, age))
+}
+
+// previewTemplateData returns placeholder values for previewing a message as if today were the
+// member's birthday
+func (b *Bot) previewTemplateData(gs database.GuildSettings, member *discordgo.Member, age *int) templateData {
+	now := b.clock.Now()
+	settings := guildFormatSettings(gs)
+	date := FormatDate(int(now.Month()), now.Day(), nil, settings)
+	return memberTemplateData(settings.Locale, member, age, b.guildName(gs.GuildID), date, 1, now)
+}
+
+// messageListPages lists a guild's messages for /bdset messages list, split into pages that each fit
+// in one Discord message. Long templates are shortened to one line.
+func messageListPages(loc i18n.Locale, messages []database.GuildMessage) []string {
+	pages := []string{i18n.T(loc, "messages.list_title", len(messages), maxGuildMessages)}
+	for _, m := range messages {
+		template := shorten(strings.ReplaceAll(m.Template, "\n", " "), maxListedTemplate)
+		line := i18n.T(loc, "messages.line", m.TemplateID, formatMessageAudience(loc, m.Audience), m.Weight, template)
+		// Discord counts characters; counting bytes keeps pages safely under the limit
+		if last := &pages[len(pages)-1]; len(*last)+len("\n")+len(line) <= maxMessageContent {
+			*last += "\n" + line
+			continue
+		}
+		pages = append(pages, line)
+	}
+	return pages
+}
+
+// handleBdsetMessages routes /bdset messages subcommands
+func (b *Bot) handleBdsetMessages(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if len(i.ApplicationCommandData().Options[0].Options) == 0 {
+		return
+	}
+
+	sub := i.ApplicationCommandData().Options[0].Options[0]
+	ctx := context.Background()
+	loc := b.interactionLocale(i)
+
+	switch sub.Name {
+	case "digest":
+		b.handleBdsetDigest(s, i)
+
+	case "add":
+		m := database.GuildMessage{GuildID: i.GuildID, Weight: 1, CreatedBy: i.Member.User.ID}
+		var audience string
+		for _, opt := range sub.Options {
+			switch opt.Name {
+			case "message":
+				m.Template = strings.TrimSpace(opt.StringValue())
+			case "weight":
+				m.Weight = int(opt.IntValue())
+			case "audience":
+				audience = opt.StringValue()
+			}
+		}
+		var err error
+		if m.Audience, err = validateGuildMessage(m.Template, audience); err != nil {
+			respondError(s, i, i18n.T(loc, "message.invalid", err))
+			return
+		}
+
+		messages, err := b.repo.GetGuildMessages(ctx, i.GuildID)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "messages.fetch_failed"))
+			return
+		}
+		if len(messages) >= maxGuildMessages {
+			respondError(s, i, i18n.T(loc, "messages.limit", maxGuildMessages))
+			return
+		}
+		id, err := b.repo.AddGuildMessage(ctx, &m)
+		if err != nil {
+			slog.Error("Failed to add guild message", "guild_id", i.GuildID, "error", err)
+			respondError(s, i, i18n.T(loc, "messages.add_failed"))
+			return
+		}
+		msg := i18n.T(loc, "messages.added", id, formatMessageAudience(loc, m.Audience), m.Weight, b.previewGuildMessage(ctx, m, i.Member))
+		if len(messages) == 0 {
+			msg += "\n" + i18n.T(loc, "messages.first_added")
+		}
+		respondEphemeral(s, i, msg)
+
+	case "remove":
+		id := sub.Options[0].IntValue()
+		deleted, err := b.repo.DeleteGuildMessage(ctx, i.GuildID, id)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "messages.remove_failed"))
+			return
+		}
+		if !deleted {
+			respondError(s, i, i18n.T(loc, "messages.not_found", id))
+			return
+		}
+		respondEphemeral(s, i, i18n.T(loc, "messages.removed", id))
+
+	case "list":
+		messages, err := b.repo.GetGuildMessages(ctx, i.GuildID)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "messages.fetch_failed"))
+			return
+		}
+		if len(messages) == 0 {
+			respondEphemeral(s, i, i18n.T(loc, "messages.none"))
+			return
+		}
+		pages := messageListPages(loc, messages)
+		respondEphemeral(s, i, pages[0])
+		for _, page := range pages[1:] {
+			if _, err := s.FollowupMessageCreate(i.Interaction, false, &discordgo.WebhookParams{
+				Content: page,
+				Flags:   discordgo.MessageFlagsEphemeral,
+			}); err != nil {
+				slog.Error("Failed to send message list page", "guild_id", i.GuildID, "error", err)
+				return
+			}
+		}
+
+	case "preview":
+		messages, err := b.repo.GetGuildMessages(ctx, i.GuildID)
+		if err != nil {
+			respondError(s, i, i18n.T(loc, "messages.fetch_failed"))
+			return
+		}
+		if len(messages) == 0 {
+			respondError(s, i, i18n.T(loc, "messages.none_to_preview"))
+			return
+		}
+
+		var m *database.GuildMessage
+		if len(sub.Options) > 0 {
+			id := sub.Options[0].IntValue()
+			for j := range messages {
+				if messages[j].TemplateID == id {
+					m = &messages[j]
+				}
+			}
+			if m == nil {
+				respondError(s, i, i18n.T(loc, "messages.not_found", id))
+				return
+			}
+		} else {
+			m = &messages[b.intN(len(messages))]
+		}
+		respondEphemeral(s, i, i18n.T(loc, "messages.preview", m.TemplateID, formatMessageAudience(loc, m.Audience), b.previewGuildMessage(ctx, *m, i.Member)))
+	}
+}
@@ -0,0 +1,135 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+)
+
+func TestPickGuildMessage(t *testing.T) {
+	messages := []database.GuildMessage{
+		{TemplateID: 1, Audience: database.MessageAudienceAny, Weight: 1},
+		{TemplateID: 2, Audience: database.MessageAudienceWithYear, Weight: 3},
+		{TemplateID: 3, Audience: database.MessageAudienceWithoutYear, Weight: 1},
+	}
+	int64Ptr := func(i int64) *int64 { return &i }
+	fixed := func(v int) func(int) int { return func(int) int { return v } }
+
+	tests := []struct {
+		name    string
+		hasYear bool
+		last    *int64
+		roll    int
+		want    int64
+	}{
+		{name: "first weight slot", hasYear: true, roll: 0, want: 1},
+		{name: "weighted slots", hasYear: true, roll: 3, want: 2},
+		{name: "without year skips age messages", hasYear: false, roll: 1, want: 3},
+		{name: "avoids last year's message", hasYear: true, last: int64Ptr(2), roll: 0, want: 1},
+		{name: "reuses the only message", hasYear: false, last: int64Ptr(3), roll: 0, want: 1},
+	}
+	for _, tt := range tests {
+		got := pickGuildMessage(messages, tt.hasYear, tt.last, fixed(tt.roll))
+		if got == nil || got.TemplateID != tt.want {
+			t.Errorf("%s: picked %+v, want %d", tt.name, got, tt.want)
+		}
+	}
+
+	if got := pickGuildMessage(messages[1:2], false, nil, fixed(0)); got != nil {
+		t.Errorf("picked %+v for a member outside every audience", got)
+	}
+	if got := pickGuildMessage(messages[1:2], true, int64Ptr(2), fixed(0)); got == nil || got.TemplateID != 2 {
+		t.Errorf("a lone message should repeat, got %+v", got)
+	}
+}
+
+func TestValidateGuildMessage(t *testing.T) {
+	tests := []struct {
+		template, audience string
+		want               string
+		wantErr            bool
+	}{
+		{template: "Happy birthday {mention}!", want: database.MessageAudienceAny},
+		{template: "{mention} is {new_age}!", want: database.MessageAudienceWithYear},
+		{template: "Happy birthday {name}!", audience: database.MessageAudienceWithoutYear, want: database.MessageAudienceWithoutYear},
+		{template: "{mention} is {new_age}!", audience: database.MessageAudienceAny, wantErr: true},
+		{template: "   ", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := validateGuildMessage(tt.template, tt.audience)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("validateGuildMessage(%q, %q) = %q, %v", tt.template, tt.audience, got, err)
+		}
+	}
+}
+
+func TestProcessBirthdaysGuildMessages(t *testing.T) {
+	now := testNow
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = testGuildSettings(now.Hour())
+	store.birthdays[testGuild] = []database.MemberBirthday{{
+		GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Timezone: "UTC",
+	}}
+	store.messages = map[string][]database.GuildMessage{testGuild: {
+		{TemplateID: 1, GuildID: testGuild, Template: "Party time, {mention}!", Audience: database.MessageAudienceAny, Weight: 1},
+		{TemplateID: 2, GuildID: testGuild, Template: "Cake for {name}!", Audience: database.MessageAudienceAny, Weight: 1},
+		{TemplateID: 3, GuildID: testGuild, Template: "{mention} is {new_age}!", Audience: database.MessageAudienceWithYear, Weight: 1},
+	}}
+	client.addMember(testGuild, testUser, "alice")
+
+	clk := clock.NewFake(now)
+	b := newTestBot(client, store, clk)
+	b.randIntN = func(int) int { return 0 }
+
+	b.processBirthdays()
+	clk.Set(now.AddDate(1, 0, 0))
+	b.processBirthdays()
+
+	if len(client.messages) != 2 {
+		t.Fatalf("sent %d messages, want one per year", len(client.messages))
+	}
+	if got := client.messages[0].Message.Content; got != "Party time, <@"+testUser+">!" {
+		t.Errorf("first year = %q", got)
+	}
+	if got := client.messages[1].Message.Content; got != "Cake for alice!" {
+		t.Errorf("second year = %q, want a different message", got)
+	}
+	if a := store.announcements[1]; a.TemplateID == nil || *a.TemplateID != 2 {
+		t.Errorf("announcement template = %v, want 2", a.TemplateID)
+	}
+}
+
+func TestMessageListPages(t *testing.T) {
+	var messages []database.GuildMessage
+	for n := range maxGuildMessages {
+		messages = append(messages, database.GuildMessage{
+			TemplateID: int64(n + 1),
+			Audience:   database.MessageAudienceAny,
+			Weight:     1,
+			Template:   "🎂 {mention}\n" + strings.Repeat("happy birthday ", maxGuildMessageLength/15),
+		})
+	}
+
+	pages := messageListPages(i18n.English, messages)
+	if len(pages) < 2 {
+		t.Fatalf("got %d page, want the list split", len(pages))
+	}
+	listed := 0
+	for _, page := range pages {
+		if n := utf8.RuneCountInString(page); n > maxMessageContent {
+			t.Errorf("page is %d characters, Discord allows %d", n, maxMessageContent)
+		}
+		listed += strings.Count(page, "· weight 1")
+	}
+	if listed != len(messages) {
+		t.Errorf("pages list %d messages, want %d", listed, len(messages))
+	}
+	if !strings.HasPrefix(pages[0], "💬 **Birthday messages (50/50)**\n`1` · ") {
+		t.Errorf("first page starts %q", shorten(pages[0], 60))
+	}
+}
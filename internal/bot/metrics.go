@@ -0,0 +1,132 @@
+package bot
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// loopDurationBuckets are the histogram upper bounds, in seconds, for birthday loop runs
+var loopDurationBuckets = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120}
+
+// metrics holds the bot's Prometheus counters. The zero value is ready to use.
+type metrics struct {
+	mu                 sync.Mutex
+	announcementsSent  uint64
+	roleAddFailures    uint64
+	roleRemoveFailures uint64
+	commandsHandled    map[string]uint64 // command path, e.g. "birthday set" -> count
+	loopDurationCounts []uint64          // per bucket, not cumulative; the last entry is +Inf
+	loopDurationSum    float64
+	loopDurationCount  uint64
+	lastLoopSuccess    time.Time
+}
+
+func (m *metrics) announcementSent() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.announcementsSent++
+}
+
+func (m *metrics) roleAddFailed() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.roleAddFailures++
+}
+
+func (m *metrics) roleRemoveFailed() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.roleRemoveFailures++
+}
+
+func (m *metrics) commandHandled(command string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.commandsHandled == nil {
+		m.commandsHandled = make(map[string]uint64)
+	}
+	m.commandsHandled[command]++
+}
+
+// observeLoop records how long a birthday loop run took
+func (m *metrics) observeLoop(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.loopDurationCounts == nil {
+		m.loopDurationCounts = make([]uint64, len(loopDurationBuckets)+1)
+	}
+	seconds := d.Seconds()
+	i := sort.SearchFloat64s(loopDurationBuckets, seconds)
+	m.loopDurationCounts[i]++
+	m.loopDurationSum += seconds
+	m.loopDurationCount++
+}
+
+// loopSucceeded records the time of the last birthday loop run that completed
+func (m *metrics) loopSucceeded(at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.lastLoopSuccess = at
+}
+
+// lastSuccess returns the time of the last completed birthday loop run, or the zero time if none has
+func (m *metrics) lastSuccess() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.lastLoopSuccess
+}
+
+// writeTo writes all metrics in the Prometheus text exposition format
+func (m *metrics) writeTo(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var sb strings.Builder
+	writeCounter(&sb, "cyan_birthdays_announcements_sent_total", "Birthday announcements sent.", m.announcementsSent)
+	writeCounter(&sb, "cyan_birthdays_role_add_failures_total", "Failed attempts to add the birthday role.", m.roleAddFailures)
+	writeCounter(&sb, "cyan_birthdays_role_remove_failures_total", "Failed attempts to remove an expired birthday role.", m.roleRemoveFailures)
+
+	sb.WriteString("# HELP cyan_birthdays_commands_handled_total Slash commands handled, by command.\n")
+	sb.WriteString("# TYPE cyan_birthdays_commands_handled_total counter\n")
+	commands := make([]string, 0, len(m.commandsHandled))
+	for c := range m.commandsHandled {
+		commands = append(commands, c)
+	}
+	sort.Strings(commands)
+	for _, c := range commands {
+		fmt.Fprintf(&sb, "cyan_birthdays_commands_handled_total{command=%s} %d\n", strconv.Quote(c), m.commandsHandled[c])
+	}
+
+	sb.WriteString("# HELP cyan_birthdays_loop_duration_seconds Time taken by each birthday loop run.\n")
+	sb.WriteString("# TYPE cyan_birthdays_loop_duration_seconds histogram\n")
+	var cumulative uint64
+	for i, le := range loopDurationBuckets {
+		if m.loopDurationCounts != nil {
+			cumulative += m.loopDurationCounts[i]
+		}
+		fmt.Fprintf(&sb, "cyan_birthdays_loop_duration_seconds_bucket{le=\"%s\"} %d\n", strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+	}
+	fmt.Fprintf(&sb, "cyan_birthdays_loop_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.loopDurationCount)
+	fmt.Fprintf(&sb, "cyan_birthdays_loop_duration_seconds_sum %s\n", strconv.FormatFloat(m.loopDurationSum, 'g', -1, 64))
+	fmt.Fprintf(&sb, "cyan_birthdays_loop_duration_seconds_count %d\n", m.loopDurationCount)
+
+	sb.WriteString("# HELP cyan_birthdays_loop_last_success_timestamp_seconds Unix time of the last completed birthday loop run.\n")
+	sb.WriteString("# TYPE cyan_birthdays_loop_last_success_timestamp_seconds gauge\n")
+	var lastSuccess int64
+	if !m.lastLoopSuccess.IsZero() {
+		lastSuccess = m.lastLoopSuccess.Unix()
+	}
+	fmt.Fprintf(&sb, "cyan_birthdays_loop_last_success_timestamp_seconds %d\n", lastSuccess)
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+func writeCounter(sb *strings.Builder, name, help string, value uint64) {
+	fmt.Fprintf(sb, "# HELP %s %s\n# TYPE %s counter\n%s %d\n", name, help, name, name, value)
+}
@@ -0,0 +1,258 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+	"github.com/bwmarrin/discordgo"
+)
+
+// Kinds of birthday direct messages
+const (
+	dmKindGreeting = "greeting"
+	dmKindReminder = "reminder"
+	dmKindFollow   = "follow"
+)
+
+// Limits for /birthday follow
+const (
+	maxFollows        = 25
+	maxFollowDaysAway = 30
+)
+
+// sendBirthdayDM sends a direct message about a birthday unless one of the same kind was already sent
+// this year. Members with closed DMs are recorded as undeliverable so they aren't retried every hour.
+func (b *Bot) sendBirthdayDM(ctx context.Context, n database.DMNotification, content string) {
+	sent, err := b.store.HasDMNotification(ctx, n.GuildID, n.UserID, n.RecipientID, n.Kind, n.BirthdayYear)
+	if err != nil {
+		slog.Warn("Failed to check DM history, skipping", "guild_id", n.GuildID, "user_id", n.UserID, "kind", n.Kind, "error", err)
+		return
+	}
+	if sent {
+		return
+	}
+
+	err = b.sendDM(n.RecipientID, content)
+	switch {
+	case err == nil:
+		n.Delivered = true
+		slog.Info("Sent birthday DM", "guild_id", n.GuildID, "user_id", n.UserID, "recipient_id", n.RecipientID, "kind", n.Kind)
+	case isClosedDMError(err):
+		slog.Info("Member does not accept DMs", "guild_id", n.GuildID, "recipient_id", n.RecipientID, "kind", n.Kind)
+	default:
+		// Leave it unrecorded so the next run retries
+		slog.Warn("Failed to send birthday DM", "guild_id", n.GuildID, "recipient_id", n.RecipientID, "kind", n.Kind, "error", err)
+		return
+	}
+
+	n.SentAt = b.clock.Now()
+	if err := b.store.RecordDMNotification(ctx, &n); err != nil {
+		slog.Error("Failed to record birthday DM", "guild_id", n.GuildID, "user_id", n.UserID, "kind", n.Kind, "error", err)
+	}
+}
+
+// sendDM sends a plain direct message to a user
+func (b *Bot) sendDM(userID, content string) error {
+	channel, err := b.client.UserChannelCreate(userID)
+	if err != nil {
+		return err
+	}
+	_, err = b.client.ChannelMessageSendComplex(channel.ID, &discordgo.MessageSend{
+		Content:         content,
+		AllowedMentions: &discordgo.MessageAllowedMentions{},
+	})
+	return err
+}
+
+// isClosedDMError reports whether Discord refused a DM because of the user's privacy settings
+func isClosedDMError(err error) bool {
+	var restErr *discordgo.RESTError
+	return errors.As(err, &restErr) && restErr.Message != nil &&
+		restErr.Message.Code == discordgo.ErrCodeCannotSendMessagesToThisUser
+}
+
+// greetingDM returns the birthday greeting sent to a member who opted in
+func (b *Bot) greetingDM(gs database.GuildSettings, age *int) string {
+	loc := guildFormatSettings(gs).Locale
+	if age != nil {
+		return i18n.T(loc, "dm.greeting_age", i18n.Ordinal(loc, *age), b.guildDisplayName(loc, gs.GuildID))
+	}
+	return i18n.T(loc, "dm.greeting", b.guildDisplayName(loc, gs.GuildID))
+}
+
+// reminderDM returns the "your birthday is tomorrow" message
+func (b *Bot) reminderDM(gs database.GuildSettings, celebrateAt time.Time) string {
+	loc := guildFormatSettings(gs).Locale
+	return i18n.T(loc, "dm.reminder", b.guildDisplayName(loc, gs.GuildID), celebrateAt.Unix())
+}
+
+// guildDisplayName returns the guild's name for messages outside the guild
+func (b *Bot) guildDisplayName(loc i18n.Locale, guildID string) string {
+	if name := b.guildName(guildID); name != "" {
+		return name
+	}
+	return i18n.T(loc, "dm.your_server")
+}
+
+// processBirthdayReminders DMs members who opted in that their birthday is tomorrow, at the guild's
+// announcement hour in their timezone on the day before
+func (b *Bot) processBirthdayReminders(ctx context.Context, guilds []database.GuildSettings, now time.Time, lastRun *time.Time) {
+	byID := make(map[string]database.GuildSettings, len(guilds))
+	for _, gs := range guilds {
+		byID[gs.GuildID] = gs
+	}
+
+	// Birthdays on tomorrow's local date
+	candidates, err := b.store.GetBirthdayCandidates(ctx, now.Add(24*time.Hour))
+	if err != nil {
+		slog.Error("Failed to get birthday reminder candidates", "error", err)
+		return
+	}
+
+	for _, bd := range candidates {
+		if !bd.DMReminder {
+			continue
+		}
+		gs, ok := byID[bd.GuildID]
+		if !ok {
+			continue
+		}
+
+		tz := bd.Timezone
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			tz, loc = "UTC", time.UTC
+		}
+		tomorrow := now.In(loc).AddDate(0, 0, 1)
+		m, d, ok := timezone.ObservedBirthday(bd.Month, bd.Day, tomorrow.Year(), gs.LeapDayPolicy)
+		if !ok || m != int(tomorrow.Month()) || d != tomorrow.Day() {
+			continue
+		}
+
+		remindAt, err := timezone.AnnouncementTime(now, gs.TimeUTC, tz)
+		if err != nil {
+			continue
+		}
+		if due, _ := isAnnouncementDue(remindAt, now, lastRun, gs.CatchupHours); !due {
+			continue
+		}
+
+		if _, ok := b.eligibleMember(gs, bd.UserID); !ok {
+			continue
+		}
+
+		b.sendBirthdayDM(ctx, database.DMNotification{
+			GuildID:      gs.GuildID,
+			UserID:       bd.UserID,
+			RecipientID:  bd.UserID,
+			Kind:         dmKindReminder,
+			BirthdayYear: tomorrow.Year(),
+		}, b.reminderDM(gs, remindAt.AddDate(0, 0, 1)))
+	}
+}
+
+// processFollowReminders DMs followers the configured number of days before a followed member's
+// birthday, at the guild's announcement hour in the follower's own timezone
+func (b *Bot) processFollowReminders(ctx context.Context, guilds []database.GuildSettings, now time.Time, lastRun *time.Time) {
+	byID := make(map[string]database.GuildSettings, len(guilds))
+	for _, gs := range guilds {
+		byID[gs.GuildID] = gs
+	}
+
+	follows, err := b.store.GetBirthdayFollows(ctx)
+	if err != nil {
+		slog.Error("Failed to get birthday follows", "error", err)
+		return
+	}
+
+	for _, f := range follows {
+		gs, ok := byID[f.GuildID]
+		if !ok || f.Birthday == nil {
+			continue
+		}
+
+		tz := f.FollowerTimezone
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			tz, loc = "UTC", time.UTC
+		}
+		target := now.In(loc).AddDate(0, 0, f.DaysBefore)
+		m, d, ok := timezone.ObservedBirthday(f.Birthday.Month, f.Birthday.Day, target.Year(), gs.LeapDayPolicy)
+		if !ok || m != int(target.Month()) || d != target.Day() {
+			continue
+		}
+
+		remindAt, err := timezone.AnnouncementTime(now, gs.TimeUTC, tz)
+		if err != nil {
+			continue
+		}
+		if due, _ := isAnnouncementDue(remindAt, now, lastRun, gs.CatchupHours); !due {
+			continue
+		}
+
+		member, ok := b.eligibleMember(gs, f.UserID)
+		if !ok {
+			continue
+		}
+		if _, err := b.client.GuildMember(gs.GuildID, f.FollowerID); err != nil {
+			slog.Debug("Follower not found", "guild_id", gs.GuildID, "user_id", f.FollowerID)
+			continue
+		}
+
+		b.sendBirthdayDM(ctx, database.DMNotification{
+			GuildID:      gs.GuildID,
+			UserID:       f.UserID,
+			RecipientID:  f.FollowerID,
+			Kind:         dmKindFollow,
+			BirthdayYear: target.Year(),
+		}, b.followReminderDM(gs, f, member, target))
+	}
+}
+
+// followReminderDM returns the message telling a follower that a birthday is coming up
+func (b *Bot) followReminderDM(gs database.GuildSettings, f database.BirthdayFollow, member *discordgo.Member, date time.Time) string {
+	settings := guildFormatSettings(gs)
+	loc := settings.Locale
+	when := i18n.T(loc, "dm.follow_in_days", f.DaysBefore)
+	if f.DaysBefore == 1 {
+		when = i18n.T(loc, "dm.follow_tomorrow")
+	}
+	args := []any{
+		member.User.Username, f.UserID, b.guildDisplayName(loc, gs.GuildID), when,
+		i18n.WeekdayName(loc, int(date.Weekday())), FormatDate(int(date.Month()), date.Day(), nil, settings),
+	}
+	if f.Birthday.Year != nil && *f.Birthday.Year > 0 {
+		return i18n.T(loc, "dm.follow_age", append(args, date.Year()-*f.Birthday.Year)...)
+	}
+	return i18n.T(loc, "dm.follow", args...)
+}
+
+// eligibleMember fetches a member and reports whether they are still in the guild and hold the
+// guild's required role, if one is set
+func (b *Bot) eligibleMember(gs database.GuildSettings, userID string) (*discordgo.Member, bool) {
+	member, err := b.client.GuildMember(gs.GuildID, userID)
+	if err != nil {
+		slog.Debug("Member not found", "guild_id", gs.GuildID, "user_id", userID)
+		return nil, false
+	}
+	if gs.RequiredRoleID != nil && !memberHasRole(member, *gs.RequiredRoleID) {
+		slog.Debug("Member missing required role", "user_id", userID, "required_role", *gs.RequiredRoleID)
+		return member, false
+	}
+	return member, true
+}
+
+// memberHasRole reports whether a member holds a role
+func memberHasRole(member *discordgo.Member, roleID string) bool {
+	for _, id := range member.Roles {
+		if id == roleID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,192 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestBirthdayGreetingDM(t *testing.T) {
+	for _, optedIn := range []bool{true, false} {
+		now := testNow
+		client := newFakeDiscord()
+		store := newFakeStore()
+		store.guilds[testGuild] = testGuildSettings(now.Hour())
+		store.birthdays[testGuild] = []database.MemberBirthday{{
+			GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(),
+			Year: intPtr(now.Year() - 21), Timezone: "UTC", DMGreeting: optedIn,
+		}}
+		client.addMember(testGuild, testUser, "alice")
+
+		clk := clock.NewFake(now)
+		b := newTestBot(client, store, clk)
+		b.processBirthdays()
+		clk.Advance(10 * time.Minute)
+		b.processBirthdays()
+
+		dms := client.directMessages(testUser)
+		if !optedIn {
+			if len(dms) != 0 {
+				t.Errorf("sent %d DMs to a member who didn't opt in", len(dms))
+			}
+			continue
+		}
+		if len(dms) != 1 {
+			t.Fatalf("sent %d greeting DMs, want 1", len(dms))
+		}
+		if !strings.Contains(dms[0].Content, "Happy 21st birthday") {
+			t.Errorf("greeting = %q", dms[0].Content)
+		}
+		if len(store.notifications) != 1 || store.notifications[0].Kind != dmKindGreeting || !store.notifications[0].Delivered {
+			t.Errorf("notifications = %+v", store.notifications)
+		}
+	}
+}
+
+func TestBirthdayReminderDM(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	// Birthday on June 16th in Berlin, announced at 09:00 local time
+	remindAt := time.Date(2026, time.June, 15, 9, 0, 0, 0, berlin)
+
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = testGuildSettings(9)
+	store.birthdays[testGuild] = []database.MemberBirthday{{
+		GuildID: testGuild, UserID: testUser, Month: 6, Day: 16, Timezone: "Europe/Berlin", DMReminder: true,
+	}}
+	client.addMember(testGuild, testUser, "alice")
+
+	clk := clock.NewFake(remindAt.Add(-time.Hour))
+	b := newTestBot(client, store, clk)
+
+	// Hour by hour across the day before: exactly one reminder, at the announcement hour
+	for range 6 {
+		b.processBirthdays()
+		if got := len(client.directMessages(testUser)); clk.Now().Before(remindAt) && got != 0 {
+			t.Fatalf("reminder sent early at %s", clk.Now().In(berlin))
+		}
+		clk.Advance(time.Hour)
+	}
+
+	dms := client.directMessages(testUser)
+	if len(dms) != 1 {
+		t.Fatalf("sent %d reminders, want 1", len(dms))
+	}
+	if want := "<t:1781593200:R>"; !strings.Contains(dms[0].Content, want) {
+		t.Errorf("reminder %q missing %s", dms[0].Content, want)
+	}
+	if len(store.announcements) != 0 {
+		t.Error("reminder announced the birthday early")
+	}
+	if n := store.notifications; len(n) != 1 || n[0].Kind != dmKindReminder || n[0].BirthdayYear != 2026 {
+		t.Errorf("notifications = %+v", n)
+	}
+}
+
+func TestBirthdayDMClosed(t *testing.T) {
+	now := testNow
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = testGuildSettings(now.Hour())
+	store.birthdays[testGuild] = []database.MemberBirthday{{
+		GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Timezone: "UTC", DMGreeting: true,
+	}}
+	client.addMember(testGuild, testUser, "alice")
+	client.closedDMs[testUser] = true
+
+	clk := clock.NewFake(now)
+	b := newTestBot(client, store, clk)
+	b.processBirthdays()
+	clk.Advance(10 * time.Minute)
+	b.processBirthdays()
+
+	if len(store.announcements) != 1 || len(client.messages) != 1 {
+		t.Fatalf("announcements = %d, messages = %d; closed DMs should not affect the announcement", len(store.announcements), len(client.messages))
+	}
+	// Recorded once as undelivered so it isn't retried
+	if len(store.notifications) != 1 || store.notifications[0].Delivered {
+		t.Errorf("notifications = %+v", store.notifications)
+	}
+}
+
+func TestFollowReminderDM(t *testing.T) {
+	const follower = "follower1"
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	// Followed birthday is June 18th; three days before at 09:00 in the follower's timezone
+	remindAt := time.Date(2026, time.June, 15, 9, 0, 0, 0, tokyo)
+
+	tests := []struct {
+		name           string
+		followerLeft   bool
+		followedAbsent bool
+		wantDM         bool
+	}{
+		{name: "reminded", wantDM: true},
+		{name: "follower left the server", followerLeft: true},
+		{name: "followed member left the server", followedAbsent: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeDiscord()
+			store := newFakeStore()
+			store.guilds[testGuild] = testGuildSettings(9)
+			store.follows = []database.BirthdayFollow{{
+				GuildID: testGuild, FollowerID: follower, UserID: testUser, DaysBefore: 3, FollowerTimezone: "Asia/Tokyo",
+				Birthday: &database.MemberBirthday{GuildID: testGuild, UserID: testUser, Month: 6, Day: 18, Year: intPtr(1996), Timezone: "UTC"},
+			}}
+			if !tt.followedAbsent {
+				client.addMember(testGuild, testUser, "alice")
+			}
+			if !tt.followerLeft {
+				client.addMember(testGuild, follower, "bob")
+			}
+
+			clk := clock.NewFake(remindAt.Add(-2 * time.Hour))
+			b := newTestBot(client, store, clk)
+			for range 6 {
+				b.processBirthdays()
+				clk.Advance(time.Hour)
+			}
+
+			dms := client.directMessages(follower)
+			if !tt.wantDM {
+				if len(dms) != 0 {
+					t.Errorf("sent %d reminders", len(dms))
+				}
+				return
+			}
+			if len(dms) != 1 {
+				t.Fatalf("sent %d reminders, want 1", len(dms))
+			}
+			for _, want := range []string{"**alice**", "in 3 days", "Thursday, June 18", "turns 30"} {
+				if !strings.Contains(dms[0].Content, want) {
+					t.Errorf("reminder %q missing %q", dms[0].Content, want)
+				}
+			}
+			if n := store.notifications; len(n) != 1 || n[0].Kind != dmKindFollow || n[0].RecipientID != follower || n[0].UserID != testUser {
+				t.Errorf("notifications = %+v", n)
+			}
+		})
+	}
+}
+
+func TestDMsUseGuildLanguage(t *testing.T) {
+	b := newTestBot(newFakeDiscord(), newFakeStore(), clock.NewFake(testNow))
+	gs := database.GuildSettings{GuildID: testGuild, Language: string(i18n.German), EuropeanDateFormat: true}
+
+	if got, want := b.greetingDM(gs, intPtr(30)), "🎂 Alles Gute zum 30. Geburtstag von **deinem Server**! Hab einen wunderschönen Tag. 🎉"; got != want {
+		t.Errorf("greeting = %q, want %q", got, want)
+	}
+
+	f := database.BirthdayFollow{UserID: testUser, DaysBefore: 1, Birthday: &database.MemberBirthday{Month: 6, Day: 18}}
+	member := &discordgo.Member{User: &discordgo.User{ID: testUser, Username: "alice"}}
+	got := b.followReminderDM(gs, f, member, time.Date(2026, time.June, 18, 0, 0, 0, 0, time.UTC))
+	if want := "🎁 **alice** (<@" + testUser + ">) von **deinem Server** hat morgen Geburtstag, am Donnerstag, 18. Juni!"; got != want {
+		t.Errorf("follow reminder = %q, want %q", got, want)
+	}
+}
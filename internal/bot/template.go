@@ -0,0 +1,358 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+// templateData holds the values birthday message placeholders are filled in from
+type templateData struct {
+	UserID         string
+	Name           string
+	DisplayName    string
+	Age            *int // nil when the member's birth year is unknown
+	Server         string
+	Date           string
+	DaysSinceJoin  *int // nil when the join date is unknown
+	BirthdaysToday int
+	Locale         i18n.Locale // for placeholders such as {ordinal_age}
+}
+
+// templatePlaceholder describes one {placeholder} in birthday messages
+type templatePlaceholder struct {
+	name    string
+	usesAge bool
+	value   func(d templateData) (string, bool) // false when the value isn't available
+}
+
+// templatePlaceholders lists the available placeholders, in the order they are documented
+var templatePlaceholders = []templatePlaceholder{
+	{name: "mention", value: func(d templateData) (string, bool) { return "<@" + d.UserID + ">", true }},
+	{name: "name", value: func(d templateData) (string, bool) { return escapeMarkdown(d.Name), true }},
+	{name: "display_name", value: func(d templateData) (string, bool) { return escapeMarkdown(d.DisplayName), true }},
+	{name: "new_age", usesAge: true, value: func(d templateData) (string, bool) {
+		if d.Age == nil {
+			return "", false
+		}
+		return strconv.Itoa(*d.Age), true
+	}},
+	{name: "ordinal_age", usesAge: true, value: func(d templateData) (string, bool) {
+		if d.Age == nil {
+			return "", false
+		}
+		return i18n.Ordinal(d.Locale, *d.Age), true
+	}},
+	{name: "server", value: func(d templateData) (string, bool) { return escapeMarkdown(d.Server), d.Server != "" }},
+	{name: "date", value: func(d templateData) (string, bool) { return d.Date, d.Date != "" }},
+	{name: "days_since_join", value: func(d templateData) (string, bool) {
+		if d.DaysSinceJoin == nil {
+			return "", false
+		}
+		return strconv.Itoa(*d.DaysSinceJoin), true
+	}},
+	{name: "birthday_count_today", value: func(d templateData) (string, bool) { return strconv.Itoa(d.BirthdaysToday), true }},
+}
+
+// lookupPlaceholder returns the placeholder with the given name
+func lookupPlaceholder(name string) (templatePlaceholder, bool) {
+	for _, p := range templatePlaceholders {
+		if p.name == name {
+			return p, true
+		}
+	}
+	return templatePlaceholder{}, false
+}
+
+// templateNode is one piece of a parsed message: literal text, a placeholder or a conditional
+type templateNode struct {
+	text        string
+	placeholder string
+	raw         string // the placeholder as written, rendered as-is when it's unknown
+	cond        *templateCond
+}
+
+// templateCond is an {if name}...{else}...{end} block
+type templateCond struct {
+	name    string
+	negate  bool
+	then    []templateNode
+	els     []templateNode
+	hasElse bool
+}
+
+// parseTemplate parses a birthday message. Parsing is lenient so old messages still render: the
+// nodes are usable even when err is non-nil, and err describes the first problem found.
+//
+// Syntax: {placeholder}, {if placeholder}...{else}...{end} (with {if !placeholder} to negate), and
+// {{ and }} for literal braces.
+func parseTemplate(template string) ([]templateNode, error) {
+	var firstErr error
+	fail := func(format string, args ...any) {
+		if firstErr == nil {
+			firstErr = fmt.Errorf(format, args...)
+		}
+	}
+
+	root := &templateCond{}
+	stack := []*templateCond{root}
+	appendNode := func(n templateNode) {
+		top := stack[len(stack)-1]
+		if top.hasElse {
+			top.els = append(top.els, n)
+		} else {
+			top.then = append(top.then, n)
+		}
+	}
+
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			appendNode(templateNode{text: text.String()})
+			text.Reset()
+		}
+	}
+
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		switch {
+		case c == '{' && strings.HasPrefix(template[i:], "{{"):
+			text.WriteByte('{')
+			i++
+		case c == '}' && strings.HasPrefix(template[i:], "}}"):
+			text.WriteByte('}')
+			i++
+		case c == '{':
+			end := strings.IndexAny(template[i+1:], "{}")
+			if end < 0 || template[i+1+end] != '}' {
+				fail("unclosed { in %q; write {{ for a literal brace", excerpt(template[i:]))
+				text.WriteByte('{')
+				continue
+			}
+			raw := template[i : i+end+2]
+			tag := strings.TrimSpace(raw[1 : len(raw)-1])
+			i += end + 1
+
+			switch {
+			case tag == "else":
+				top := stack[len(stack)-1]
+				if top == root {
+					fail("{else} without a matching {if}")
+					text.WriteString(raw)
+					continue
+				}
+				if top.hasElse {
+					fail("{if %s} has more than one {else}", top.name)
+				}
+				flush()
+				top.hasElse = true
+			case tag == "end":
+				if len(stack) == 1 {
+					fail("{end} without a matching {if}")
+					text.WriteString(raw)
+					continue
+				}
+				flush()
+				stack = stack[:len(stack)-1]
+			case strings.HasPrefix(tag, "if "):
+				name := strings.TrimSpace(strings.TrimPrefix(tag, "if "))
+				cond := &templateCond{name: strings.TrimPrefix(name, "!"), negate: strings.HasPrefix(name, "!")}
+				if _, ok := lookupPlaceholder(cond.name); !ok {
+					fail("%s", unknownPlaceholderError(cond.name))
+				}
+				flush()
+				appendNode(templateNode{cond: cond})
+				stack = append(stack, cond)
+			default:
+				if _, ok := lookupPlaceholder(tag); !ok {
+					fail("%s", unknownPlaceholderError(tag))
+				}
+				flush()
+				appendNode(templateNode{placeholder: tag, raw: raw})
+			}
+		default:
+			text.WriteByte(c)
+		}
+	}
+	flush()
+
+	if len(stack) > 1 {
+		fail("{if %s} is missing its {end}", stack[len(stack)-1].name)
+	}
+	return root.then, firstErr
+}
+
+// unknownPlaceholderError explains an unknown placeholder, suggesting the closest known one
+func unknownPlaceholderError(name string) string {
+	var names []string
+	best, bestDist := "", 3
+	for _, p := range templatePlaceholders {
+		names = append(names, "{"+p.name+"}")
+		if d := editDistance(name, p.name); d < bestDist {
+			best, bestDist = p.name, d
+		}
+	}
+	msg := fmt.Sprintf("unknown placeholder {%s}", name)
+	if best != "" {
+		msg += fmt.Sprintf(" (did you mean {%s}?)", best)
+	}
+	return msg + ". Available placeholders: " + strings.Join(names, ", ")
+}
+
+// renderTemplate fills in a birthday message. Unknown placeholders are left as written and
+// unavailable ones (such as {new_age} without a birth year) are left empty.
+func renderTemplate(template string, data templateData) string {
+	nodes, _ := parseTemplate(template)
+	var sb strings.Builder
+	renderNodes(&sb, nodes, data)
+	return sb.String()
+}
+
+func renderNodes(sb *strings.Builder, nodes []templateNode, data templateData) {
+	for _, n := range nodes {
+		switch {
+		case n.cond != nil:
+			if conditionHolds(n.cond, data) {
+				renderNodes(sb, n.cond.then, data)
+			} else {
+				renderNodes(sb, n.cond.els, data)
+			}
+		case n.placeholder != "":
+			p, ok := lookupPlaceholder(n.placeholder)
+			if !ok {
+				sb.WriteString(n.raw)
+				continue
+			}
+			v, _ := p.value(data)
+			sb.WriteString(v)
+		default:
+			sb.WriteString(n.text)
+		}
+	}
+}
+
+// conditionHolds reports whether an {if} block's placeholder has a value. Empty values and 0 count
+// as false.
+func conditionHolds(c *templateCond, data templateData) bool {
+	holds := false
+	if p, ok := lookupPlaceholder(c.name); ok {
+		v, available := p.value(data)
+		holds = available && v != "" && v != "0"
+	}
+	return holds != c.negate
+}
+
+// validateTemplate checks a birthday message before it is saved. allowAge is false for messages only
+// used for members without a birth year, where the age placeholders would always be empty.
+func validateTemplate(template string, allowAge bool) error {
+	nodes, err := parseTemplate(template)
+	if err != nil {
+		return err
+	}
+	if !allowAge {
+		if name, ok := firstAgePlaceholder(nodes, false); ok {
+			return fmt.Errorf("{%s} isn't available in messages for members without a birth year", name)
+		}
+	}
+	return nil
+}
+
+// templateUsesAge reports whether a message shows the member's age outside an {if new_age} or
+// {if ordinal_age} block, so it only makes sense for members with a birth year
+func templateUsesAge(template string) bool {
+	nodes, _ := parseTemplate(template)
+	_, ok := firstAgePlaceholder(nodes, true)
+	return ok
+}
+
+// firstAgePlaceholder returns the first age placeholder in nodes. With skipGuarded, placeholders
+// inside blocks that only render when the age is known are ignored.
+func firstAgePlaceholder(nodes []templateNode, skipGuarded bool) (string, bool) {
+	for _, n := range nodes {
+		if n.cond != nil {
+			p, _ := lookupPlaceholder(n.cond.name)
+			guarded := skipGuarded && p.usesAge
+			if !guarded || n.cond.negate {
+				if name, ok := firstAgePlaceholder(n.cond.then, skipGuarded); ok {
+					return name, true
+				}
+			}
+			if !guarded || !n.cond.negate {
+				if name, ok := firstAgePlaceholder(n.cond.els, skipGuarded); ok {
+					return name, true
+				}
+			}
+			continue
+		}
+		if p, ok := lookupPlaceholder(n.placeholder); ok && p.usesAge {
+			return p.name, true
+		}
+	}
+	return "", false
+}
+
+// memberTemplateData returns the placeholder values for a member's birthday message
+func memberTemplateData(loc i18n.Locale, member *discordgo.Member, age *int, server, date string, birthdaysToday int, now time.Time) templateData {
+	d := templateData{
+		Locale:         loc,
+		UserID:         member.User.ID,
+		Name:           member.User.Username,
+		DisplayName:    member.DisplayName(),
+		Age:            age,
+		Server:         server,
+		Date:           date,
+		BirthdaysToday: birthdaysToday,
+	}
+	if !member.JoinedAt.IsZero() {
+		days := int(now.Sub(member.JoinedAt).Hours() / 24)
+		d.DaysSinceJoin = &days
+	}
+	return d
+}
+
+// escapeMarkdown escapes Discord formatting characters in names so they display as written
+func escapeMarkdown(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, `*`, `\*`, `_`, `\_`, `~`, `\~`, "`", "\\`", `|`, `\|`, `>`, `\>`,
+)
+
+// excerpt shortens s for error messages
+func excerpt(s string) string {
+	return shorten(s, 20)
+}
+
+// shorten cuts s to at most n characters, marking the cut with an ellipsis
+func shorten(s string, n int) string {
+	if r := []rune(s); len(r) > n {
+		return string(r[:n]) + "…"
+	}
+	return s
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	data := templateData{
+		UserID:         "42",
+		Name:           "cool_cat",
+		DisplayName:    "Cool *Cat*",
+		Age:            intPtr(21),
+		Server:         "Cyan",
+		Date:           "June 15",
+		DaysSinceJoin:  intPtr(400),
+		BirthdaysToday: 1,
+	}
+	noAge := data
+	noAge.Age = nil
+	german := data
+	german.Locale = i18n.German
+
+	tests := []struct {
+		name     string
+		template string
+		data     templateData
+		want     string
+	}{
+		{name: "legacy placeholders", template: "{mention} has turned {new_age}, happy birthday!", data: data, want: "<@42> has turned 21, happy birthday!"},
+		{name: "names are escaped", template: "{name} / {display_name}", data: data, want: `cool\_cat / Cool \*Cat\*`},
+		{name: "ordinal and server", template: "Happy {ordinal_age} from {server} on {date}!", data: data, want: "Happy 21st from Cyan on June 15!"},
+		{name: "localized ordinal", template: "Alles Gute zum {ordinal_age} Geburtstag!", data: german, want: "Alles Gute zum 21. Geburtstag!"},
+		{name: "join and count", template: "{days_since_join} days, {birthday_count_today} today", data: data, want: "400 days, 1 today"},
+		{name: "conditional with age", template: "Happy birthday{if new_age}, {ordinal_age} one{else}!{end}", data: data, want: "Happy birthday, 21st one"},
+		{name: "conditional without age", template: "Happy birthday{if new_age}, {ordinal_age} one{else}!{end}", data: noAge, want: "Happy birthday!"},
+		{name: "negated conditional", template: "{if !new_age}No age{end}", data: noAge, want: "No age"},
+		{name: "nested conditionals", template: "{if server}{if new_age}{new_age} in {server}{end}{end}", data: data, want: "21 in Cyan"},
+		{name: "zero is false", template: "{if birthday_count_today}yes{else}no{end}", data: templateData{}, want: "no"},
+		{name: "escaped braces", template: "{{mention}} is {mention}}}", data: data, want: "{mention} is <@42>}"},
+		{name: "unavailable placeholder is empty", template: "Age: {new_age}.", data: noAge, want: "Age: ."},
+		{name: "unknown placeholder is kept", template: "Hi {nmae}", data: data, want: "Hi {nmae}"},
+		{name: "unclosed brace is kept", template: "Hi { there", data: data, want: "Hi { there"},
+		{name: "missing end", template: "{if new_age}{new_age}", data: data, want: "21"},
+	}
+	for _, tt := range tests {
+		if got := renderTemplate(tt.template, tt.data); got != tt.want {
+			t.Errorf("%s: renderTemplate(%q) = %q, want %q", tt.name, tt.template, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		allowAge bool
+		wantErr  string
+	}{
+		{template: "{mention} has turned {new_age}!", allowAge: true},
+		{template: "Happy birthday {display_name}{if server} from {server}{end}!", allowAge: false},
+		{template: "Happy {nmae}!", allowAge: true, wantErr: "did you mean {name}?"},
+		{template: "Happy {foo}!", allowAge: true, wantErr: "Available placeholders: {mention}"},
+		{template: "{if new_age}x", allowAge: true, wantErr: "missing its {end}"},
+		{template: "x{end}", allowAge: true, wantErr: "{end} without a matching {if}"},
+		{template: "x{else}y", allowAge: true, wantErr: "{else} without a matching {if}"},
+		{template: "{if new_age}a{else}b{else}c{end}", allowAge: true, wantErr: "more than one {else}"},
+		{template: "{if new_agee}x{end}", allowAge: true, wantErr: "did you mean {new_age}?"},
+		{template: "Hi {mention", allowAge: true, wantErr: "write {{ for a literal brace"},
+		{template: "{mention} is {ordinal_age}", allowAge: false, wantErr: "{ordinal_age} isn't available"},
+	}
+	for _, tt := range tests {
+		err := validateTemplate(tt.template, tt.allowAge)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("validateTemplate(%q) = %v", tt.template, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("validateTemplate(%q) = %v, want error containing %q", tt.template, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTemplateUsesAge(t *testing.T) {
+	tests := map[string]bool{
+		"Happy birthday {mention}!":                    false,
+		"{mention} turns {new_age}":                    true,
+		"{if new_age}{ordinal_age} birthday{end}":      false,
+		"{if !new_age}{new_age}{end}":                  true,
+		"{if new_age}yay{else}{ordinal_age}{end}":      true,
+		"{if !new_age}hi{else}{ordinal_age} bday{end}": false,
+		"{if server}{new_age}{end}":                    true,
+	}
+	for template, want := range tests {
+		if got := templateUsesAge(template); got != want {
+			t.Errorf("templateUsesAge(%q) = %v, want %v", template, got, want)
+		}
+	}
+}
+
+func TestProcessBirthdaysTemplateData(t *testing.T) {
+	now := testNow
+	gs := testGuildSettings(now.Hour())
+	gs.MessageWithoutYear = "{display_name}: {date}, {days_since_join} days, {birthday_count_today} today"
+	gs.EuropeanDateFormat = true
+
+	client := newFakeDiscord()
+	store := newFakeStore()
+	store.guilds[testGuild] = gs
+	store.birthdays[testGuild] = []database.MemberBirthday{
+		{GuildID: testGuild, UserID: testUser, Month: int(now.Month()), Day: now.Day(), Timezone: "UTC"},
+		{GuildID: testGuild, UserID: "later", Month: int(now.Month()), Day: now.Day(), Timezone: "Pacific/Kiritimati"},
+	}
+	client.addMember(testGuild, testUser, "alice")
+	m := client.members[testGuild][testUser]
+	m.Nick = "Ali"
+	m.JoinedAt = now.Add(-10 * 24 * time.Hour)
+
+	newTestBot(client, store, clock.NewFake(now)).processBirthdays()
+
+	if len(client.messages) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(client.messages))
+	}
+	if got, want := client.messages[0].Message.Content, "Ali: 15 June, 10 days, 2 today"; got != want {
+		t.Errorf("content = %q, want %q", got, want)
+	}
+}
+
+func TestMemberTemplateDataWithoutJoinDate(t *testing.T) {
+	member := &discordgo.Member{User: &discordgo.User{ID: "1", Username: "bob"}}
+	if d := memberTemplateData(i18n.English, member, nil, "", "", 0, testNow); d.DaysSinceJoin != nil || d.DisplayName != "bob" {
+		t.Errorf("data = %+v", d)
+	}
+}
@@ -0,0 +1,165 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+	"github.com/bwmarrin/discordgo"
+	"github.com/jackc/pgx/v5"
+)
+
+// upcomingBirthday is a birthday falling within the requested window
+type upcomingBirthday struct {
+	UserID      string
+	Month       int
+	Day         int
+	Year        *int
+	Timezone    string
+	Next        time.Time // the celebrated date, at midnight UTC
+	DaysAway    int
+	AnnouncesAt time.Time // the announcement hour on the celebrated date, in the member's timezone
+}
+
+// Age returns the age the member turns on their next birthday, or 0 if their birth year is unknown
+func (u upcomingBirthday) Age() int {
+	if u.Year == nil || *u.Year <= 0 {
+		return 0
+	}
+	return u.Next.Year() - *u.Year
+}
+
+// upcomingBirthdays returns the guild's birthdays within the next days days, soonest first, along with
+// the total number of birthdays set in the guild. Members missing the required role are left out.
+func (b *Bot) upcomingBirthdays(ctx context.Context, guildID string, days int) ([]upcomingBirthday, int, error) {
+	birthdays, err := b.repo.GetAllGuildBirthdays(ctx, guildID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(birthdays) == 0 {
+		return nil, 0, nil
+	}
+
+	gs, err := b.repo.GetGuildSettings(ctx, guildID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			slog.Warn("Failed to get guild settings for upcoming birthdays", "guild_id", guildID, "error", err)
+		}
+		gs = &database.GuildSettings{LeapDayPolicy: timezone.LeapDayFeb28} // Midnight announcements, default leap-day policy
+	}
+
+	hasRequiredRole := func(userID string) bool {
+		if gs.RequiredRoleID == nil {
+			return true
+		}
+		member, err := b.client.GuildMember(guildID, userID)
+		if err != nil {
+			slog.Debug("Could not fetch member for role check", "userID", userID, "error", err)
+			return false // Skip user if we can't check their roles
+		}
+		for _, roleID := range member.Roles {
+			if roleID == *gs.RequiredRoleID {
+				return true
+			}
+		}
+		slog.Debug("User missing required role, skipping from upcoming", "userID", userID)
+		return false
+	}
+
+	return computeUpcoming(birthdays, gs, b.clock.Now(), days, hasRequiredRole), len(birthdays), nil
+}
+
+// computeUpcoming filters birthdays to those celebrated within days days of now, honoring the guild's
+// leap-day policy, and sorts them soonest first. include is only asked about birthdays in the window.
+func computeUpcoming(birthdays []database.MemberBirthday, gs *database.GuildSettings, now time.Time, days int, include func(userID string) bool) []upcomingBirthday {
+	now = now.UTC()
+	// Truncate to start of day for accurate date comparison
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	slog.Debug("Checking upcoming birthdays", "today", today.Format("2006-01-02"), "totalBirthdays", len(birthdays))
+
+	var upcoming []upcomingBirthday
+	for _, bd := range birthdays {
+		// Calculate days until birthday using date-only comparison, honoring the leap-day policy
+		nextBday := timezone.NextBirthday(today, bd.Month, bd.Day, gs.LeapDayPolicy)
+		if nextBday.IsZero() {
+			continue
+		}
+
+		daysAway := int(nextBday.Sub(today).Hours() / 24)
+
+		slog.Debug("Checking birthday", "userID", bd.UserID, "month", bd.Month, "day", bd.Day, "nextBday", nextBday.Format("2006-01-02"), "daysAway", daysAway)
+
+		if daysAway > days || (include != nil && !include(bd.UserID)) {
+			continue
+		}
+
+		loc, err := time.LoadLocation(bd.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+
+		upcoming = append(upcoming, upcomingBirthday{
+			UserID:      bd.UserID,
+			Month:       bd.Month,
+			Day:         bd.Day,
+			Year:        bd.Year,
+			Timezone:    bd.Timezone,
+			Next:        nextBday,
+			DaysAway:    daysAway,
+			AnnouncesAt: time.Date(nextBday.Year(), nextBday.Month(), nextBday.Day(), gs.TimeUTC, 0, 0, 0, loc),
+		})
+	}
+
+	slog.Debug("Upcoming birthdays filtered", "count", len(upcoming))
+
+	sort.SliceStable(upcoming, func(i, j int) bool { return upcoming[i].DaysAway < upcoming[j].DaysAway })
+	return upcoming
+}
+
+// upcomingEmbedFields groups upcoming birthdays into one embed field per day, soonest first
+func upcomingEmbedFields(upcoming []upcomingBirthday, loc i18n.Locale) []*discordgo.MessageEmbedField {
+	var fields []*discordgo.MessageEmbedField
+	byDay := make(map[int]*discordgo.MessageEmbedField)
+	for _, bd := range upcoming {
+		field, ok := byDay[bd.DaysAway]
+		if !ok {
+			var name string
+			switch bd.DaysAway {
+			case 0:
+				name = i18n.T(loc, "upcoming.today")
+			case 1:
+				name = i18n.T(loc, "upcoming.tomorrow")
+			default:
+				name = i18n.T(loc, "upcoming.in_days", bd.DaysAway)
+			}
+			field = &discordgo.MessageEmbedField{Name: name}
+			byDay[bd.DaysAway] = field
+			fields = append(fields, field)
+		}
+
+		// Format the announcement time as a Discord timestamp (shows time only in viewer's local time)
+		timestamp := fmt.Sprintf("<t:%d:t>", bd.AnnouncesAt.Unix())
+
+		mention := fmt.Sprintf("<@%s> - %s", bd.UserID, timestamp)
+		if bd.Year != nil && *bd.Year > 0 {
+			age := bd.Age()
+			if bd.DaysAway > 0 {
+				mention = i18n.T(loc, "upcoming.turning", bd.UserID, age, timestamp)
+			} else {
+				mention = i18n.T(loc, "upcoming.now", bd.UserID, age, timestamp)
+			}
+		}
+		if field.Value != "" {
+			field.Value += "\n"
+		}
+		field.Value += mention
+	}
+	return fields
+}
@@ -0,0 +1,48 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/timezone"
+)
+
+func TestComputeUpcoming(t *testing.T) {
+	// 2027 is not a leap year
+	now := time.Date(2027, time.February, 26, 15, 0, 0, 0, time.UTC)
+	gs := &database.GuildSettings{TimeUTC: 9, LeapDayPolicy: timezone.LeapDayMar1}
+	birthdays := []database.MemberBirthday{
+		{UserID: "later", Month: 3, Day: 5, Timezone: "UTC"},
+		{UserID: "leap", Month: 2, Day: 29, Year: intPtr(2000), Timezone: "Europe/Berlin"},
+		{UserID: "today", Month: 2, Day: 26, Timezone: "bad/zone"},
+		{UserID: "outside", Month: 3, Day: 20, Timezone: "UTC"},
+		{UserID: "hidden", Month: 2, Day: 27, Timezone: "UTC"},
+	}
+
+	upcoming := computeUpcoming(birthdays, gs, now, 7, func(userID string) bool { return userID != "hidden" })
+
+	var order []string
+	for _, u := range upcoming {
+		order = append(order, u.UserID)
+	}
+	if len(order) != 3 || order[0] != "today" || order[1] != "leap" || order[2] != "later" {
+		t.Fatalf("order = %v, want [today leap later]", order)
+	}
+
+	leap := upcoming[1]
+	if leap.DaysAway != 3 || leap.Next.Format("2006-01-02") != "2027-03-01" {
+		t.Errorf("leap day birthday: next %s, %d days away", leap.Next.Format("2006-01-02"), leap.DaysAway)
+	}
+	if leap.Age() != 27 {
+		t.Errorf("age = %d, want 27", leap.Age())
+	}
+	if want := time.Date(2027, 3, 1, 8, 0, 0, 0, time.UTC); !leap.AnnouncesAt.Equal(want) {
+		t.Errorf("announces at %s, want %s", leap.AnnouncesAt.UTC(), want)
+	}
+
+	// An unknown timezone falls back to UTC, and no year means no age
+	if today := upcoming[0]; today.DaysAway != 0 || today.Age() != 0 || today.AnnouncesAt.Location() != time.UTC {
+		t.Errorf("today: %+v", today)
+	}
+}
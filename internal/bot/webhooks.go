@@ -0,0 +1,389 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+	"github.com/Johnnycyan/cyan-birthdays/internal/database"
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+)
+
+// Webhook event names
+const (
+	webhookEventAnnounced   = "birthday.announced"
+	webhookEventRoleExpired = "birthday.role_expired"
+	webhookEventPing        = "ping"
+)
+
+// Webhook request headers
+const (
+	webhookHeaderEvent     = "X-Cyan-Birthdays-Event"
+	webhookHeaderDelivery  = "X-Cyan-Birthdays-Delivery"
+	webhookHeaderTimestamp = "X-Cyan-Birthdays-Timestamp"
+	webhookHeaderSignature = "X-Cyan-Birthdays-Signature"
+)
+
+// maxGuildWebhooks limits how many webhooks a guild can register
+const maxGuildWebhooks = 5
+
+// webhookTimeout bounds a single delivery attempt
+const webhookTimeout = 10 * time.Second
+
+// defaultWebhookRetryDelays are the waits before each retry of a failed delivery
+var defaultWebhookRetryDelays = []time.Duration{5 * time.Second, 30 * time.Second, 2 * time.Minute, 10 * time.Minute}
+
+// webhookStore is the subset of the repository used to deliver webhooks
+type webhookStore interface {
+	GetGuildWebhooks(ctx context.Context, guildID string) ([]database.Webhook, error)
+	GetWebhookSecret(ctx context.Context, guildID string) (*string, error)
+	RecordWebhookDelivery(ctx context.Context, d *database.WebhookDelivery) error
+}
+
+var _ webhookStore = (*database.Repository)(nil)
+
+// webhookPayload is the signed JSON body sent to webhooks
+type webhookPayload struct {
+	ID        string    `json:"id"`
+	Event     string    `json:"event"`
+	GuildID   string    `json:"guild_id"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+// webhookAnnouncedData is the data of a birthday.announced event
+type webhookAnnouncedData struct {
+	UserID        string    `json:"user_id"`
+	BirthdayYear  int       `json:"birthday_year"`
+	Age           *int      `json:"age"`
+	ChannelID     string    `json:"channel_id"`
+	MessageID     string    `json:"message_id"`
+	Late          bool      `json:"late"`
+	RoleExpiresAt time.Time `json:"role_expires_at"`
+}
+
+// webhookRoleExpiredData is the data of a birthday.role_expired event
+type webhookRoleExpiredData struct {
+	UserID      string    `json:"user_id"`
+	RoleID      string    `json:"role_id"`
+	ExpiredAt   time.Time `json:"expired_at"`
+	RoleRemoved bool      `json:"role_removed"`
+}
+
+// webhookDispatcher delivers events to guild webhooks in the background, retrying failures
+type webhookDispatcher struct {
+	store       webhookStore
+	client      *http.Client
+	clock       clock.Clock
+	retryDelays []time.Duration
+	stopCh      <-chan struct{}
+	wg          sync.WaitGroup
+
+	mu      sync.Mutex // guards stopped, so no delivery is added to wg once stop is waiting on it
+	stopped bool
+}
+
+// newWebhookDispatcher creates a dispatcher that stops retrying once stopCh is closed
+func newWebhookDispatcher(store webhookStore, clk clock.Clock, stopCh <-chan struct{}) *webhookDispatcher {
+	return &webhookDispatcher{
+		store:       store,
+		client:      newWebhookHTTPClient(),
+		clock:       clk,
+		retryDelays: defaultWebhookRetryDelays,
+		stopCh:      stopCh,
+	}
+}
+
+// fireWebhook sends an event to the guild's webhooks without blocking the caller
+func (b *Bot) fireWebhook(guildID, event string, data any) {
+	if b.webhooks == nil {
+		return
+	}
+	b.webhooks.dispatch(guildID, event, data)
+}
+
+// dispatch delivers an event to every webhook of the guild in the background
+func (d *webhookDispatcher) dispatch(guildID, event string, data any) {
+	d.mu.Lock()
+	if d.stopped {
+		d.mu.Unlock()
+		slog.Debug("Dropping webhook event after shutdown", "guild_id", guildID, "event", event)
+		return
+	}
+	d.wg.Add(1)
+	d.mu.Unlock()
+
+	go func() {
+		defer d.wg.Done()
+
+		ctx := context.Background()
+		webhooks, err := d.store.GetGuildWebhooks(ctx, guildID)
+		if err != nil {
+			slog.Error("Failed to get guild webhooks", "guild_id", guildID, "event", event, "error", err)
+			return
+		}
+		if len(webhooks) == 0 {
+			return
+		}
+		secret, err := d.store.GetWebhookSecret(ctx, guildID)
+		if err != nil || secret == nil {
+			slog.Error("Missing webhook secret, not delivering", "guild_id", guildID, "event", event, "error", err)
+			return
+		}
+
+		for _, wh := range webhooks {
+			payload := webhookPayload{
+				ID:        newDeliveryID(),
+				Event:     event,
+				GuildID:   guildID,
+				CreatedAt: d.clock.Now().UTC(),
+				Data:      data,
+			}
+			d.wg.Add(1)
+			go func() {
+				defer d.wg.Done()
+				d.deliver(ctx, wh, *secret, payload)
+			}()
+		}
+	}()
+}
+
+// deliver posts a payload to one webhook, retrying with backoff, and records the outcome
+func (d *webhookDispatcher) deliver(ctx context.Context, wh database.Webhook, secret string, payload webhookPayload) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		slog.Error("Failed to encode webhook payload", "event", payload.Event, "error", err)
+		return
+	}
+
+	delivery := &database.WebhookDelivery{
+		DeliveryID: payload.ID,
+		WebhookID:  wh.WebhookID,
+		GuildID:    wh.GuildID,
+		Event:      payload.Event,
+		CreatedAt:  payload.CreatedAt,
+	}
+
+	for attempt := 1; ; attempt++ {
+		delivery.Attempts = attempt
+		status, retry, err := d.post(ctx, wh.URL, secret, payload, body)
+		delivery.StatusCode = nil
+		if status != 0 {
+			delivery.StatusCode = &status
+		}
+		if err == nil {
+			delivery.Success = true
+			delivery.Error = nil
+			break
+		}
+		msg := err.Error()
+		delivery.Error = &msg
+
+		if !retry || attempt > len(d.retryDelays) {
+			break
+		}
+		slog.Debug("Webhook delivery failed, retrying", "webhook_id", wh.WebhookID, "attempt", attempt, "error", err)
+		if !d.sleep(d.retryDelays[attempt-1]) {
+			msg += " (retries cancelled by shutdown)"
+			break
+		}
+	}
+	delivery.CompletedAt = d.clock.Now().UTC()
+
+	if delivery.Success {
+		slog.Info("Delivered webhook", "guild_id", wh.GuildID, "webhook_id", wh.WebhookID, "event", payload.Event, "attempts", delivery.Attempts)
+	} else {
+		slog.Warn("Webhook delivery failed", "guild_id", wh.GuildID, "webhook_id", wh.WebhookID, "event", payload.Event, "attempts", delivery.Attempts, "error", *delivery.Error)
+	}
+	if err := d.store.RecordWebhookDelivery(ctx, delivery); err != nil {
+		slog.Error("Failed to record webhook delivery", "delivery_id", delivery.DeliveryID, "error", err)
+	}
+}
+
+// post makes a single signed delivery attempt. It returns the response status (0 if there was none)
+// and whether a failure is worth retrying.
+func (d *webhookDispatcher) post(ctx context.Context, target, secret string, payload webhookPayload, body []byte) (int, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, webhookTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+	// Sign with the send time so retries aren't rejected as replays
+	timestamp := strconv.FormatInt(d.clock.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "cyan-birthdays-webhooks")
+	req.Header.Set(webhookHeaderEvent, payload.Event)
+	req.Header.Set(webhookHeaderDelivery, payload.ID)
+	req.Header.Set(webhookHeaderTimestamp, timestamp)
+	req.Header.Set(webhookHeaderSignature, "sha256="+signWebhook(secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, !errors.Is(err, errWebhookAddressBlocked), err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, false, nil
+	}
+	retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+	return resp.StatusCode, retry, fmt.Errorf("unexpected status %s", resp.Status)
+}
+
+// sleep waits for d, returning false if the bot is stopping
+func (d *webhookDispatcher) sleep(wait time.Duration) bool {
+	select {
+	case <-d.stopCh:
+		return false
+	case <-time.After(wait):
+		return true
+	}
+}
+
+// wait blocks until in-flight deliveries have finished
+func (d *webhookDispatcher) wait() {
+	d.wg.Wait()
+}
+
+// stop refuses any further events, then waits for in-flight deliveries to finish
+func (d *webhookDispatcher) stop() {
+	d.mu.Lock()
+	d.stopped = true
+	d.mu.Unlock()
+	d.wait()
+}
+
+// signWebhook returns the hex HMAC-SHA256 of "<timestamp>.<body>" under the guild's secret
+func signWebhook(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookSecret returns the guild's webhook signing secret, creating it if needed or if rotate is set
+func (b *Bot) webhookSecret(ctx context.Context, guildID string, rotate bool) (string, error) {
+	if !rotate {
+		secret, err := b.repo.GetWebhookSecret(ctx, guildID)
+		if err != nil {
+			return "", err
+		}
+		if secret != nil {
+			return *secret, nil
+		}
+	}
+	secret := newWebhookSecret()
+	if err := b.repo.SetWebhookSecret(ctx, guildID, secret); err != nil {
+		return "", err
+	}
+	return secret, nil
+}
+
+// formatWebhookDelivery renders a delivery log entry for Discord
+func formatWebhookDelivery(loc i18n.Locale, d database.WebhookDelivery) string {
+	result := "✅"
+	if !d.Success {
+		result = "❌"
+	}
+	line := fmt.Sprintf("%s <t:%d:f> · `%s` → ", result, d.CreatedAt.Unix(), d.Event) + i18n.T(loc, "webhook.delivery_target", d.WebhookID)
+	if d.StatusCode != nil {
+		line += fmt.Sprintf(" · HTTP %d", *d.StatusCode)
+	}
+	if d.Attempts > 1 {
+		line += " · " + i18n.T(loc, "webhook.delivery_attempts", d.Attempts)
+	}
+	if d.Error != nil && !d.Success {
+		errText := *d.Error
+		if len(errText) > 120 {
+			errText = errText[:117] + "..."
+		}
+		line += " · " + errText
+	}
+	return line
+}
+
+// newWebhookSecret generates a guild's webhook signing secret
+func newWebhookSecret() string {
+	buf := make([]byte, 32)
+	rand.Read(buf)
+	return "whsec_" + hex.EncodeToString(buf)
+}
+
+// newDeliveryID generates a unique ID for a webhook delivery
+func newDeliveryID() string {
+	buf := make([]byte, 16)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// validateWebhookURL checks that a webhook URL is an absolute http(s) URL
+func validateWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return errors.New("not a valid URL")
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return errors.New("the URL must start with https:// or http://")
+	}
+	if u.User != nil {
+		return errors.New("the URL must not contain credentials")
+	}
+	return nil
+}
+
+// errWebhookAddressBlocked is returned when a webhook resolves to a non-public address
+var errWebhookAddressBlocked = errors.New("webhook address is not publicly routable")
+
+// newWebhookHTTPClient returns a client that refuses to connect to loopback, private and link-local
+// addresses, so guild admins can't use webhooks to reach the bot host's internal network
+func newWebhookHTTPClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !isPublicIP(ip) {
+				return errWebhookAddressBlocked
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+	return &http.Client{
+		Transport: transport,
+		Timeout:   webhookTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isPublicIP reports whether ip is a globally routable unicast address
+func isPublicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+}
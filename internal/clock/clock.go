@@ -0,0 +1,109 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time and timers that follow it
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the time once d has passed on this clock
+	After(d time.Duration) <-chan time.Time
+}
+
+// Real is a Clock backed by the system time
+type Real struct{}
+
+// Now returns the current system time
+func (Real) Now() time.Time {
+	return time.Now()
+}
+
+// After waits for d to pass on the system clock
+func (Real) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Fake is a Clock whose time only changes when told to, for use in tests. Its timers fire when
+// Set or Advance moves the clock past them.
+type Fake struct {
+	mu      sync.Mutex
+	changed sync.Cond // signalled when a timer is added
+	now     time.Time
+	timers  []fakeTimer
+}
+
+// fakeTimer is a pending After call on a Fake clock
+type fakeTimer struct {
+	at time.Time
+	ch chan time.Time
+}
+
+// NewFake creates a Fake clock set to t
+func NewFake(t time.Time) *Fake {
+	f := &Fake{now: t}
+	f.changed.L = &f.mu
+	return f
+}
+
+// Now returns the fake current time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// After returns a channel that receives the fake time once the clock has been moved d forward
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- f.now
+		return ch
+	}
+	f.timers = append(f.timers, fakeTimer{at: f.now.Add(d), ch: ch})
+	f.changed.Broadcast()
+	return ch
+}
+
+// Set moves the clock to t
+func (f *Fake) Set(t time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = t
+	f.fire()
+}
+
+// Advance moves the clock forward by d
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	f.fire()
+}
+
+// BlockUntil waits until n timers are pending, so a test knows the code under test is waiting on
+// the clock before moving it
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.timers) < n {
+		f.changed.Wait()
+	}
+}
+
+// fire sends the current time to the timers that are due. The caller must hold f.mu.
+func (f *Fake) fire() {
+	pending := f.timers[:0]
+	for _, t := range f.timers {
+		if t.at.After(f.now) {
+			pending = append(pending, t)
+			continue
+		}
+		t.ch <- f.now
+	}
+	f.timers = pending
+}
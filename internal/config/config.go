@@ -2,7 +2,10 @@ package config
 
 import (
 	"errors"
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 )
 
 // Config holds the bot configuration
@@ -11,6 +14,11 @@ type Config struct {
 	DatabaseURL  string
 	OwnerID      string
 	LogLevel     string
+	HTTPAddr     string // listen address for the optional HTTP server, e.g. ":8080"; empty disables it
+	PublicURL    string // externally reachable base URL of the HTTP server, used in links
+	MetricsAddr  string // listen address for /metrics, kept off the public HTTP server; empty disables it
+	MinAge       int    // youngest age a birth year may give; 0 allows any
+	MaxAge       int    // oldest age a birth year may give; 0 uses the parser's default
 }
 
 // Load reads configuration from environment variables
@@ -20,9 +28,21 @@ func Load() (*Config, error) {
 		return nil, errors.New("DISCORD_TOKEN environment variable is required")
 	}
 
-	dbURL := os.Getenv("DATABASE_URL")
-	if dbURL == "" {
-		return nil, errors.New("DATABASE_URL environment variable is required")
+	dbURL, err := LoadDatabaseURL()
+	if err != nil {
+		return nil, err
+	}
+
+	minAge, err := loadAge("BIRTHDAY_MIN_AGE")
+	if err != nil {
+		return nil, err
+	}
+	maxAge, err := loadAge("BIRTHDAY_MAX_AGE")
+	if err != nil {
+		return nil, err
+	}
+	if maxAge != 0 && minAge > maxAge {
+		return nil, errors.New("BIRTHDAY_MIN_AGE must not be greater than BIRTHDAY_MAX_AGE")
 	}
 
 	return &Config{
@@ -30,5 +50,32 @@ func Load() (*Config, error) {
 		DatabaseURL:  dbURL,
 		OwnerID:      os.Getenv("OWNER_ID"),
 		LogLevel:     os.Getenv("LOG_LEVEL"),
+		HTTPAddr:     os.Getenv("HTTP_ADDR"),
+		PublicURL:    strings.TrimRight(os.Getenv("PUBLIC_URL"), "/"),
+		MetricsAddr:  os.Getenv("METRICS_ADDR"),
+		MinAge:       minAge,
+		MaxAge:       maxAge,
 	}, nil
 }
+
+// loadAge reads an optional non-negative age in years from the environment variable name
+func loadAge(name string) (int, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return 0, nil
+	}
+	age, err := strconv.Atoi(v)
+	if err != nil || age < 0 {
+		return 0, fmt.Errorf("%s must be a whole number of years, got %q", name, v)
+	}
+	return age, nil
+}
+
+// LoadDatabaseURL reads only the database connection string, for commands that don't talk to Discord
+func LoadDatabaseURL() (string, error) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		return "", errors.New("DATABASE_URL environment variable is required")
+	}
+	return dbURL, nil
+}
@@ -2,96 +2,263 @@ package database
 
 import (
 	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-const schema = `
-CREATE TABLE IF NOT EXISTS guild_settings (
-    guild_id           VARCHAR(32) PRIMARY KEY,
-    channel_id         VARCHAR(32),
-    role_id            VARCHAR(32),
-    time_utc           INTEGER DEFAULT 0,
-    message_with_year  TEXT DEFAULT '{mention} has turned {new_age}, happy birthday!',
-    message_without_year TEXT DEFAULT 'Happy birthday {mention}!',
-    allow_role_mention BOOLEAN DEFAULT FALSE,
-    required_role_id   VARCHAR(32),
-    default_timezone   VARCHAR(64) DEFAULT 'UTC',
-    european_date_format BOOLEAN DEFAULT FALSE,
-    use_24h_time       BOOLEAN DEFAULT FALSE,
-    setup_complete     BOOLEAN DEFAULT FALSE,
-    created_at         TIMESTAMP DEFAULT NOW(),
-    updated_at         TIMESTAMP DEFAULT NOW()
-);
-
-CREATE TABLE IF NOT EXISTS member_birthdays (
-    guild_id   VARCHAR(32) NOT NULL,
-    user_id    VARCHAR(32) NOT NULL,
-    month      INTEGER NOT NULL,
-    day        INTEGER NOT NULL,
-    year       INTEGER,
-    timezone   VARCHAR(64) DEFAULT 'UTC',
-    created_at TIMESTAMP DEFAULT NOW(),
-    updated_at TIMESTAMP DEFAULT NOW(),
-    PRIMARY KEY (guild_id, user_id)
-);
-
-CREATE TABLE IF NOT EXISTS active_birthday_roles (
-    guild_id         VARCHAR(32) NOT NULL,
-    user_id          VARCHAR(32) NOT NULL,
-    role_assigned_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    role_expires_at  TIMESTAMP NOT NULL,
-    PRIMARY KEY (guild_id, user_id)
-);
-
-CREATE TABLE IF NOT EXISTS bot_admins (
-    guild_id    VARCHAR(32) NOT NULL,
-    target_id   VARCHAR(32) NOT NULL,
-    target_type VARCHAR(8) NOT NULL,
-    added_by    VARCHAR(32) NOT NULL,
-    added_at    TIMESTAMP DEFAULT NOW(),
-    PRIMARY KEY (guild_id, target_id, target_type)
-);
-
-CREATE INDEX IF NOT EXISTS idx_birthdays_date ON member_birthdays(month, day);
-CREATE INDEX IF NOT EXISTS idx_active_roles_expiry ON active_birthday_roles(role_expires_at);
-CREATE INDEX IF NOT EXISTS idx_bot_admins_guild ON bot_admins(guild_id);
-`
-
-// migrations to add new columns to existing tables
-const migrations = `
--- Add european_date_format column if it doesn't exist
-DO $$ 
-BEGIN 
-    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
-                   WHERE table_name='guild_settings' AND column_name='european_date_format') THEN
-        ALTER TABLE guild_settings ADD COLUMN european_date_format BOOLEAN DEFAULT FALSE;
-    END IF;
-END $$;
-
--- Add use_24h_time column if it doesn't exist
-DO $$ 
-BEGIN 
-    IF NOT EXISTS (SELECT 1 FROM information_schema.columns 
-                   WHERE table_name='guild_settings' AND column_name='use_24h_time') THEN
-        ALTER TABLE guild_settings ADD COLUMN use_24h_time BOOLEAN DEFAULT FALSE;
-    END IF;
-END $$;
-`
-
-// Migrate runs the database migrations
-func Migrate(pool *pgxpool.Pool) error {
-	ctx := context.Background()
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// migrationLockID is the advisory lock key held while migrating so that
+// concurrently starting bot instances don't apply migrations twice
+const migrationLockID = 0x62646179 // "bday"
+
+// Migration is a numbered schema change with up and down SQL
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus describes whether a migration has been applied
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt *time.Time
+}
+
+// loadMigrations reads the embedded NNNN_name.up.sql / NNNN_name.down.sql files, sorted by version
+func loadMigrations() ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		name := e.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(name, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(name, ".down.sql"):
+			direction = "down"
+		default:
+			return nil, fmt.Errorf("unexpected migration file: %s", name)
+		}
+
+		base := strings.TrimSuffix(name, "."+direction+".sql")
+		versionStr, label, ok := strings.Cut(base, "_")
+		if !ok {
+			return nil, fmt.Errorf("migration file missing name: %s", name)
+		}
+		version, err := strconv.Atoi(versionStr)
+		if err != nil {
+			return nil, fmt.Errorf("migration file has invalid version: %s", name)
+		}
+
+		body, err := migrationFiles.ReadFile(path.Join("migrations", name))
+		if err != nil {
+			return nil, err
+		}
 
-	// Create tables
-	if _, err := pool.Exec(ctx, schema); err != nil {
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: label}
+			byVersion[version] = m
+		} else if m.Name != label {
+			return nil, fmt.Errorf("migration %04d has conflicting names %q and %q", version, m.Name, label)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up file", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	return migrations, nil
+}
+
+// withMigrationLock runs fn on a dedicated connection holding the migration advisory lock
+func withMigrationLock(ctx context.Context, pool *pgxpool.Pool, fn func(conn *pgxpool.Conn) error) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
 		return err
 	}
+	defer conn.Release()
 
-	// Run migrations for new columns
-	if _, err := pool.Exec(ctx, migrations); err != nil {
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return fmt.Errorf("acquire migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.Exec(context.Background(), `SELECT pg_advisory_unlock($1)`, migrationLockID); err != nil {
+			slog.Warn("Failed to release migration lock", "error", err)
+		}
+	}()
+
+	if _, err := conn.Exec(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+		    version    INTEGER PRIMARY KEY,
+		    name       VARCHAR(128) NOT NULL,
+		    applied_at TIMESTAMP NOT NULL DEFAULT NOW()
+		)
+	`); err != nil {
 		return err
 	}
 
-	return nil
+	return fn(conn)
+}
+
+// appliedMigrations returns the applied versions and when they ran
+func appliedMigrations(ctx context.Context, conn *pgxpool.Conn) (map[int]time.Time, error) {
+	rows, err := conn.Query(ctx, `SELECT version, applied_at FROM schema_migrations`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	return applied, rows.Err()
+}
+
+// applyMigration runs one direction of a migration and updates schema_migrations in a single transaction
+func applyMigration(ctx context.Context, conn *pgxpool.Conn, m Migration, up bool) error {
+	return pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		body := m.Up
+		if !up {
+			body = m.Down
+		}
+		if _, err := tx.Exec(ctx, body); err != nil {
+			return err
+		}
+		if up {
+			_, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version, name) VALUES ($1, $2)`, m.Version, m.Name)
+			return err
+		}
+		_, err := tx.Exec(ctx, `DELETE FROM schema_migrations WHERE version = $1`, m.Version)
+		return err
+	})
+}
+
+// Migrate applies all pending migrations
+func Migrate(pool *pgxpool.Pool) error {
+	_, err := MigrateUp(context.Background(), pool)
+	return err
+}
+
+// MigrateUp applies all pending migrations in order and returns the ones it applied
+func MigrateUp(ctx context.Context, pool *pgxpool.Pool) ([]Migration, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []Migration
+	err = withMigrationLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range migrations {
+			if _, ok := applied[m.Version]; ok {
+				continue
+			}
+			slog.Info("Applying migration", "version", m.Version, "name", m.Name)
+			if err := applyMigration(ctx, conn, m, true); err != nil {
+				return fmt.Errorf("migration %04d_%s: %w", m.Version, m.Name, err)
+			}
+			ran = append(ran, m)
+		}
+		return nil
+	})
+	return ran, err
+}
+
+// MigrateDown rolls back the most recently applied migrations, up to steps of them
+func MigrateDown(ctx context.Context, pool *pgxpool.Pool, steps int) ([]Migration, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []Migration
+	err = withMigrationLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for idx := len(migrations) - 1; idx >= 0 && len(ran) < steps; idx-- {
+			m := migrations[idx]
+			if _, ok := applied[m.Version]; !ok {
+				continue
+			}
+			if m.Down == "" {
+				return fmt.Errorf("migration %04d_%s cannot be rolled back", m.Version, m.Name)
+			}
+			slog.Info("Rolling back migration", "version", m.Version, "name", m.Name)
+			if err := applyMigration(ctx, conn, m, false); err != nil {
+				return fmt.Errorf("rollback %04d_%s: %w", m.Version, m.Name, err)
+			}
+			ran = append(ran, m)
+		}
+		return nil
+	})
+	return ran, err
+}
+
+// GetMigrationStatus lists every known migration and whether it has been applied
+func GetMigrationStatus(ctx context.Context, pool *pgxpool.Pool) ([]MigrationStatus, error) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []MigrationStatus
+	err = withMigrationLock(ctx, pool, func(conn *pgxpool.Conn) error {
+		applied, err := appliedMigrations(ctx, conn)
+		if err != nil {
+			return err
+		}
+		for _, m := range migrations {
+			st := MigrationStatus{Migration: m}
+			if at, ok := applied[m.Version]; ok {
+				st.Applied = true
+				st.AppliedAt = &at
+			}
+			statuses = append(statuses, st)
+		}
+		return nil
+	})
+	return statuses, err
 }
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS bot_admins;
+DROP TABLE IF EXISTS active_birthday_roles;
+DROP TABLE IF EXISTS member_birthdays;
+DROP TABLE IF EXISTS guild_settings;
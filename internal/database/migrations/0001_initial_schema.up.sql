@@ -0,0 +1,47 @@
+CREATE TABLE IF NOT EXISTS guild_settings (
+    guild_id           VARCHAR(32) PRIMARY KEY,
+    channel_id         VARCHAR(32),
+    role_id            VARCHAR(32),
+    time_utc           INTEGER DEFAULT 0,
+    message_with_year  TEXT DEFAULT '{mention} has turned {new_age}, happy birthday!',
+    message_without_year TEXT DEFAULT 'Happy birthday {mention}!',
+    allow_role_mention BOOLEAN DEFAULT FALSE,
+    required_role_id   VARCHAR(32),
+    default_timezone   VARCHAR(64) DEFAULT 'UTC',
+    setup_complete     BOOLEAN DEFAULT FALSE,
+    created_at         TIMESTAMP DEFAULT NOW(),
+    updated_at         TIMESTAMP DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS member_birthdays (
+    guild_id   VARCHAR(32) NOT NULL,
+    user_id    VARCHAR(32) NOT NULL,
+    month      INTEGER NOT NULL,
+    day        INTEGER NOT NULL,
+    year       INTEGER,
+    timezone   VARCHAR(64) DEFAULT 'UTC',
+    created_at TIMESTAMP DEFAULT NOW(),
+    updated_at TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (guild_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS active_birthday_roles (
+    guild_id         VARCHAR(32) NOT NULL,
+    user_id          VARCHAR(32) NOT NULL,
+    role_assigned_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    role_expires_at  TIMESTAMP NOT NULL,
+    PRIMARY KEY (guild_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS bot_admins (
+    guild_id    VARCHAR(32) NOT NULL,
+    target_id   VARCHAR(32) NOT NULL,
+    target_type VARCHAR(8) NOT NULL,
+    added_by    VARCHAR(32) NOT NULL,
+    added_at    TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (guild_id, target_id, target_type)
+);
+
+CREATE INDEX IF NOT EXISTS idx_birthdays_date ON member_birthdays(month, day);
+CREATE INDEX IF NOT EXISTS idx_active_roles_expiry ON active_birthday_roles(role_expires_at);
+CREATE INDEX IF NOT EXISTS idx_bot_admins_guild ON bot_admins(guild_id);
@@ -0,0 +1,2 @@
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS use_24h_time;
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS european_date_format;
@@ -0,0 +1,2 @@
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS european_date_format BOOLEAN DEFAULT FALSE;
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS use_24h_time BOOLEAN DEFAULT FALSE;
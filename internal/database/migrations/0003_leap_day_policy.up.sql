@@ -0,0 +1 @@
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS leap_day_policy VARCHAR(16) DEFAULT 'feb28';
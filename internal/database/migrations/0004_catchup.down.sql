@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS loop_state;
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS catchup_hours;
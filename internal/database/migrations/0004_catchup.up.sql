@@ -0,0 +1,6 @@
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS catchup_hours INTEGER DEFAULT 6;
+
+CREATE TABLE IF NOT EXISTS loop_state (
+    id                INTEGER PRIMARY KEY DEFAULT 1 CHECK (id = 1),
+    last_processed_at TIMESTAMP NOT NULL
+);
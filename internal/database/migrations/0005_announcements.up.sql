@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS announcements (
+    guild_id      VARCHAR(32) NOT NULL,
+    user_id       VARCHAR(32) NOT NULL,
+    birthday_year INTEGER NOT NULL,
+    channel_id    VARCHAR(32) NOT NULL,
+    message_id    VARCHAR(32),
+    announced_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, user_id, birthday_year)
+);
+
+CREATE INDEX IF NOT EXISTS idx_announcements_guild ON announcements(guild_id, announced_at DESC);
+
+-- Backfill announcements for birthday roles handed out before announcement history existed
+INSERT INTO announcements (guild_id, user_id, birthday_year, channel_id, announced_at)
+SELECT abr.guild_id, abr.user_id, EXTRACT(YEAR FROM abr.role_assigned_at)::INTEGER,
+       COALESCE(gs.channel_id, ''), abr.role_assigned_at
+FROM active_birthday_roles abr
+LEFT JOIN guild_settings gs ON gs.guild_id = abr.guild_id
+ON CONFLICT (guild_id, user_id, birthday_year) DO NOTHING;
@@ -0,0 +1,2 @@
+ALTER TABLE member_birthdays DROP COLUMN IF EXISTS calendar_opt_out;
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS calendar_token;
@@ -0,0 +1,2 @@
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS calendar_token VARCHAR(64);
+ALTER TABLE member_birthdays ADD COLUMN IF NOT EXISTS calendar_opt_out BOOLEAN NOT NULL DEFAULT FALSE;
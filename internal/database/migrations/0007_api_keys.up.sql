@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS guild_api_keys (
+    key_id       VARCHAR(16) PRIMARY KEY,
+    guild_id     VARCHAR(32) NOT NULL,
+    name         VARCHAR(100) NOT NULL,
+    key_hash     CHAR(64) NOT NULL UNIQUE,
+    created_by   VARCHAR(32) NOT NULL,
+    created_at   TIMESTAMP NOT NULL DEFAULT NOW(),
+    last_used_at TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_guild_api_keys_guild ON guild_api_keys(guild_id);
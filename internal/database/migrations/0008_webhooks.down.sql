@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS guild_webhooks;
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS webhook_secret;
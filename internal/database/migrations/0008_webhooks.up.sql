@@ -0,0 +1,26 @@
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS webhook_secret VARCHAR(64);
+
+CREATE TABLE IF NOT EXISTS guild_webhooks (
+    webhook_id BIGSERIAL PRIMARY KEY,
+    guild_id   VARCHAR(32) NOT NULL,
+    url        TEXT NOT NULL,
+    created_by VARCHAR(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_guild_webhooks_guild ON guild_webhooks(guild_id);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    delivery_id  VARCHAR(32) PRIMARY KEY,
+    webhook_id   BIGINT NOT NULL,
+    guild_id     VARCHAR(32) NOT NULL,
+    event        VARCHAR(50) NOT NULL,
+    attempts     INTEGER NOT NULL,
+    status_code  INTEGER,
+    error        TEXT,
+    success      BOOLEAN NOT NULL,
+    created_at   TIMESTAMP NOT NULL,
+    completed_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_guild ON webhook_deliveries(guild_id, created_at DESC);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS dm_notifications;
+ALTER TABLE member_birthdays DROP COLUMN IF EXISTS dm_reminder;
+ALTER TABLE member_birthdays DROP COLUMN IF EXISTS dm_greeting;
@@ -0,0 +1,14 @@
+ALTER TABLE member_birthdays ADD COLUMN IF NOT EXISTS dm_greeting BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE member_birthdays ADD COLUMN IF NOT EXISTS dm_reminder BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- One row per direct message sent about a birthday, so each is sent at most once per year
+CREATE TABLE IF NOT EXISTS dm_notifications (
+    guild_id      VARCHAR(32) NOT NULL,
+    user_id       VARCHAR(32) NOT NULL, -- whose birthday it is
+    recipient_id  VARCHAR(32) NOT NULL,
+    kind          VARCHAR(16) NOT NULL,
+    birthday_year INTEGER NOT NULL,
+    delivered     BOOLEAN NOT NULL,
+    sent_at       TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, user_id, recipient_id, kind, birthday_year)
+);
@@ -0,0 +1,11 @@
+-- Members who want a DM some days before another member's birthday
+CREATE TABLE IF NOT EXISTS birthday_follows (
+    guild_id    VARCHAR(32) NOT NULL,
+    follower_id VARCHAR(32) NOT NULL,
+    user_id     VARCHAR(32) NOT NULL, -- whose birthday is followed
+    days_before INTEGER NOT NULL DEFAULT 1 CHECK (days_before BETWEEN 1 AND 30),
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, follower_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_birthday_follows_user ON birthday_follows(guild_id, user_id);
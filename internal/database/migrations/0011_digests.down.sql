@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS digest_posts;
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS weekly_digest_hour;
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS weekly_digest_day;
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS announcement_mode;
@@ -0,0 +1,16 @@
+-- 'individual' posts one announcement per birthday, 'digest' one message per day for all of them
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS announcement_mode VARCHAR(16) NOT NULL DEFAULT 'individual';
+-- Weekday (0 = Sunday) and hour in the default timezone of the weekly "coming up" post; NULL disables it
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS weekly_digest_day INTEGER;
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS weekly_digest_hour INTEGER NOT NULL DEFAULT 9;
+
+-- One row per scheduled digest post, so each is posted at most once
+CREATE TABLE IF NOT EXISTS digest_posts (
+    guild_id   VARCHAR(32) NOT NULL,
+    kind       VARCHAR(16) NOT NULL,
+    period     DATE NOT NULL,
+    channel_id VARCHAR(32) NOT NULL,
+    message_id VARCHAR(32), -- NULL when there was nothing to post
+    posted_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (guild_id, kind, period)
+);
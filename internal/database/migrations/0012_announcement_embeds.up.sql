@@ -0,0 +1,12 @@
+-- Embed design for birthday announcements; guilds without a row (or disabled) announce in plain text
+CREATE TABLE IF NOT EXISTS announcement_embeds (
+    guild_id    VARCHAR(32) PRIMARY KEY,
+    enabled     BOOLEAN NOT NULL DEFAULT FALSE,
+    title       TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '', -- empty uses the guild's birthday message
+    color       INTEGER,
+    image_url   TEXT NOT NULL DEFAULT '',
+    footer      TEXT NOT NULL DEFAULT '',
+    show_avatar BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,2 @@
+ALTER TABLE announcements DROP COLUMN IF EXISTS template_id;
+DROP TABLE IF EXISTS guild_messages;
@@ -0,0 +1,15 @@
+-- Extra birthday message templates; guilds without any use message_with_year/message_without_year
+CREATE TABLE IF NOT EXISTS guild_messages (
+    template_id BIGSERIAL PRIMARY KEY,
+    guild_id    VARCHAR(32) NOT NULL,
+    template    TEXT NOT NULL,
+    audience    VARCHAR(16) NOT NULL DEFAULT 'any', -- 'any', 'with_year' or 'without_year'
+    weight      INTEGER NOT NULL DEFAULT 1 CHECK (weight BETWEEN 1 AND 100),
+    created_by  VARCHAR(32) NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_guild_messages_guild ON guild_messages(guild_id);
+
+-- Which template an announcement used, so a member doesn't get the same one two years running
+ALTER TABLE announcements ADD COLUMN IF NOT EXISTS template_id BIGINT;
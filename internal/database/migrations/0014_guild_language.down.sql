@@ -0,0 +1 @@
+ALTER TABLE guild_settings DROP COLUMN IF EXISTS language;
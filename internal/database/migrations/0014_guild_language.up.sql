@@ -0,0 +1,2 @@
+-- Language code for the bot's replies and announcements; empty follows each member's Discord language
+ALTER TABLE guild_settings ADD COLUMN IF NOT EXISTS language VARCHAR(8) NOT NULL DEFAULT '';
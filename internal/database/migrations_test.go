@@ -0,0 +1,25 @@
+package database
+
+import "testing"
+
+func TestLoadMigrations(t *testing.T) {
+	migrations, err := loadMigrations()
+	if err != nil {
+		t.Fatalf("loadMigrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("no embedded migrations found")
+	}
+
+	for idx, m := range migrations {
+		if want := idx + 1; m.Version != want {
+			t.Errorf("migration %d has version %04d, want %04d (versions must be contiguous)", idx, m.Version, want)
+		}
+		if m.Name == "" {
+			t.Errorf("migration %04d has no name", m.Version)
+		}
+		if m.Up == "" || m.Down == "" {
+			t.Errorf("migration %04d_%s must have both up and down SQL", m.Version, m.Name)
+		}
+	}
+}
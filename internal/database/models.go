@@ -2,12 +2,45 @@ package database
 
 import (
 	"context"
+	"errors"
 	"log/slog"
+	"sync"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// DefaultCatchupHours is the catch-up window new guilds start with (the catchup_hours column default)
+const DefaultCatchupHours = 6
+
+// DefaultGuildSettings returns the settings a new guild_settings row gets from its column defaults
+func DefaultGuildSettings(guildID string) *GuildSettings {
+	return &GuildSettings{
+		GuildID:            guildID,
+		MessageWithYear:    "{mention} has turned {new_age}, happy birthday!",
+		MessageWithoutYear: "Happy birthday {mention}!",
+		DefaultTimezone:    "UTC",
+		LeapDayPolicy:      "feb28",
+		CatchupHours:       DefaultCatchupHours,
+		AnnouncementMode:   AnnouncementModeIndividual,
+		WeeklyDigestHour:   9,
+	}
+}
+
+// Announcement modes
+const (
+	AnnouncementModeIndividual = "individual" // one message per birthday
+	AnnouncementModeDigest     = "digest"     // one message per day listing all of the day's birthdays
+)
+
+// Guild message audiences: which members a message template can be used for
+const (
+	MessageAudienceAny         = "any"
+	MessageAudienceWithYear    = "with_year"    // members who shared their birth year
+	MessageAudienceWithoutYear = "without_year" // members who didn't
+)
+
 // GuildSettings represents per-guild configuration
 type GuildSettings struct {
 	GuildID            string
@@ -21,6 +54,12 @@ type GuildSettings struct {
 	DefaultTimezone    string
 	EuropeanDateFormat bool
 	Use24hTime         bool
+	LeapDayPolicy      string
+	CatchupHours       int
+	AnnouncementMode   string
+	WeeklyDigestDay    *int // time.Weekday of the weekly digest, nil if disabled
+	WeeklyDigestHour   int
+	Language           string // i18n locale code, "" to follow each member's Discord language
 	SetupComplete      bool
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
@@ -36,6 +75,69 @@ type MemberBirthday struct {
 	Timezone  string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// Calendar and direct message preferences, only loaded by GetMemberBirthday, GetAllGuildBirthdays and GetBirthdayCandidates
+	CalendarOptOut bool
+	DMGreeting     bool
+	DMReminder     bool
+}
+
+// DMNotification records a direct message sent about a member's birthday
+type DMNotification struct {
+	GuildID      string
+	UserID       string // whose birthday it is
+	RecipientID  string
+	Kind         string
+	BirthdayYear int
+	Delivered    bool
+	SentAt       time.Time
+}
+
+// DigestPost records a scheduled digest message for one period
+type DigestPost struct {
+	GuildID   string
+	Kind      string
+	Period    time.Time // the local date the digest is for
+	ChannelID string
+	MessageID *string // nil if there was nothing to post
+	PostedAt  time.Time
+}
+
+// AnnouncementEmbed is a guild's embed design for birthday announcements
+type AnnouncementEmbed struct {
+	GuildID     string
+	Enabled     bool
+	Title       string
+	Description string // empty uses the guild's birthday message
+	Color       *int
+	ImageURL    string
+	Footer      string
+	ShowAvatar  bool
+	UpdatedAt   time.Time
+}
+
+// GuildMessage is one of a guild's birthday message templates
+type GuildMessage struct {
+	TemplateID int64
+	GuildID    string
+	Template   string
+	Audience   string
+	Weight     int
+	CreatedBy  string
+	CreatedAt  time.Time
+}
+
+// BirthdayFollow is a member's request to be reminded of another member's birthday
+type BirthdayFollow struct {
+	GuildID    string
+	FollowerID string
+	UserID     string // whose birthday is followed
+	DaysBefore int
+	CreatedAt  time.Time
+
+	// Loaded by GetBirthdayFollows and GetMemberFollows
+	FollowerTimezone string          // the follower's own timezone, or the guild default
+	Birthday         *MemberBirthday // nil if the followed member hasn't set a birthday
 }
 
 // ActiveBirthdayRole tracks when a user's birthday role should expire
@@ -46,6 +148,17 @@ type ActiveBirthdayRole struct {
 	RoleExpiresAt  time.Time
 }
 
+// Announcement records a birthday announcement sent for a member in a given year
+type Announcement struct {
+	GuildID      string
+	UserID       string
+	BirthdayYear int
+	ChannelID    string
+	MessageID    *string
+	TemplateID   *int64 // the guild message used, nil for the default messages
+	AnnouncedAt  time.Time
+}
+
 // BotAdmin represents a user or role that has admin permissions for the bot in a guild
 type BotAdmin struct {
 	GuildID    string
@@ -55,9 +168,46 @@ type BotAdmin struct {
 	AddedAt    time.Time
 }
 
+// APIKey is a per-guild key for the REST API. Only a hash of the secret is stored.
+type APIKey struct {
+	KeyID      string
+	GuildID    string
+	Name       string
+	KeyHash    string
+	CreatedBy  string
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+}
+
+// Webhook is an outgoing webhook a guild has registered for birthday events
+type Webhook struct {
+	WebhookID int64
+	GuildID   string
+	URL       string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// WebhookDelivery records the outcome of delivering one event to one webhook, after any retries
+type WebhookDelivery struct {
+	DeliveryID  string
+	WebhookID   int64
+	GuildID     string
+	Event       string
+	Attempts    int
+	StatusCode  *int
+	Error       *string
+	Success     bool
+	CreatedAt   time.Time
+	CompletedAt time.Time
+}
+
 // Repository handles database operations
 type Repository struct {
 	pool *pgxpool.Pool
+
+	tzMu    sync.Mutex
+	tzNames []string // zone names the server knows, loaded on first use
 }
 
 // NewRepository creates a new database repository
@@ -72,13 +222,16 @@ func (r *Repository) GetGuildSettings(ctx context.Context, guildID string) (*Gui
 		SELECT guild_id, channel_id, role_id, time_utc, message_with_year, 
 		       message_without_year, allow_role_mention, required_role_id,
 		       default_timezone, european_date_format, use_24h_time,
-		       setup_complete, created_at, updated_at
+		       leap_day_policy, catchup_hours, announcement_mode, weekly_digest_day,
+		       weekly_digest_hour, language, setup_complete, created_at, updated_at
 		FROM guild_settings WHERE guild_id = $1
 	`, guildID).Scan(
 		&gs.GuildID, &gs.ChannelID, &gs.RoleID, &gs.TimeUTC,
 		&gs.MessageWithYear, &gs.MessageWithoutYear, &gs.AllowRoleMention,
 		&gs.RequiredRoleID, &gs.DefaultTimezone, &gs.EuropeanDateFormat,
-		&gs.Use24hTime, &gs.SetupComplete, &gs.CreatedAt, &gs.UpdatedAt,
+		&gs.Use24hTime, &gs.LeapDayPolicy, &gs.CatchupHours, &gs.AnnouncementMode,
+		&gs.WeeklyDigestDay, &gs.WeeklyDigestHour, &gs.Language, &gs.SetupComplete,
+		&gs.CreatedAt, &gs.UpdatedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -91,8 +244,11 @@ func (r *Repository) UpsertGuildSettings(ctx context.Context, gs *GuildSettings)
 	_, err := r.pool.Exec(ctx, `
 		INSERT INTO guild_settings (guild_id, channel_id, role_id, time_utc, 
 		    message_with_year, message_without_year, allow_role_mention,
-		    required_role_id, default_timezone, setup_complete, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		    required_role_id, default_timezone, european_date_format, use_24h_time,
+		    leap_day_policy, catchup_hours, announcement_mode, weekly_digest_day,
+		    weekly_digest_hour, language, setup_complete, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13,
+		    COALESCE(NULLIF($14, ''), 'individual'), $15, $16, $17, $18, NOW())
 		ON CONFLICT (guild_id) DO UPDATE SET
 		    channel_id = EXCLUDED.channel_id,
 		    role_id = EXCLUDED.role_id,
@@ -102,11 +258,21 @@ func (r *Repository) UpsertGuildSettings(ctx context.Context, gs *GuildSettings)
 		    allow_role_mention = EXCLUDED.allow_role_mention,
 		    required_role_id = EXCLUDED.required_role_id,
 		    default_timezone = EXCLUDED.default_timezone,
+		    european_date_format = EXCLUDED.european_date_format,
+		    use_24h_time = EXCLUDED.use_24h_time,
+		    leap_day_policy = EXCLUDED.leap_day_policy,
+		    catchup_hours = EXCLUDED.catchup_hours,
+		    announcement_mode = EXCLUDED.announcement_mode,
+		    weekly_digest_day = EXCLUDED.weekly_digest_day,
+		    weekly_digest_hour = EXCLUDED.weekly_digest_hour,
+		    language = EXCLUDED.language,
 		    setup_complete = EXCLUDED.setup_complete,
 		    updated_at = NOW()
 	`, gs.GuildID, gs.ChannelID, gs.RoleID, gs.TimeUTC,
 		gs.MessageWithYear, gs.MessageWithoutYear, gs.AllowRoleMention,
-		gs.RequiredRoleID, gs.DefaultTimezone, gs.SetupComplete)
+		gs.RequiredRoleID, gs.DefaultTimezone, gs.EuropeanDateFormat, gs.Use24hTime,
+		gs.LeapDayPolicy, gs.CatchupHours, gs.AnnouncementMode, gs.WeeklyDigestDay,
+		gs.WeeklyDigestHour, gs.Language, gs.SetupComplete)
 	return err
 }
 
@@ -230,6 +396,56 @@ func (r *Repository) UpdateGuildUse24hTime(ctx context.Context, guildID string,
 	return err
 }
 
+// UpdateGuildLeapDayPolicy sets when Feb 29 birthdays are celebrated in common years
+func (r *Repository) UpdateGuildLeapDayPolicy(ctx context.Context, guildID, policy string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO guild_settings (guild_id, leap_day_policy, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (guild_id) DO UPDATE SET
+		    leap_day_policy = EXCLUDED.leap_day_policy,
+		    updated_at = NOW()
+	`, guildID, policy)
+	return err
+}
+
+// UpdateGuildCatchupHours sets how many hours late a missed announcement may still be sent
+func (r *Repository) UpdateGuildCatchupHours(ctx context.Context, guildID string, hours int) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO guild_settings (guild_id, catchup_hours, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (guild_id) DO UPDATE SET
+		    catchup_hours = EXCLUDED.catchup_hours,
+		    updated_at = NOW()
+	`, guildID, hours)
+	return err
+}
+
+// UpdateGuildDigestSettings sets the announcement mode and the weekly digest schedule (nil day disables it)
+func (r *Repository) UpdateGuildDigestSettings(ctx context.Context, guildID, mode string, weeklyDay *int, weeklyHour int) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO guild_settings (guild_id, announcement_mode, weekly_digest_day, weekly_digest_hour, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (guild_id) DO UPDATE SET
+		    announcement_mode = EXCLUDED.announcement_mode,
+		    weekly_digest_day = EXCLUDED.weekly_digest_day,
+		    weekly_digest_hour = EXCLUDED.weekly_digest_hour,
+		    updated_at = NOW()
+	`, guildID, mode, weeklyDay, weeklyHour)
+	return err
+}
+
+// UpdateGuildLanguage sets the guild's language, or "" to follow each member's Discord language
+func (r *Repository) UpdateGuildLanguage(ctx context.Context, guildID, language string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO guild_settings (guild_id, language, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (guild_id) DO UPDATE SET
+		    language = EXCLUDED.language,
+		    updated_at = NOW()
+	`, guildID, language)
+	return err
+}
+
 // UpdateGuildSetupComplete marks setup as complete
 func (r *Repository) UpdateGuildSetupComplete(ctx context.Context, guildID string, complete bool) error {
 	_, err := r.pool.Exec(ctx, `
@@ -251,7 +467,8 @@ func (r *Repository) GetAllSetupGuilds(ctx context.Context) ([]GuildSettings, er
 		SELECT guild_id, channel_id, role_id, time_utc, message_with_year, 
 		       message_without_year, allow_role_mention, required_role_id,
 		       default_timezone, european_date_format, use_24h_time,
-		       setup_complete, created_at, updated_at
+		       leap_day_policy, catchup_hours, announcement_mode, weekly_digest_day,
+		       weekly_digest_hour, language, setup_complete, created_at, updated_at
 		FROM guild_settings WHERE setup_complete = true
 	`)
 	if err != nil {
@@ -266,7 +483,9 @@ func (r *Repository) GetAllSetupGuilds(ctx context.Context) ([]GuildSettings, er
 			&gs.GuildID, &gs.ChannelID, &gs.RoleID, &gs.TimeUTC,
 			&gs.MessageWithYear, &gs.MessageWithoutYear, &gs.AllowRoleMention,
 			&gs.RequiredRoleID, &gs.DefaultTimezone, &gs.EuropeanDateFormat,
-			&gs.Use24hTime, &gs.SetupComplete, &gs.CreatedAt, &gs.UpdatedAt,
+			&gs.Use24hTime, &gs.LeapDayPolicy, &gs.CatchupHours, &gs.AnnouncementMode,
+			&gs.WeeklyDigestDay, &gs.WeeklyDigestHour, &gs.Language, &gs.SetupComplete,
+			&gs.CreatedAt, &gs.UpdatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -302,11 +521,11 @@ func (r *Repository) SetMemberBirthday(ctx context.Context, mb *MemberBirthday)
 func (r *Repository) GetMemberBirthday(ctx context.Context, guildID, userID string) (*MemberBirthday, error) {
 	var mb MemberBirthday
 	err := r.pool.QueryRow(ctx, `
-		SELECT guild_id, user_id, month, day, year, timezone, created_at, updated_at
+		SELECT guild_id, user_id, month, day, year, timezone, created_at, updated_at, calendar_opt_out, dm_greeting, dm_reminder
 		FROM member_birthdays WHERE guild_id = $1 AND user_id = $2
 	`, guildID, userID).Scan(
 		&mb.GuildID, &mb.UserID, &mb.Month, &mb.Day, &mb.Year,
-		&mb.Timezone, &mb.CreatedAt, &mb.UpdatedAt,
+		&mb.Timezone, &mb.CreatedAt, &mb.UpdatedAt, &mb.CalendarOptOut, &mb.DMGreeting, &mb.DMReminder,
 	)
 	if err != nil {
 		return nil, err
@@ -381,7 +600,7 @@ func (r *Repository) GetAllGuildBirthdays(ctx context.Context, guildID string) (
 	slog.Debug("GetAllGuildBirthdays called", "guildID", guildID)
 
 	rows, err := r.pool.Query(ctx, `
-		SELECT guild_id, user_id, month, day, year, timezone, created_at, updated_at
+		SELECT guild_id, user_id, month, day, year, timezone, created_at, updated_at, calendar_opt_out, dm_greeting, dm_reminder
 		FROM member_birthdays WHERE guild_id = $1
 		ORDER BY month, day
 	`, guildID)
@@ -396,7 +615,7 @@ func (r *Repository) GetAllGuildBirthdays(ctx context.Context, guildID string) (
 		var mb MemberBirthday
 		if err := rows.Scan(
 			&mb.GuildID, &mb.UserID, &mb.Month, &mb.Day, &mb.Year,
-			&mb.Timezone, &mb.CreatedAt, &mb.UpdatedAt,
+			&mb.Timezone, &mb.CreatedAt, &mb.UpdatedAt, &mb.CalendarOptOut, &mb.DMGreeting, &mb.DMReminder,
 		); err != nil {
 			slog.Error("GetAllGuildBirthdays scan failed", "error", err)
 			return nil, err
@@ -408,6 +627,160 @@ func (r *Repository) GetAllGuildBirthdays(ctx context.Context, guildID string) (
 	return birthdays, nil
 }
 
+// GetCalendarBirthdays retrieves a guild's birthdays that members haven't hidden from the calendar
+func (r *Repository) GetCalendarBirthdays(ctx context.Context, guildID string) ([]MemberBirthday, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT guild_id, user_id, month, day, year, timezone, created_at, updated_at
+		FROM member_birthdays WHERE guild_id = $1 AND NOT calendar_opt_out
+		ORDER BY month, day
+	`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var birthdays []MemberBirthday
+	for rows.Next() {
+		var mb MemberBirthday
+		if err := rows.Scan(
+			&mb.GuildID, &mb.UserID, &mb.Month, &mb.Day, &mb.Year,
+			&mb.Timezone, &mb.CreatedAt, &mb.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		birthdays = append(birthdays, mb)
+	}
+	return birthdays, rows.Err()
+}
+
+// SetCalendarOptOut hides or shows a member's birthday in the guild calendar.
+// It reports false if the member has no birthday set.
+func (r *Repository) SetCalendarOptOut(ctx context.Context, guildID, userID string, optOut bool) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE member_birthdays SET calendar_opt_out = $3, updated_at = NOW()
+		WHERE guild_id = $1 AND user_id = $2
+	`, guildID, userID, optOut)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetCalendarToken returns the guild's calendar feed token, or nil if the feed is disabled
+func (r *Repository) GetCalendarToken(ctx context.Context, guildID string) (*string, error) {
+	var token *string
+	err := r.pool.QueryRow(ctx, `
+		SELECT calendar_token FROM guild_settings WHERE guild_id = $1
+	`, guildID).Scan(&token)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return token, err
+}
+
+// SetCalendarToken sets the guild's calendar feed token; nil disables the feed
+func (r *Repository) SetCalendarToken(ctx context.Context, guildID string, token *string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO guild_settings (guild_id, calendar_token, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (guild_id) DO UPDATE SET
+		    calendar_token = EXCLUDED.calendar_token,
+		    updated_at = NOW()
+	`, guildID, token)
+	return err
+}
+
+// timezoneNames returns the zone names the database server knows. They are read once, since
+// pg_timezone_names scans the server's zone files on every query.
+func (r *Repository) timezoneNames(ctx context.Context) ([]string, error) {
+	r.tzMu.Lock()
+	defer r.tzMu.Unlock()
+	if r.tzNames != nil {
+		return r.tzNames, nil
+	}
+
+	rows, err := r.pool.Query(ctx, `SELECT name FROM pg_timezone_names`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	r.tzNames = names
+	return names, nil
+}
+
+// GetBirthdayCandidates retrieves birthdays in setup guilds whose month/day is today in the member's
+// own timezone at the given instant. Feb 29 birthdays are also returned on Feb 28 and Mar 1 so the
+// caller can apply the guild's leap-day policy.
+func (r *Repository) GetBirthdayCandidates(ctx context.Context, now time.Time) ([]MemberBirthday, error) {
+	slog.Debug("GetBirthdayCandidates called", "now", now.UTC())
+
+	// Every timezone's local date is within a day of the UTC date, so narrowing to those three
+	// month/day pairs (plus Feb 29) lets the planner use idx_birthdays_date before the exact check
+	utc := now.UTC()
+	var dates []any
+	for _, offset := range []int{-1, 0, 1} {
+		d := utc.AddDate(0, 0, offset)
+		dates = append(dates, int(d.Month()), d.Day())
+	}
+
+	// Timezones the server doesn't know would make AT TIME ZONE fail the whole query, so they count as UTC
+	tzNames, err := r.timezoneNames(ctx)
+	if err != nil {
+		slog.Error("GetBirthdayCandidates timezone lookup failed", "error", err)
+		return nil, err
+	}
+
+	rows, err := r.pool.Query(ctx, `
+		SELECT mb.guild_id, mb.user_id, mb.month, mb.day, mb.year, mb.timezone,
+		       mb.created_at, mb.updated_at, mb.calendar_opt_out, mb.dm_greeting, mb.dm_reminder
+		FROM member_birthdays mb
+		JOIN guild_settings gs ON gs.guild_id = mb.guild_id AND gs.setup_complete = true
+		CROSS JOIN LATERAL (
+		    SELECT ($1::timestamptz AT TIME ZONE
+		        CASE WHEN mb.timezone = ANY($8::text[]) THEN mb.timezone ELSE 'UTC' END) AS ts
+		) AS local_now
+		WHERE ((mb.month = $2 AND mb.day = $3)
+		    OR (mb.month = $4 AND mb.day = $5)
+		    OR (mb.month = $6 AND mb.day = $7)
+		    OR (mb.month = 2 AND mb.day = 29))
+		  AND ((EXTRACT(MONTH FROM local_now.ts) = mb.month AND EXTRACT(DAY FROM local_now.ts) = mb.day)
+		    OR (mb.month = 2 AND mb.day = 29 AND to_char(local_now.ts, 'MM-DD') IN ('02-28', '03-01')))
+	`, append(append([]any{now}, dates...), tzNames)...)
+	if err != nil {
+		slog.Error("GetBirthdayCandidates query failed", "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var birthdays []MemberBirthday
+	for rows.Next() {
+		var mb MemberBirthday
+		if err := rows.Scan(
+			&mb.GuildID, &mb.UserID, &mb.Month, &mb.Day, &mb.Year,
+			&mb.Timezone, &mb.CreatedAt, &mb.UpdatedAt, &mb.CalendarOptOut, &mb.DMGreeting, &mb.DMReminder,
+		); err != nil {
+			slog.Error("GetBirthdayCandidates scan failed", "error", err)
+			return nil, err
+		}
+		birthdays = append(birthdays, mb)
+	}
+
+	slog.Debug("GetBirthdayCandidates completed", "count", len(birthdays))
+	return birthdays, nil
+}
+
 // SetActiveBirthdayRole records that a user has been given the birthday role
 func (r *Repository) SetActiveBirthdayRole(ctx context.Context, guildID, userID string, expiresAt time.Time) error {
 	slog.Debug("SetActiveBirthdayRole", "guildID", guildID, "userID", userID, "expiresAt", expiresAt)
@@ -426,15 +799,15 @@ func (r *Repository) SetActiveBirthdayRole(ctx context.Context, guildID, userID
 	return err
 }
 
-// GetExpiredBirthdayRoles returns all roles that should be removed
-func (r *Repository) GetExpiredBirthdayRoles(ctx context.Context) ([]ActiveBirthdayRole, error) {
+// GetExpiredBirthdayRoles returns all roles that should be removed as of now
+func (r *Repository) GetExpiredBirthdayRoles(ctx context.Context, now time.Time) ([]ActiveBirthdayRole, error) {
 	slog.Debug("GetExpiredBirthdayRoles called")
 
 	rows, err := r.pool.Query(ctx, `
 		SELECT guild_id, user_id, role_assigned_at, role_expires_at
 		FROM active_birthday_roles
-		WHERE role_expires_at <= NOW()
-	`)
+		WHERE role_expires_at <= $1
+	`, now.UTC())
 	if err != nil {
 		slog.Error("GetExpiredBirthdayRoles query failed", "error", err)
 		return nil, err
@@ -474,6 +847,296 @@ func (r *Repository) HasActiveBirthdayRole(ctx context.Context, guildID, userID
 	return exists, err
 }
 
+// HasAnnouncement checks if a member's birthday has already been announced for the given year
+func (r *Repository) HasAnnouncement(ctx context.Context, guildID, userID string, year int) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM announcements WHERE guild_id = $1 AND user_id = $2 AND birthday_year = $3)
+	`, guildID, userID, year).Scan(&exists)
+	return exists, err
+}
+
+// RecordAnnouncement stores a sent birthday announcement
+func (r *Repository) RecordAnnouncement(ctx context.Context, a *Announcement) error {
+	slog.Debug("RecordAnnouncement", "guildID", a.GuildID, "userID", a.UserID, "year", a.BirthdayYear)
+
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO announcements (guild_id, user_id, birthday_year, channel_id, message_id, template_id, announced_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (guild_id, user_id, birthday_year) DO UPDATE SET
+		    channel_id = EXCLUDED.channel_id,
+		    message_id = EXCLUDED.message_id,
+		    template_id = EXCLUDED.template_id,
+		    announced_at = EXCLUDED.announced_at
+	`, a.GuildID, a.UserID, a.BirthdayYear, a.ChannelID, a.MessageID, a.TemplateID, a.AnnouncedAt.UTC())
+
+	if err != nil {
+		slog.Error("RecordAnnouncement failed", "error", err)
+	}
+	return err
+}
+
+// GetAnnouncementHistory retrieves the most recent announcements for a guild, optionally for one user
+func (r *Repository) GetAnnouncementHistory(ctx context.Context, guildID string, userID *string, limit int) ([]Announcement, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT guild_id, user_id, birthday_year, channel_id, message_id, template_id, announced_at
+		FROM announcements
+		WHERE guild_id = $1 AND ($2::VARCHAR IS NULL OR user_id = $2)
+		ORDER BY announced_at DESC
+		LIMIT $3
+	`, guildID, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var announcements []Announcement
+	for rows.Next() {
+		var a Announcement
+		if err := rows.Scan(&a.GuildID, &a.UserID, &a.BirthdayYear, &a.ChannelID, &a.MessageID, &a.TemplateID, &a.AnnouncedAt); err != nil {
+			return nil, err
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements, nil
+}
+
+// CreateAPIKey stores a new API key
+func (r *Repository) CreateAPIKey(ctx context.Context, k *APIKey) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO guild_api_keys (key_id, guild_id, name, key_hash, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+	`, k.KeyID, k.GuildID, k.Name, k.KeyHash, k.CreatedBy)
+	return err
+}
+
+// GetAPIKeyByHash looks up an API key by the hash of its secret, returning nil if there is none
+func (r *Repository) GetAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	var k APIKey
+	err := r.pool.QueryRow(ctx, `
+		SELECT key_id, guild_id, name, key_hash, created_by, created_at, last_used_at
+		FROM guild_api_keys WHERE key_hash = $1
+	`, keyHash).Scan(&k.KeyID, &k.GuildID, &k.Name, &k.KeyHash, &k.CreatedBy, &k.CreatedAt, &k.LastUsedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+// GetAPIKeys lists a guild's API keys, oldest first
+func (r *Repository) GetAPIKeys(ctx context.Context, guildID string) ([]APIKey, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT key_id, guild_id, name, key_hash, created_by, created_at, last_used_at
+		FROM guild_api_keys WHERE guild_id = $1
+		ORDER BY created_at
+	`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []APIKey
+	for rows.Next() {
+		var k APIKey
+		if err := rows.Scan(&k.KeyID, &k.GuildID, &k.Name, &k.KeyHash, &k.CreatedBy, &k.CreatedAt, &k.LastUsedAt); err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// DeleteAPIKey revokes one of a guild's API keys. It reports false if the key doesn't exist.
+func (r *Repository) DeleteAPIKey(ctx context.Context, guildID, keyID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM guild_api_keys WHERE guild_id = $1 AND key_id = $2
+	`, guildID, keyID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// TouchAPIKey records when an API key was last used
+func (r *Repository) TouchAPIKey(ctx context.Context, keyID string, t time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		UPDATE guild_api_keys SET last_used_at = $2 WHERE key_id = $1
+	`, keyID, t.UTC())
+	return err
+}
+
+// AddWebhook registers an outgoing webhook and returns its ID
+func (r *Repository) AddWebhook(ctx context.Context, w *Webhook) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO guild_webhooks (guild_id, url, created_by)
+		VALUES ($1, $2, $3)
+		RETURNING webhook_id
+	`, w.GuildID, w.URL, w.CreatedBy).Scan(&id)
+	return id, err
+}
+
+// DeleteWebhook removes one of a guild's webhooks. It reports false if the webhook doesn't exist.
+func (r *Repository) DeleteWebhook(ctx context.Context, guildID string, webhookID int64) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM guild_webhooks WHERE guild_id = $1 AND webhook_id = $2
+	`, guildID, webhookID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetGuildWebhooks lists a guild's webhooks, oldest first
+func (r *Repository) GetGuildWebhooks(ctx context.Context, guildID string) ([]Webhook, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT webhook_id, guild_id, url, created_by, created_at
+		FROM guild_webhooks WHERE guild_id = $1
+		ORDER BY webhook_id
+	`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []Webhook
+	for rows.Next() {
+		var w Webhook
+		if err := rows.Scan(&w.WebhookID, &w.GuildID, &w.URL, &w.CreatedBy, &w.CreatedAt); err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, w)
+	}
+	return webhooks, rows.Err()
+}
+
+// GetWebhookSecret returns the guild's webhook signing secret, or nil if none has been created
+func (r *Repository) GetWebhookSecret(ctx context.Context, guildID string) (*string, error) {
+	var secret *string
+	err := r.pool.QueryRow(ctx, `
+		SELECT webhook_secret FROM guild_settings WHERE guild_id = $1
+	`, guildID).Scan(&secret)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return secret, err
+}
+
+// SetWebhookSecret sets the guild's webhook signing secret
+func (r *Repository) SetWebhookSecret(ctx context.Context, guildID, secret string) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO guild_settings (guild_id, webhook_secret, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (guild_id) DO UPDATE SET
+		    webhook_secret = EXCLUDED.webhook_secret,
+		    updated_at = NOW()
+	`, guildID, secret)
+	return err
+}
+
+// RecordWebhookDelivery stores the outcome of a webhook delivery
+func (r *Repository) RecordWebhookDelivery(ctx context.Context, d *WebhookDelivery) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO webhook_deliveries
+		    (delivery_id, webhook_id, guild_id, event, attempts, status_code, error, success, created_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`, d.DeliveryID, d.WebhookID, d.GuildID, d.Event, d.Attempts, d.StatusCode, d.Error, d.Success,
+		d.CreatedAt.UTC(), d.CompletedAt.UTC())
+	return err
+}
+
+// GetWebhookDeliveries retrieves a guild's most recent webhook deliveries
+func (r *Repository) GetWebhookDeliveries(ctx context.Context, guildID string, limit int) ([]WebhookDelivery, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT delivery_id, webhook_id, guild_id, event, attempts, status_code, error, success, created_at, completed_at
+		FROM webhook_deliveries WHERE guild_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, guildID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []WebhookDelivery
+	for rows.Next() {
+		var d WebhookDelivery
+		if err := rows.Scan(&d.DeliveryID, &d.WebhookID, &d.GuildID, &d.Event, &d.Attempts, &d.StatusCode,
+			&d.Error, &d.Success, &d.CreatedAt, &d.CompletedAt); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// SetMemberNotifications updates a member's direct message preferences; nil leaves a preference unchanged.
+// It reports false if the member has no birthday set.
+func (r *Repository) SetMemberNotifications(ctx context.Context, guildID, userID string, greeting, reminder *bool) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE member_birthdays SET
+		    dm_greeting = COALESCE($3, dm_greeting),
+		    dm_reminder = COALESCE($4, dm_reminder),
+		    updated_at = NOW()
+		WHERE guild_id = $1 AND user_id = $2
+	`, guildID, userID, greeting, reminder)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// HasDMNotification checks if a direct message of the given kind was already sent for a birthday
+func (r *Repository) HasDMNotification(ctx context.Context, guildID, userID, recipientID, kind string, year int) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(
+		    SELECT 1 FROM dm_notifications
+		    WHERE guild_id = $1 AND user_id = $2 AND recipient_id = $3 AND kind = $4 AND birthday_year = $5
+		)
+	`, guildID, userID, recipientID, kind, year).Scan(&exists)
+	return exists, err
+}
+
+// RecordDMNotification stores a sent (or undeliverable) direct message
+func (r *Repository) RecordDMNotification(ctx context.Context, n *DMNotification) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO dm_notifications (guild_id, user_id, recipient_id, kind, birthday_year, delivered, sent_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (guild_id, user_id, recipient_id, kind, birthday_year) DO NOTHING
+	`, n.GuildID, n.UserID, n.RecipientID, n.Kind, n.BirthdayYear, n.Delivered, n.SentAt.UTC())
+	return err
+}
+
+// GetLastProcessedAt returns when the birthday loop last completed, or nil if it never has
+func (r *Repository) GetLastProcessedAt(ctx context.Context) (*time.Time, error) {
+	var t time.Time
+	err := r.pool.QueryRow(ctx, `
+		SELECT last_processed_at FROM loop_state WHERE id = 1
+	`).Scan(&t)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// SetLastProcessedAt records when the birthday loop last completed
+func (r *Repository) SetLastProcessedAt(ctx context.Context, t time.Time) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO loop_state (id, last_processed_at)
+		VALUES (1, $1)
+		ON CONFLICT (id) DO UPDATE SET
+		    last_processed_at = EXCLUDED.last_processed_at
+	`, t.UTC())
+	return err
+}
+
 // AddBotAdmin adds a user or role as a bot admin for a guild
 func (r *Repository) AddBotAdmin(ctx context.Context, guildID, targetID, targetType, addedBy string) error {
 	_, err := r.pool.Exec(ctx, `
@@ -540,3 +1203,191 @@ func (r *Repository) IsBotAdmin(ctx context.Context, guildID, userID string, rol
 	}
 	return exists, nil
 }
+
+// AddBirthdayFollow follows a member's birthday, or changes how early an existing follow reminds
+func (r *Repository) AddBirthdayFollow(ctx context.Context, f *BirthdayFollow) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO birthday_follows (guild_id, follower_id, user_id, days_before)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (guild_id, follower_id, user_id) DO UPDATE SET days_before = EXCLUDED.days_before
+	`, f.GuildID, f.FollowerID, f.UserID, f.DaysBefore)
+	return err
+}
+
+// RemoveBirthdayFollow unfollows a member's birthday. It reports false if there was no follow.
+func (r *Repository) RemoveBirthdayFollow(ctx context.Context, guildID, followerID, userID string) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM birthday_follows WHERE guild_id = $1 AND follower_id = $2 AND user_id = $3
+	`, guildID, followerID, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetMemberFollows returns the birthdays a member follows in a guild, including followed members
+// who haven't set a birthday yet
+func (r *Repository) GetMemberFollows(ctx context.Context, guildID, followerID string) ([]BirthdayFollow, error) {
+	return r.queryBirthdayFollows(ctx, `
+		WHERE f.guild_id = $1 AND f.follower_id = $2
+		ORDER BY f.created_at
+	`, guildID, followerID)
+}
+
+// GetBirthdayFollows returns every follow of a member with a birthday in a setup guild
+func (r *Repository) GetBirthdayFollows(ctx context.Context) ([]BirthdayFollow, error) {
+	return r.queryBirthdayFollows(ctx, `
+		WHERE gs.setup_complete = true AND mb.user_id IS NOT NULL
+	`)
+}
+
+// queryBirthdayFollows loads follows joined with the followed birthday and the follower's timezone
+func (r *Repository) queryBirthdayFollows(ctx context.Context, where string, args ...any) ([]BirthdayFollow, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT f.guild_id, f.follower_id, f.user_id, f.days_before, f.created_at,
+		       COALESCE(fb.timezone, gs.default_timezone),
+		       mb.user_id IS NOT NULL, COALESCE(mb.month, 0), COALESCE(mb.day, 0), mb.year, COALESCE(mb.timezone, '')
+		FROM birthday_follows f
+		JOIN guild_settings gs ON gs.guild_id = f.guild_id
+		LEFT JOIN member_birthdays mb ON mb.guild_id = f.guild_id AND mb.user_id = f.user_id
+		LEFT JOIN member_birthdays fb ON fb.guild_id = f.guild_id AND fb.user_id = f.follower_id
+	`+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var follows []BirthdayFollow
+	for rows.Next() {
+		var f BirthdayFollow
+		var hasBirthday bool
+		var mb MemberBirthday
+		if err := rows.Scan(
+			&f.GuildID, &f.FollowerID, &f.UserID, &f.DaysBefore, &f.CreatedAt, &f.FollowerTimezone,
+			&hasBirthday, &mb.Month, &mb.Day, &mb.Year, &mb.Timezone,
+		); err != nil {
+			return nil, err
+		}
+		if hasBirthday {
+			mb.GuildID, mb.UserID = f.GuildID, f.UserID
+			f.Birthday = &mb
+		}
+		follows = append(follows, f)
+	}
+	return follows, rows.Err()
+}
+
+// HasDigestPost checks if a digest of the given kind was already posted for a period
+func (r *Repository) HasDigestPost(ctx context.Context, guildID, kind string, period time.Time) (bool, error) {
+	var exists bool
+	err := r.pool.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM digest_posts WHERE guild_id = $1 AND kind = $2 AND period = $3)
+	`, guildID, kind, period).Scan(&exists)
+	return exists, err
+}
+
+// RecordDigestPost stores a posted digest
+func (r *Repository) RecordDigestPost(ctx context.Context, d *DigestPost) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO digest_posts (guild_id, kind, period, channel_id, message_id, posted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (guild_id, kind, period) DO NOTHING
+	`, d.GuildID, d.Kind, d.Period, d.ChannelID, d.MessageID, d.PostedAt.UTC())
+	return err
+}
+
+// GetAnnouncementEmbed returns a guild's announcement embed design, or nil if it has none
+func (r *Repository) GetAnnouncementEmbed(ctx context.Context, guildID string) (*AnnouncementEmbed, error) {
+	var e AnnouncementEmbed
+	err := r.pool.QueryRow(ctx, `
+		SELECT guild_id, enabled, title, description, color, image_url, footer, show_avatar, updated_at
+		FROM announcement_embeds WHERE guild_id = $1
+	`, guildID).Scan(&e.GuildID, &e.Enabled, &e.Title, &e.Description, &e.Color, &e.ImageURL, &e.Footer, &e.ShowAvatar, &e.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// SetAnnouncementEmbed creates or replaces a guild's announcement embed design
+func (r *Repository) SetAnnouncementEmbed(ctx context.Context, e *AnnouncementEmbed) error {
+	_, err := r.pool.Exec(ctx, `
+		INSERT INTO announcement_embeds (guild_id, enabled, title, description, color, image_url, footer, show_avatar, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())
+		ON CONFLICT (guild_id) DO UPDATE SET
+		    enabled = EXCLUDED.enabled,
+		    title = EXCLUDED.title,
+		    description = EXCLUDED.description,
+		    color = EXCLUDED.color,
+		    image_url = EXCLUDED.image_url,
+		    footer = EXCLUDED.footer,
+		    show_avatar = EXCLUDED.show_avatar,
+		    updated_at = NOW()
+	`, e.GuildID, e.Enabled, e.Title, e.Description, e.Color, e.ImageURL, e.Footer, e.ShowAvatar)
+	return err
+}
+
+// AddGuildMessage stores a new message template for a guild and returns its ID
+func (r *Repository) AddGuildMessage(ctx context.Context, m *GuildMessage) (int64, error) {
+	var id int64
+	err := r.pool.QueryRow(ctx, `
+		INSERT INTO guild_messages (guild_id, template, audience, weight, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING template_id
+	`, m.GuildID, m.Template, m.Audience, m.Weight, m.CreatedBy).Scan(&id)
+	return id, err
+}
+
+// DeleteGuildMessage removes one of a guild's message templates. It reports false if the template
+// doesn't exist.
+func (r *Repository) DeleteGuildMessage(ctx context.Context, guildID string, templateID int64) (bool, error) {
+	tag, err := r.pool.Exec(ctx, `
+		DELETE FROM guild_messages WHERE guild_id = $1 AND template_id = $2
+	`, guildID, templateID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetGuildMessages lists a guild's message templates, oldest first
+func (r *Repository) GetGuildMessages(ctx context.Context, guildID string) ([]GuildMessage, error) {
+	rows, err := r.pool.Query(ctx, `
+		SELECT template_id, guild_id, template, audience, weight, created_by, created_at
+		FROM guild_messages WHERE guild_id = $1
+		ORDER BY template_id
+	`, guildID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []GuildMessage
+	for rows.Next() {
+		var m GuildMessage
+		if err := rows.Scan(&m.TemplateID, &m.GuildID, &m.Template, &m.Audience, &m.Weight, &m.CreatedBy, &m.CreatedAt); err != nil {
+			return nil, err
+		}
+		messages = append(messages, m)
+	}
+	return messages, rows.Err()
+}
+
+// GetLastAnnouncementTemplate returns the guild message used for a member's most recent
+// announcement, or nil if it used a default message or there is none
+func (r *Repository) GetLastAnnouncementTemplate(ctx context.Context, guildID, userID string) (*int64, error) {
+	var id *int64
+	err := r.pool.QueryRow(ctx, `
+		SELECT template_id FROM announcements
+		WHERE guild_id = $1 AND user_id = $2
+		ORDER BY birthday_year DESC
+		LIMIT 1
+	`, guildID, userID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return id, err
+}
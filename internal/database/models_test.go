@@ -0,0 +1,438 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// newTestRepository connects to TEST_DATABASE_URL inside a throwaway schema and applies all migrations.
+// Tests and benchmarks using it are skipped when the variable is not set.
+func newTestRepository(tb testing.TB) *Repository {
+	tb.Helper()
+
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		tb.Skip("TEST_DATABASE_URL not set")
+	}
+
+	cfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	schema := fmt.Sprintf("bday_test_%d", time.Now().UnixNano())
+	cfg.ConnConfig.RuntimeParams["search_path"] = schema
+
+	ctx := context.Background()
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	if _, err := pool.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		pool.Close()
+		tb.Fatal(err)
+	}
+	tb.Cleanup(func() {
+		pool.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+		pool.Close()
+	})
+
+	if err := Migrate(pool); err != nil {
+		tb.Fatal(err)
+	}
+	return NewRepository(pool)
+}
+
+var seedTimezones = []string{
+	"UTC", "America/New_York", "America/Los_Angeles", "Europe/London", "Europe/Berlin",
+	"Asia/Tokyo", "Asia/Kolkata", "Australia/Sydney", "Pacific/Auckland", "Pacific/Honolulu",
+	"Pacific/Kiritimati", "Not/AZone",
+}
+
+// seedBirthdays fills the database with setup guilds whose members have random birthdays
+func seedBirthdays(tb testing.TB, r *Repository, guilds, membersPerGuild int) {
+	tb.Helper()
+	ctx := context.Background()
+	rng := rand.New(rand.NewSource(1))
+
+	for g := 0; g < guilds; g++ {
+		guildID := fmt.Sprintf("g%d", g)
+		channel, role := "c", "r"
+		if err := r.UpsertGuildSettings(ctx, &GuildSettings{
+			GuildID:            guildID,
+			ChannelID:          &channel,
+			RoleID:             &role,
+			MessageWithYear:    "x",
+			MessageWithoutYear: "y",
+			DefaultTimezone:    "UTC",
+			SetupComplete:      true,
+		}); err != nil {
+			tb.Fatal(err)
+		}
+
+		rows := make([][]any, 0, membersPerGuild)
+		for m := 0; m < membersPerGuild; m++ {
+			date := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC).AddDate(0, 0, rng.Intn(366))
+			rows = append(rows, []any{
+				guildID, fmt.Sprintf("u%d", m), int(date.Month()), date.Day(),
+				seedTimezones[rng.Intn(len(seedTimezones))],
+			})
+		}
+		if _, err := r.pool.CopyFrom(ctx,
+			pgx.Identifier{"member_birthdays"},
+			[]string{"guild_id", "user_id", "month", "day", "timezone"},
+			pgx.CopyFromRows(rows),
+		); err != nil {
+			tb.Fatal(err)
+		}
+	}
+	if _, err := r.pool.Exec(ctx, "ANALYZE member_birthdays"); err != nil {
+		tb.Fatal(err)
+	}
+}
+
+// scanCandidates is the previous approach: load every birthday of every setup guild and filter in Go
+func scanCandidates(ctx context.Context, r *Repository, now time.Time) ([]MemberBirthday, error) {
+	guilds, err := r.GetAllSetupGuilds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var candidates []MemberBirthday
+	for _, gs := range guilds {
+		birthdays, err := r.GetAllGuildBirthdays(ctx, gs.GuildID)
+		if err != nil {
+			return nil, err
+		}
+		for _, bd := range birthdays {
+			loc, err := time.LoadLocation(bd.Timezone)
+			if err != nil {
+				loc = time.UTC
+			}
+			local := now.In(loc)
+			m, d := int(local.Month()), local.Day()
+			leap := bd.Month == 2 && bd.Day == 29 && ((m == 2 && d == 28) || (m == 3 && d == 1))
+			if (bd.Month == m && bd.Day == d) || leap {
+				candidates = append(candidates, bd)
+			}
+		}
+	}
+	return candidates, nil
+}
+
+func candidateKeys(birthdays []MemberBirthday) []string {
+	keys := make([]string, 0, len(birthdays))
+	for _, bd := range birthdays {
+		keys = append(keys, bd.GuildID+"/"+bd.UserID)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestGetBirthdayCandidatesMatchesScan(t *testing.T) {
+	r := newTestRepository(t)
+	seedBirthdays(t, r, 5, 500)
+	ctx := context.Background()
+
+	for _, now := range []time.Time{
+		time.Date(2026, 6, 15, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 12, 31, 11, 0, 0, 0, time.UTC),
+		time.Date(2026, 2, 28, 23, 0, 0, 0, time.UTC),
+		time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC),
+	} {
+		want, err := scanCandidates(ctx, r, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := r.GetBirthdayCandidates(ctx, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		wantKeys, gotKeys := candidateKeys(want), candidateKeys(got)
+		if fmt.Sprint(wantKeys) != fmt.Sprint(gotKeys) {
+			t.Errorf("%s: candidates differ\n got: %v\nwant: %v", now, gotKeys, wantKeys)
+		}
+	}
+}
+
+func BenchmarkBirthdayLookup(b *testing.B) {
+	r := newTestRepository(b)
+	seedBirthdays(b, r, 50, 2000)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 15, 9, 0, 0, 0, time.UTC)
+
+	b.Run("scan_all_guilds", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			if _, err := scanCandidates(ctx, r, now); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("sql_candidates", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			if _, err := r.GetBirthdayCandidates(ctx, now); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func TestAPIKeys(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	key := &APIKey{KeyID: "0a1b2c3d", GuildID: "g1", Name: "website", KeyHash: fmt.Sprintf("%064x", 1), CreatedBy: "u1"}
+	if err := r.CreateAPIKey(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := r.GetAPIKeyByHash(ctx, key.KeyHash)
+	if err != nil || got == nil || got.GuildID != "g1" || got.LastUsedAt != nil {
+		t.Fatalf("GetAPIKeyByHash = %+v, %v", got, err)
+	}
+	if missing, err := r.GetAPIKeyByHash(ctx, fmt.Sprintf("%064x", 2)); err != nil || missing != nil {
+		t.Fatalf("unknown hash = %+v, %v", missing, err)
+	}
+
+	if err := r.TouchAPIKey(ctx, key.KeyID, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	keys, err := r.GetAPIKeys(ctx, "g1")
+	if err != nil || len(keys) != 1 || keys[0].LastUsedAt == nil {
+		t.Fatalf("GetAPIKeys = %+v, %v", keys, err)
+	}
+
+	if deleted, err := r.DeleteAPIKey(ctx, "other-guild", key.KeyID); err != nil || deleted {
+		t.Fatalf("revoking another guild's key: %v, %v", deleted, err)
+	}
+	if deleted, err := r.DeleteAPIKey(ctx, "g1", key.KeyID); err != nil || !deleted {
+		t.Fatalf("DeleteAPIKey: %v, %v", deleted, err)
+	}
+}
+
+func TestDMNotifications(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	if err := r.UpsertGuildSettings(ctx, &GuildSettings{GuildID: "g1", MessageWithYear: "x", MessageWithoutYear: "y", DefaultTimezone: "UTC"}); err != nil {
+		t.Fatal(err)
+	}
+	if found, err := r.SetMemberNotifications(ctx, "g1", "u1", nil, nil); err != nil || found {
+		t.Fatalf("member without a birthday: %v, %v", found, err)
+	}
+	if err := r.SetMemberBirthday(ctx, &MemberBirthday{GuildID: "g1", UserID: "u1", Month: 6, Day: 16, Timezone: "UTC"}); err != nil {
+		t.Fatal(err)
+	}
+
+	on := true
+	if found, err := r.SetMemberNotifications(ctx, "g1", "u1", nil, &on); err != nil || !found {
+		t.Fatalf("SetMemberNotifications: %v, %v", found, err)
+	}
+	mb, err := r.GetMemberBirthday(ctx, "g1", "u1")
+	if err != nil || mb.DMGreeting || !mb.DMReminder {
+		t.Fatalf("GetMemberBirthday = %+v, %v", mb, err)
+	}
+
+	n := &DMNotification{GuildID: "g1", UserID: "u1", RecipientID: "u1", Kind: "reminder", BirthdayYear: 2026, SentAt: time.Now()}
+	for range 2 {
+		if err := r.RecordDMNotification(ctx, n); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if sent, err := r.HasDMNotification(ctx, "g1", "u1", "u1", "reminder", 2026); err != nil || !sent {
+		t.Fatalf("HasDMNotification = %v, %v", sent, err)
+	}
+	if sent, err := r.HasDMNotification(ctx, "g1", "u1", "u1", "reminder", 2027); err != nil || sent {
+		t.Fatalf("next year's reminder already sent: %v, %v", sent, err)
+	}
+}
+
+func TestBirthdayFollows(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	for _, gs := range []*GuildSettings{
+		{GuildID: "g1", MessageWithYear: "x", MessageWithoutYear: "y", DefaultTimezone: "Europe/Berlin", SetupComplete: true},
+		{GuildID: "g2", MessageWithYear: "x", MessageWithoutYear: "y", DefaultTimezone: "UTC"},
+	} {
+		if err := r.UpsertGuildSettings(ctx, gs); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, mb := range []*MemberBirthday{
+		{GuildID: "g1", UserID: "u1", Month: 6, Day: 18, Timezone: "UTC"},
+		{GuildID: "g1", UserID: "f2", Month: 1, Day: 2, Timezone: "Asia/Tokyo"},
+		{GuildID: "g2", UserID: "u1", Month: 6, Day: 18, Timezone: "UTC"},
+	} {
+		if err := r.SetMemberBirthday(ctx, mb); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, f := range []*BirthdayFollow{
+		{GuildID: "g1", FollowerID: "f1", UserID: "u1", DaysBefore: 1},
+		{GuildID: "g1", FollowerID: "f1", UserID: "u1", DaysBefore: 7}, // updates the first
+		{GuildID: "g1", FollowerID: "f2", UserID: "u1", DaysBefore: 2},
+		{GuildID: "g1", FollowerID: "f1", UserID: "no-birthday", DaysBefore: 1},
+		{GuildID: "g2", FollowerID: "f1", UserID: "u1", DaysBefore: 1}, // guild not set up
+	} {
+		if err := r.AddBirthdayFollow(ctx, f); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	follows, err := r.GetBirthdayFollows(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timezones := map[string]string{}
+	for _, f := range follows {
+		if f.GuildID != "g1" || f.UserID != "u1" || f.Birthday == nil || f.Birthday.Month != 6 {
+			t.Errorf("unexpected follow %+v", f)
+		}
+		if f.FollowerID == "f1" && f.DaysBefore != 7 {
+			t.Errorf("days before = %d, want 7", f.DaysBefore)
+		}
+		timezones[f.FollowerID] = f.FollowerTimezone
+	}
+	if len(follows) != 2 || timezones["f1"] != "Europe/Berlin" || timezones["f2"] != "Asia/Tokyo" {
+		t.Fatalf("follower timezones = %v", timezones)
+	}
+
+	mine, err := r.GetMemberFollows(ctx, "g1", "f1")
+	if err != nil || len(mine) != 2 || mine[1].Birthday != nil {
+		t.Fatalf("GetMemberFollows = %+v, %v", mine, err)
+	}
+	if removed, err := r.RemoveBirthdayFollow(ctx, "g1", "f1", "u1"); err != nil || !removed {
+		t.Fatalf("RemoveBirthdayFollow: %v, %v", removed, err)
+	}
+	if removed, err := r.RemoveBirthdayFollow(ctx, "g1", "f1", "u1"); err != nil || removed {
+		t.Fatalf("second RemoveBirthdayFollow: %v, %v", removed, err)
+	}
+}
+
+func TestDigestSettingsAndPosts(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	if err := r.UpsertGuildSettings(ctx, &GuildSettings{GuildID: "g1", MessageWithYear: "x", MessageWithoutYear: "y", DefaultTimezone: "UTC"}); err != nil {
+		t.Fatal(err)
+	}
+	gs, err := r.GetGuildSettings(ctx, "g1")
+	if err != nil || gs.AnnouncementMode != AnnouncementModeIndividual || gs.WeeklyDigestDay != nil {
+		t.Fatalf("defaults = %+v, %v", gs, err)
+	}
+
+	monday := int(time.Monday)
+	if err := r.UpdateGuildDigestSettings(ctx, "g1", AnnouncementModeDigest, &monday, 8); err != nil {
+		t.Fatal(err)
+	}
+	gs, err = r.GetGuildSettings(ctx, "g1")
+	if err != nil || gs.AnnouncementMode != AnnouncementModeDigest || gs.WeeklyDigestDay == nil || *gs.WeeklyDigestDay != monday || gs.WeeklyDigestHour != 8 {
+		t.Fatalf("updated = %+v, %v", gs, err)
+	}
+
+	period := time.Date(2026, 6, 15, 0, 0, 0, 0, time.UTC)
+	if posted, err := r.HasDigestPost(ctx, "g1", "weekly", period); err != nil || posted {
+		t.Fatalf("HasDigestPost before posting = %v, %v", posted, err)
+	}
+	if err := r.RecordDigestPost(ctx, &DigestPost{GuildID: "g1", Kind: "weekly", Period: period, ChannelID: "c", PostedAt: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if posted, err := r.HasDigestPost(ctx, "g1", "weekly", period); err != nil || !posted {
+		t.Fatalf("HasDigestPost = %v, %v", posted, err)
+	}
+	if posted, err := r.HasDigestPost(ctx, "g1", "weekly", period.AddDate(0, 0, 7)); err != nil || posted {
+		t.Fatalf("next week already posted: %v, %v", posted, err)
+	}
+}
+
+func TestGuildLanguage(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	if err := r.UpdateGuildLanguage(ctx, "g1", "fr"); err != nil {
+		t.Fatal(err)
+	}
+	gs, err := r.GetGuildSettings(ctx, "g1")
+	if err != nil || gs.Language != "fr" {
+		t.Fatalf("language = %+v, %v", gs, err)
+	}
+
+	gs.Language = ""
+	if err := r.UpsertGuildSettings(ctx, gs); err != nil {
+		t.Fatal(err)
+	}
+	if gs, err = r.GetGuildSettings(ctx, "g1"); err != nil || gs.Language != "" {
+		t.Fatalf("language after reset = %+v, %v", gs, err)
+	}
+}
+
+func TestAnnouncementEmbed(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	if e, err := r.GetAnnouncementEmbed(ctx, "g1"); err != nil || e != nil {
+		t.Fatalf("GetAnnouncementEmbed before saving = %+v, %v", e, err)
+	}
+
+	color := 0xFF66AA
+	e := &AnnouncementEmbed{GuildID: "g1", Enabled: true, Title: "Hi {name}", Color: &color, ShowAvatar: true}
+	if err := r.SetAnnouncementEmbed(ctx, e); err != nil {
+		t.Fatal(err)
+	}
+	e.Footer = "From everyone"
+	e.Color = nil
+	if err := r.SetAnnouncementEmbed(ctx, e); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := r.GetAnnouncementEmbed(ctx, "g1")
+	if err != nil || got == nil || !got.Enabled || got.Title != "Hi {name}" || got.Footer != "From everyone" || got.Color != nil || !got.ShowAvatar {
+		t.Fatalf("GetAnnouncementEmbed = %+v, %v", got, err)
+	}
+}
+
+func TestGuildMessages(t *testing.T) {
+	r := newTestRepository(t)
+	ctx := context.Background()
+
+	first, err := r.AddGuildMessage(ctx, &GuildMessage{GuildID: "g1", Template: "Hi {mention}", Audience: MessageAudienceAny, Weight: 1, CreatedBy: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := r.AddGuildMessage(ctx, &GuildMessage{GuildID: "g1", Template: "{mention} is {new_age}", Audience: MessageAudienceWithYear, Weight: 3, CreatedBy: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	messages, err := r.GetGuildMessages(ctx, "g1")
+	if err != nil || len(messages) != 2 || messages[0].TemplateID != first || messages[1].Weight != 3 {
+		t.Fatalf("GetGuildMessages = %+v, %v", messages, err)
+	}
+
+	if last, err := r.GetLastAnnouncementTemplate(ctx, "g1", "u1"); err != nil || last != nil {
+		t.Fatalf("GetLastAnnouncementTemplate before announcing = %v, %v", last, err)
+	}
+	for year, id := range map[int]*int64{2025: &first, 2026: &second} {
+		if err := r.RecordAnnouncement(ctx, &Announcement{GuildID: "g1", UserID: "u1", BirthdayYear: year, ChannelID: "c", TemplateID: id, AnnouncedAt: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if last, err := r.GetLastAnnouncementTemplate(ctx, "g1", "u1"); err != nil || last == nil || *last != second {
+		t.Fatalf("GetLastAnnouncementTemplate = %v, %v", last, err)
+	}
+
+	if deleted, err := r.DeleteGuildMessage(ctx, "other", first); err != nil || deleted {
+		t.Fatalf("deleted another guild's message: %v, %v", deleted, err)
+	}
+	if deleted, err := r.DeleteGuildMessage(ctx, "g1", first); err != nil || !deleted {
+		t.Fatalf("DeleteGuildMessage = %v, %v", deleted, err)
+	}
+}
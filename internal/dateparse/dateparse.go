@@ -0,0 +1,357 @@
+// Package dateparse reads birthdays as members type them, in any of the bot's languages.
+package dateparse
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/i18n"
+)
+
+// Date is a parsed birthday
+type Date struct {
+	Month int
+	Day   int
+	Year  *int // nil if no year was given
+}
+
+// String formats d in English, e.g. "March 4" or "March 4, 2002"
+func (d Date) String() string {
+	s := fmt.Sprintf("%s %d", i18n.MonthName(i18n.English, d.Month), d.Day)
+	if d.Year != nil {
+		s += fmt.Sprintf(", %d", *d.Year)
+	}
+	return s
+}
+
+// Options control how inputs that depend on context are read
+type Options struct {
+	// DayFirst puts the day/month reading first when an input like 3/4 could be either
+	DayFirst bool
+	// Today resolves relative dates such as "tomorrow" and the century of two-digit years. The zero
+	// value means the current system date.
+	Today time.Time
+	// MinAge and MaxAge bound the age a birth year may give on Today. A MaxAge of 0 means DefaultMaxAge.
+	MinAge int
+	MaxAge int
+}
+
+// DefaultMaxAge is the oldest age a birth year may give unless Options.MaxAge says otherwise
+const DefaultMaxAge = 120
+
+// Reasons a date that was read is rejected, wrapped in an *InvalidDateError
+var (
+	ErrNoSuchDay      = errors.New("month doesn't have that many days")
+	ErrNotLeapYear    = errors.New("February 29 only exists in leap years")
+	ErrFutureDate     = errors.New("date is in the future")
+	ErrImplausibleAge = errors.New("birth year is outside the plausible age range")
+)
+
+// InvalidDateError reports an input that reads as a date that doesn't exist or can't be a birthday
+type InvalidDateError struct {
+	Input string
+	Date  Date
+	Err   error // one of the Err values above
+}
+
+func (e *InvalidDateError) Error() string {
+	return fmt.Sprintf("invalid date %s: %v", e.Input, e.Err)
+}
+
+func (e *InvalidDateError) Unwrap() error {
+	return e.Err
+}
+
+// AmbiguousError reports an input with more than one valid reading, such as 3/4
+type AmbiguousError struct {
+	Input      string
+	Candidates []Date // preferred reading first
+}
+
+func (e *AmbiguousError) Error() string {
+	readings := make([]string, len(e.Candidates))
+	for i, c := range e.Candidates {
+		readings[i] = c.String()
+	}
+	return fmt.Sprintf("%s could be %s", e.Input, strings.Join(readings, " or "))
+}
+
+// Parse reads a date such as "9/24", "2002-09-24", "September 24th, 2002", "24. September",
+// "24 septembre 2002", "24 de septiembre" or "tomorrow". Numeric dates that are valid with the day and
+// month either way round return an *AmbiguousError listing both readings. Dates that don't exist, such
+// as April 31, or whose year isn't a plausible birth year return an *InvalidDateError.
+func Parse(input string, opts Options) (Date, error) {
+	opts = opts.withDefaults()
+	input = strings.TrimSpace(input)
+	text := strings.Join(strings.Fields(strings.ToLower(input)), " ")
+
+	if d, ok := parseRelative(text, opts.Today); ok {
+		return d, nil
+	}
+	if d, ok := parseISO(text); ok {
+		return checked(input, d, opts)
+	}
+	if readings, ok := parseNumeric(text, opts); ok {
+		// Keep the readings that are real birthdays, so 11/1/2026 isn't ambiguous when November 1 is
+		// still to come
+		var candidates []Date
+		var invalid error
+		for _, r := range readings {
+			if _, err := checked(input, r, opts); err != nil {
+				invalid = cmp.Or(invalid, err)
+				continue
+			}
+			candidates = append(candidates, r)
+		}
+		switch len(candidates) {
+		case 0:
+			if invalid != nil {
+				return Date{}, invalid
+			}
+		case 1:
+			return candidates[0], nil
+		default:
+			return Date{}, &AmbiguousError{Input: input, Candidates: candidates}
+		}
+	}
+	if d, ok := parseWords(text, opts.Today); ok {
+		return checked(input, d, opts)
+	}
+
+	return Date{}, fmt.Errorf("could not parse date: %s", input)
+}
+
+// Validate reports why d isn't a real birthday with opts, as one of the Err values, or nil if it is
+func Validate(d Date, opts Options) error {
+	opts = opts.withDefaults()
+	if d.Month < 1 || d.Month > 12 || d.Day < 1 {
+		return ErrNoSuchDay
+	}
+	if d.Day > DaysIn(d.Month, d.Year) {
+		if d.Month == 2 && d.Day == 29 {
+			return ErrNotLeapYear
+		}
+		return ErrNoSuchDay
+	}
+	if d.Year == nil {
+		return nil
+	}
+
+	today := time.Date(opts.Today.Year(), opts.Today.Month(), opts.Today.Day(), 0, 0, 0, 0, time.UTC)
+	if time.Date(*d.Year, time.Month(d.Month), d.Day, 0, 0, 0, 0, time.UTC).After(today) {
+		return ErrFutureDate
+	}
+	if age := Age(d, opts.Today); age < opts.MinAge || age > opts.MaxAge {
+		return ErrImplausibleAge
+	}
+	return nil
+}
+
+// DaysIn returns the number of days in month, counting February 29 when year is nil or a leap year
+func DaysIn(month int, year *int) int {
+	y := 2000 // a leap year, as a birthday without a year may be February 29
+	if year != nil {
+		y = *year
+	}
+	return time.Date(y, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Age returns how old someone born on d is on today. d must have a year.
+func Age(d Date, today time.Time) int {
+	age := today.Year() - *d.Year
+	if int(today.Month()) < d.Month || (int(today.Month()) == d.Month && today.Day() < d.Day) {
+		age--
+	}
+	return age
+}
+
+// withDefaults fills in the zero values of opts that stand for a default
+func (opts Options) withDefaults() Options {
+	if opts.Today.IsZero() {
+		opts.Today = time.Now()
+	}
+	if opts.MaxAge == 0 {
+		opts.MaxAge = DefaultMaxAge
+	}
+	return opts
+}
+
+// checked returns d, or an *InvalidDateError if it isn't a real birthday
+func checked(input string, d Date, opts Options) (Date, error) {
+	if err := Validate(d, opts); err != nil {
+		return Date{}, &InvalidDateError{Input: input, Date: d, Err: err}
+	}
+	return d, nil
+}
+
+// relativeDays maps words for nearby days in each language to their offset from today
+var relativeDays = map[string]int{
+	"today": 0, "heute": 0, "aujourd'hui": 0, "aujourd’hui": 0, "hoy": 0,
+	"tomorrow": 1, "morgen": 1, "demain": 1, "mañana": 1, "manana": 1,
+	"yesterday": -1, "gestern": -1, "hier": -1, "ayer": -1,
+	"day after tomorrow": 2, "übermorgen": 2, "uebermorgen": 2, "après-demain": 2, "apres-demain": 2,
+	"pasado mañana": 2, "pasado manana": 2,
+	"day before yesterday": -2, "vorgestern": -2, "avant-hier": -2, "anteayer": -2,
+}
+
+// parseRelative reads phrases like "tomorrow" or "the day after tomorrow". The year isn't known, so
+// none is returned.
+func parseRelative(text string, today time.Time) (Date, bool) {
+	offset, ok := relativeDays[strings.TrimPrefix(text, "the ")]
+	if !ok {
+		return Date{}, false
+	}
+	t := today.AddDate(0, 0, offset)
+	return Date{Month: int(t.Month()), Day: t.Day()}, true
+}
+
+// parseISO reads YYYY-MM-DD, also with / or . separators. The order is never ambiguous. The year is
+// left for Validate to check.
+func parseISO(text string) (Date, bool) {
+	for _, sep := range []string{"-", "/", "."} {
+		parts := strings.Split(text, sep)
+		if len(parts) != 3 || len(parts[0]) != 4 {
+			continue
+		}
+		y, err1 := strconv.Atoi(parts[0])
+		m, err2 := strconv.Atoi(parts[1])
+		d, err3 := strconv.Atoi(parts[2])
+		if err1 == nil && err2 == nil && err3 == nil && y >= 0 && valid(m, d) {
+			return Date{Month: m, Day: d, Year: &y}, true
+		}
+	}
+	return Date{}, false
+}
+
+// parseNumeric reads day and month numbers with an optional year, such as 9/24, 24.9. or 3-4-2002,
+// and returns every reading with the month and day in range, preferred order first. ok is false if
+// text isn't numeric.
+func parseNumeric(text string, opts Options) (readings []Date, ok bool) {
+	for _, sep := range []string{"/", "-", "."} {
+		parts := strings.Split(text, sep)
+		for i := range parts {
+			parts[i] = strings.TrimSpace(parts[i])
+		}
+		if len(parts) == 3 && parts[2] == "" {
+			parts = parts[:2] // German style "24.9."
+		}
+		if len(parts) < 2 || len(parts) > 3 {
+			continue
+		}
+
+		first, err1 := strconv.Atoi(parts[0])
+		second, err2 := strconv.Atoi(parts[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		var year *int
+		if len(parts) == 3 {
+			y, ok := parseYear(parts[2], opts.Today)
+			if !ok {
+				continue
+			}
+			year = &y
+		}
+
+		monthFirst := Date{Month: first, Day: second, Year: year}
+		dayFirst := Date{Month: second, Day: first, Year: year}
+		orders := []Date{monthFirst, dayFirst}
+		if opts.DayFirst {
+			orders = []Date{dayFirst, monthFirst}
+		}
+		for _, r := range orders {
+			if valid(r.Month, r.Day) && (len(readings) == 0 || readings[0] != r) {
+				readings = append(readings, r)
+			}
+		}
+		return readings, true
+	}
+	return nil, false
+}
+
+// fillerWords are skipped in dates like "the 7th of December", "am 24. September" or
+// "24 de septiembre de 2002"
+var fillerWords = map[string]bool{
+	"the": true, "of": true, "am": true, "der": true, "den": true, "le": true, "el": true, "de": true, "del": true,
+}
+
+// parseWords reads dates with a month name in any supported language, with the day before or after it
+func parseWords(text string, today time.Time) (Date, bool) {
+	var words []string
+	for _, word := range strings.Fields(strings.ReplaceAll(text, ",", " ")) {
+		if fillerWords[word] {
+			continue
+		}
+		words = append(words, stripOrdinal(word))
+	}
+
+	for i, word := range words {
+		m, ok := i18n.ParseMonth(word)
+		if !ok {
+			continue
+		}
+		// "Month Day [Year]" (e.g. "December 7, 2000"), then "Day Month [Year]" (e.g. "7 décembre 2000")
+		for _, at := range []int{i + 1, i - 1} {
+			if at < 0 || at >= len(words) {
+				continue
+			}
+			d, err := strconv.Atoi(words[at])
+			if err != nil || !valid(m, d) {
+				continue
+			}
+			date := Date{Month: m, Day: d}
+			if yearAt := max(i, at) + 1; yearAt < len(words) {
+				if y, ok := parseYear(words[yearAt], today); ok {
+					date.Year = &y
+				}
+			}
+			return date, true
+		}
+	}
+	return Date{}, false
+}
+
+// ordinalSuffixes are stripped from day numbers: 1st, 2nd, 3rd, 4th, 24., 1er, 2e, 2ème, 24º
+var ordinalSuffixes = []string{"st", "nd", "rd", "th", "er", "ème", "eme", "e", ".", "º", "ª"}
+
+// stripOrdinal returns the number in an ordinal day such as "7th" or "24.", or word unchanged
+func stripOrdinal(word string) string {
+	for _, suffix := range ordinalSuffixes {
+		num, ok := strings.CutSuffix(word, suffix)
+		if !ok || num == "" {
+			continue
+		}
+		if _, err := strconv.Atoi(num); err == nil {
+			return num
+		}
+	}
+	return word
+}
+
+// parseYear reads a four-digit year, or a short one in the century that keeps it from being in the
+// future
+func parseYear(s string, today time.Time) (int, bool) {
+	if len(s) == 0 || len(s) == 3 || len(s) > 4 {
+		return 0, false
+	}
+	y, err := strconv.Atoi(s)
+	if err != nil || y < 0 {
+		return 0, false
+	}
+	if len(s) <= 2 {
+		y += 2000
+		if y > today.Year() {
+			y -= 100
+		}
+	}
+	return y, true
+}
+
+// valid reports whether month and day are in range for some month. Validate checks the calendar.
+func valid(month, day int) bool {
+	return month >= 1 && month <= 12 && day >= 1 && day <= 31
+}
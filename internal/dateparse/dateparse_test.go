@@ -0,0 +1,182 @@
+package dateparse
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+var today = time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input      string
+		dayFirst   bool
+		month, day int
+		year       int // 0 for no year
+	}{
+		{"9/24", false, 9, 24, 0},
+		{"24/9", false, 9, 24, 0},
+		{"9/24/2002", false, 9, 24, 2002},
+		{"24.09.2002", true, 9, 24, 2002},
+		{"24.9.", true, 9, 24, 0},
+		{"9 / 24", false, 9, 24, 0},
+		{"2002-09-24", true, 9, 24, 2002},
+		{"9/24/02", false, 9, 24, 2002},
+		{"9/24/85", false, 9, 24, 1985},
+		{"September 24", false, 9, 24, 0},
+		{"September 24th, 2002", false, 9, 24, 2002},
+		{"the 24th of September", false, 9, 24, 0},
+		{"24. September", false, 9, 24, 0},
+		{"am 24. September 2002", false, 9, 24, 2002},
+		{"24 septembre 2002", false, 9, 24, 2002},
+		{"le 1er mars", false, 3, 1, 0},
+		{"24 de septiembre", false, 9, 24, 0},
+		{"24 de septiembre de 2002", false, 9, 24, 2002},
+		{"7 déc.", false, 12, 7, 0},
+		{"3. März", false, 3, 3, 0},
+		{"3/3", false, 3, 3, 0},
+		{"today", false, 10, 16, 0},
+		{"Tomorrow", false, 10, 17, 0},
+		{"morgen", false, 10, 17, 0},
+		{"mañana", false, 10, 17, 0},
+		{"après-demain", false, 10, 18, 0},
+		{"the day before yesterday", false, 10, 14, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, Options{DayFirst: tt.dayFirst, Today: today})
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if got.Month != tt.month || got.Day != tt.day {
+				t.Errorf("Parse(%q) = %s, want month %d day %d", tt.input, got, tt.month, tt.day)
+			}
+			switch {
+			case tt.year == 0 && got.Year != nil:
+				t.Errorf("Parse(%q) year = %d, want none", tt.input, *got.Year)
+			case tt.year != 0 && (got.Year == nil || *got.Year != tt.year):
+				t.Errorf("Parse(%q) = %s, want year %d", tt.input, got, tt.year)
+			}
+		})
+	}
+}
+
+func TestParseAmbiguous(t *testing.T) {
+	_, err := Parse("3/4", Options{Today: today})
+	var ambiguous *AmbiguousError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Parse(3/4) error = %v, want an AmbiguousError", err)
+	}
+	if got := err.Error(); got != "3/4 could be March 4 or April 3" {
+		t.Errorf("error = %q", got)
+	}
+
+	_, err = Parse("3/4/2002", Options{DayFirst: true, Today: today})
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("Parse(3/4/2002) error = %v, want an AmbiguousError", err)
+	}
+	first := ambiguous.Candidates[0]
+	if first.Month != 4 || first.Day != 3 || first.Year == nil || *first.Year != 2002 {
+		t.Errorf("preferred day-first reading = %s, want April 3, 2002", first)
+	}
+}
+
+func TestParseRejects(t *testing.T) {
+	for _, input := range []string{"", "hello", "13/13", "0/5", "September", "32 September", "9/24/123", "the 7th"} {
+		if d, err := Parse(input, Options{Today: today}); err == nil {
+			t.Errorf("Parse(%q) = %s, want an error", input, d)
+		}
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := []struct {
+		input string
+		opts  Options
+		want  error
+	}{
+		{"2/31", Options{}, ErrNoSuchDay},
+		{"April 31", Options{}, ErrNoSuchDay},
+		{"31 avril", Options{}, ErrNoSuchDay},
+		{"31.4.", Options{DayFirst: true}, ErrNoSuchDay},
+		{"2002-06-31", Options{}, ErrNoSuchDay},
+		{"2/29/2023", Options{}, ErrNotLeapYear},
+		{"29. Februar 1999", Options{}, ErrNotLeapYear},
+		{"1900-02-29", Options{MaxAge: 200}, ErrNotLeapYear},
+		{"9/24/2030", Options{}, ErrFutureDate},
+		{"October 17, 2026", Options{}, ErrFutureDate},
+		{"2099-01-01", Options{}, ErrFutureDate},
+		{"1850-05-05", Options{}, ErrImplausibleAge},
+		{"5/5/1950", Options{MaxAge: 70}, ErrImplausibleAge},
+		{"5/5/2020", Options{MinAge: 13}, ErrImplausibleAge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			tt.opts.Today = today
+			d, err := Parse(tt.input, tt.opts)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Parse(%q) = %s, %v, want %v", tt.input, d, err, tt.want)
+			}
+			var invalid *InvalidDateError
+			if !errors.As(err, &invalid) || invalid.Input != tt.input {
+				t.Errorf("Parse(%q) error = %#v, want an InvalidDateError for the input", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestParseAcceptsRealDates(t *testing.T) {
+	for _, input := range []string{"2/29", "February 29", "2/29/2000", "2024-02-29", "4/30", "10/16/2026", "1906-10-16"} {
+		if _, err := Parse(input, Options{Today: today}); err != nil {
+			t.Errorf("Parse(%q) error: %v", input, err)
+		}
+	}
+}
+
+func TestParseDropsImpossibleReadings(t *testing.T) {
+	// November 1, 2026 is still to come, so only January 11 is left
+	d, err := Parse("11/1/2026", Options{Today: today})
+	if err != nil || d.Month != 1 || d.Day != 11 {
+		t.Errorf("Parse(11/1/2026) = %s, %v, want January 11, 2026", d, err)
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	for _, seed := range []string{
+		"9/24", "24.9.", "3/4/2002", "2/29/2023", "2002-09-24", "September 24th, 2002", "le 1er mars",
+		"24 de septiembre de 2002", "29. Februar", "the day after tomorrow", "31 avril", "0/0/0", "",
+	} {
+		f.Add(seed, false)
+		f.Add(seed, true)
+	}
+
+	f.Fuzz(func(t *testing.T, input string, dayFirst bool) {
+		opts := Options{DayFirst: dayFirst, Today: today, MinAge: 5}
+		d, err := Parse(input, opts)
+
+		var ambiguous *AmbiguousError
+		var invalid *InvalidDateError
+		switch {
+		case err == nil:
+			if verr := Validate(d, opts); verr != nil {
+				t.Fatalf("Parse(%q) = %s, which isn't valid: %v", input, d, verr)
+			}
+		case errors.As(err, &ambiguous):
+			if len(ambiguous.Candidates) < 2 {
+				t.Fatalf("Parse(%q) is ambiguous with %d candidates", input, len(ambiguous.Candidates))
+			}
+			for _, c := range ambiguous.Candidates {
+				if verr := Validate(c, opts); verr != nil {
+					t.Fatalf("Parse(%q) offers %s, which isn't valid: %v", input, c, verr)
+				}
+			}
+		case errors.As(err, &invalid):
+			if verr := Validate(invalid.Date, opts); verr != invalid.Err {
+				t.Fatalf("Parse(%q) rejected %s with %v, but Validate says %v", input, invalid.Date, invalid.Err, verr)
+			}
+		}
+	})
+}
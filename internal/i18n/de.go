@@ -0,0 +1,563 @@
+package i18n
+
+var german = map[string]string{
+	"language.name": "Deutsch",
+
+	// Dates
+	"month.1":             "Januar",
+	"month.2":             "Februar",
+	"month.3":             "März",
+	"month.4":             "April",
+	"month.5":             "Mai",
+	"month.6":             "Juni",
+	"month.7":             "Juli",
+	"month.8":             "August",
+	"month.9":             "September",
+	"month.10":            "Oktober",
+	"month.11":            "November",
+	"month.12":            "Dezember",
+	"weekday.0":           "Sonntag",
+	"weekday.1":           "Montag",
+	"weekday.2":           "Dienstag",
+	"weekday.3":           "Mittwoch",
+	"weekday.4":           "Donnerstag",
+	"weekday.5":           "Freitag",
+	"weekday.6":           "Samstag",
+	"date.month_day":      "%d. %s",
+	"date.month_day_year": "%d. %s %d",
+	"date.day_month":      "%d. %s",
+	"date.day_month_year": "%d. %s %d",
+	"date.hint_month_day": "MM/TT",
+	"date.hint_day_month": "TT/MM",
+
+	// Shared
+	"button.cancel":               "Abbrechen",
+	"button.edit":                 "Bearbeiten",
+	"common.cancelled":            "Abgebrochen.",
+	"common.updated":              "✅ Aktualisiert",
+	"error.fetch_birthdays":       "Geburtstage konnten nicht geladen werden",
+	"error.date.no_such_day":      "Der %s hat nur %d Tage",
+	"error.date.not_leap_year":    "%d war kein Schaltjahr, es gab also keinen 29. Februar",
+	"error.date.future":           "**%s** liegt in der Zukunft. Gib bitte dein Geburtsdatum ein.",
+	"error.date.implausible_age":  "Das Geburtsjahr %d scheint nicht zu stimmen. Es muss ein Alter zwischen %d und %d ergeben, oder du lässt das Jahr weg.",
+	"error.invalid_date":          "Ungültiges Datum. Verwende Formate wie: %[1]s, %[2]s oder %[1]s/2002",
+	"error.invalid_timezone":      "Ungültige Zeitzone: %s. Bitte wähle eine aus der Vorschlagsliste.",
+	"error.invalid_timezone_iana": "Ungültige Zeitzone: %s. Verwende das IANA-Format, z. B. Europe/Berlin",
+	"error.no_birthday":           "Du hast auf diesem Server noch keinen Geburtstag eingetragen",
+	"error.no_permission":         "Du darfst diesen Befehl nicht verwenden. Dafür brauchst du die Berechtigung „Server verwalten“ oder musst Bot-Admin sein.",
+	"error.update_setting":        "Einstellung konnte nicht gespeichert werden",
+	"error.user_required":         "Ein Mitglied muss angegeben werden",
+
+	// Setting values
+	"value.yes":             "ja",
+	"value.no":              "nein",
+	"value.on":              "✅ An",
+	"value.off":             "❌ Aus",
+	"value.enabled":         "✅ Aktiviert",
+	"value.disabled":        "❌ Deaktiviert",
+	"value.not_set":         "Nicht festgelegt",
+	"value.date_american":   "MM/TT/JJJJ (amerikanisch)",
+	"value.date_european":   "TT/MM/JJJJ (europäisch)",
+	"value.time_12h":        "12 Stunden (2:00 PM)",
+	"value.time_24h":        "24 Stunden (14:00)",
+	"value.hours":           "%d Stunden",
+	"value.mode_individual": "Eine Nachricht pro Geburtstag",
+	"value.mode_digest":     "Eine tägliche Zusammenfassung",
+	"value.weekly_digest":   "%ss um %02d:00",
+	"value.leap_feb28":      "28. Februar in Nicht-Schaltjahren",
+	"value.leap_mar1":       "1. März in Nicht-Schaltjahren",
+	"value.leap_only":       "Nur in Schaltjahren",
+	"value.language_auto":   "Automatisch (Discord-Sprache des Mitglieds)",
+
+	// /birthday set and remove
+	"birthday.set.success":    "🎂 Dein Geburtstag wurde auf den **%s** gesetzt!\nZeitzone: %s (aktuelle Uhrzeit: %s)",
+	"birthday.set.failed":     "Dein Geburtstag konnte nicht gespeichert werden",
+	"birthday.set.ambiguous":  "🤔 **%s** kann mehr als ein Datum bedeuten. Welches ist dein Geburtstag?",
+	"birthday.remove.confirm": "Möchtest du deinen Geburtstag wirklich entfernen?",
+	"birthday.remove.button":  "Ja, entfernen",
+	"birthday.remove.failed":  "Geburtstag konnte nicht entfernt werden",
+	"birthday.remove.success": "✅ Dein Geburtstag wurde entfernt.",
+
+	// /birthday upcoming and the weekly digest
+	"upcoming.none_set": "Auf diesem Server wurden noch keine Geburtstage eingetragen.",
+	"upcoming.none":     "Keine Geburtstage in den nächsten %d Tagen.",
+	"upcoming.title":    "🎂 Anstehende Geburtstage (nächste %d Tage)",
+	"upcoming.today":    "Heute!",
+	"upcoming.tomorrow": "Morgen",
+	"upcoming.in_days":  "In %d Tagen",
+	"upcoming.turning":  "<@%s> (wird %d) - %s",
+	"upcoming.now":      "<@%s> (jetzt %d) - %s",
+
+	// /birthday calendar
+	"calendar.visibility.failed": "Kalender-Sichtbarkeit konnte nicht geändert werden",
+	"calendar.visibility.shown":  "✅ Dein Geburtstag wird im Kalender dieses Servers angezeigt",
+	"calendar.visibility.hidden": "✅ Dein Geburtstag ist jetzt im Kalender dieses Servers ausgeblendet",
+	"calendar.build_failed":      "Der Geburtstagskalender konnte nicht erstellt werden",
+	"calendar.file":              "📅 Geburtstagskalender mit **%d** Geburtstagen. Öffne die Datei, um sie zu deiner Kalender-App hinzuzufügen.",
+	"calendar.subscribe":         "Um immer aktuell zu bleiben, abonniere <%s>",
+	"calendar.hide_hint":         "Mit `/geburtstag kalender mich_anzeigen:False` blendest du deinen eigenen Geburtstag aus.",
+	"calendar.name":              "Geburtstage",
+	"calendar.name_guild":        "%s Geburtstage",
+	"calendar.event_summary":     "🎂 Geburtstag von %s",
+
+	// /birthday notifications
+	"notifications.update_failed": "Deine Benachrichtigungen konnten nicht gespeichert werden",
+	"notifications.get_failed":    "Deine Benachrichtigungen konnten nicht geladen werden",
+	"notifications.status":        "**Geburtstags-DMs**\nGlückwunsch an deinem Geburtstag: %s\nErinnerung am Vortag: %s\n\nDMs kommen nur an, wenn du Direktnachrichten von Mitgliedern dieses Servers erlaubst.",
+
+	// /birthday follow and following
+	"follow.self":               "Mit `/geburtstag benachrichtigungen` wirst du an deinen eigenen Geburtstag erinnert",
+	"follow.bot":                "Bots haben keinen Geburtstag",
+	"follow.failed":             "Geburtstag konnte nicht gefolgt werden",
+	"follow.limit":              "Du kannst höchstens %d Geburtstagen folgen. Entferne einen mit `/geburtstag gefolgt entfolgen:`.",
+	"follow.success":            "✅ Du bekommst %[1]s dem Geburtstag von <@%[2]s> eine DM.",
+	"follow.no_birthday_yet":    "Das Mitglied hat noch keinen Geburtstag eingetragen, du wirst erinnert, sobald es das tut.",
+	"follow.allow_dms":          "Achte darauf, dass du Direktnachrichten von Mitgliedern dieses Servers erlaubst.",
+	"follow.days_before_one":    "1 Tag vor",
+	"follow.days_before":        "%d Tage vor",
+	"following.unfollow_failed": "Entfolgen fehlgeschlagen",
+	"following.not_following":   "Du folgst dem Geburtstag von <@%s> nicht",
+	"following.unfollowed":      "✅ Du folgst dem Geburtstag von <@%s> nicht mehr",
+	"following.failed":          "Die Geburtstage, denen du folgst, konnten nicht geladen werden",
+	"following.none":            "Du folgst keinen Geburtstagen. Mit `/geburtstag folgen` bekommst du vor einem Geburtstag eine DM.",
+	"following.title":           "**Geburtstage, denen du folgst (%d/%d)**",
+	"following.no_birthday":     "kein Geburtstag eingetragen",
+	"following.line":            "• <@%s> — %s, Erinnerung %s",
+	"following.unfollow_hint":   "Mit `/geburtstag gefolgt entfolgen:` folgst du jemandem nicht mehr.",
+
+	// Birthday DMs
+	"dm.greeting":        "🎂 Alles Gute zum Geburtstag von **%s**! Hab einen wunderschönen Tag. 🎉",
+	"dm.greeting_age":    "🎂 Alles Gute zum %s Geburtstag von **%s**! Hab einen wunderschönen Tag. 🎉",
+	"dm.reminder":        "🎈 Morgen ist dein Geburtstag! Auf **%s** wird er <t:%d:R> gefeiert.",
+	"dm.your_server":     "deinem Server",
+	"dm.follow":          "🎁 **%s** (<@%s>) von **%s** hat %s Geburtstag, am %s, %s!",
+	"dm.follow_age":      "🎁 **%s** (<@%s>) von **%s** hat %s Geburtstag, am %s, %s, und wird %d!",
+	"dm.follow_tomorrow": "morgen",
+	"dm.follow_in_days":  "in %d Tagen",
+
+	// /bdset channel, role, time, rolemention, requiredrole, defaulttimezone, force
+	"channel.failed":          "Kanal konnte nicht gespeichert werden",
+	"channel.success":         "✅ Geburtstagskanal auf <#%s> gesetzt",
+	"role.failed":             "Rolle konnte nicht gespeichert werden",
+	"role.success":            "✅ Geburtstagsrolle auf <@&%s> gesetzt",
+	"time.failed":             "Uhrzeit konnte nicht gespeichert werden",
+	"time.success":            "✅ Geburtstagsankündigungen werden um %02d:00 gesendet (in der Zeitzone des jeweiligen Mitglieds)",
+	"rolemention.enabled":     "✅ Rollenerwähnungen in Geburtstagsnachrichten sind jetzt aktiviert",
+	"rolemention.disabled":    "✅ Rollenerwähnungen in Geburtstagsnachrichten sind jetzt deaktiviert",
+	"requiredrole.set":        "✅ Nur Mitglieder mit <@&%s> bekommen eine Geburtstagsankündigung",
+	"requiredrole.removed":    "✅ Erforderliche Rolle entfernt. Alle Mitglieder bekommen eine Geburtstagsankündigung.",
+	"defaulttimezone.success": "✅ Standard-Zeitzone auf %s gesetzt (aktuelle Uhrzeit: %s)",
+	"force.failed":            "Geburtstag konnte nicht gespeichert werden",
+	"force.success":           "🎂 Der Geburtstag von <@%s> wurde auf den **%s** gesetzt!\nZeitzone: %s (aktuelle Uhrzeit: %s)",
+
+	// /bdset msgwithyear and msgwithoutyear
+	"message.invalid":       "Ungültige Nachricht: %s",
+	"message.update_failed": "Nachricht konnte nicht gespeichert werden",
+	"message.updated":       "✅ Nachricht aktualisiert! Vorschau:\n> %s",
+
+	// /bdset settings
+	"settings.none":                 "Noch keine Einstellungen vorhanden. Starte die Einrichtung mit `/bdset einrichtung`.",
+	"settings.fetch_failed":         "Einstellungen konnten nicht geladen werden",
+	"settings.title":                "🎂 Geburtstags-Bot-Einstellungen",
+	"settings.channel":              "Kanal",
+	"settings.role":                 "Rolle",
+	"settings.hour":                 "Ankündigungszeit",
+	"settings.default_timezone":     "Standard-Zeitzone",
+	"settings.role_mentions":        "Rollenerwähnungen",
+	"settings.required_role":        "Erforderliche Rolle",
+	"settings.date_format":          "Datumsformat",
+	"settings.time_format":          "Zeitformat",
+	"settings.language":             "Sprache",
+	"settings.leap_day":             "Geburtstage am 29. Februar",
+	"settings.catchup":              "Nachholfenster",
+	"settings.announcements":        "Ankündigungen",
+	"settings.weekly_digest":        "Wochenübersicht",
+	"settings.setup_complete":       "Einrichtung abgeschlossen",
+	"settings.message_with_year":    "Nachricht (mit Alter)",
+	"settings.message_without_year": "Nachricht (ohne Alter)",
+
+	// /bdset stop
+	"stop.confirm": "⚠️ Möchtest du wirklich alle Geburtstagseinstellungen löschen? Dadurch werden keine Geburtstagsnachrichten und Rollen mehr vergeben.",
+	"stop.button":  "Ja, Einstellungen löschen",
+	"stop.failed":  "Einstellungen konnten nicht gelöscht werden",
+	"stop.success": "✅ Alle Geburtstagseinstellungen wurden gelöscht.",
+
+	// /bdset interactive
+	"setup.title":                "Geburtstags-Bot einrichten",
+	"setup.message_with_year":    "Mit Alter ({mention}, {name}, {new_age})",
+	"setup.message_without_year": "Ohne Alter ({mention}, {name})",
+	"setup.hour":                 "Ankündigungsstunde (0-23)",
+	"setup.date_format":          "Europäisches Datum TT/MM? (ja/nein)",
+	"setup.time_format":          "24-Stunden-Format? (ja/nein)",
+	"setup.example_with_year":    "{mention} ist {new_age} geworden, alles Gute zum Geburtstag!",
+	"setup.example_without_year": "Alles Gute zum Geburtstag, {mention}!",
+	"setup.invalid_with_year":    "Ungültige Nachricht mit Jahr: %s",
+	"setup.invalid_without_year": "Ungültige Nachricht ohne Jahr: %s",
+	"setup.invalid_hour":         "Ungültige Stunde. Bitte gib eine Zahl zwischen 0 und 23 ein.",
+	"setup.failed":               "Einstellungen konnten nicht gespeichert werden",
+	"setup.success":              "✅ Einstellungen gespeichert!\n\n**Ankündigungszeit:** %02d:00\n**Datumsformat:** %s\n**Zeitformat:** %s\n\nLege jetzt Kanal und Rolle fest:\n• `/bdset kanal #kanal`\n• `/bdset rolle @rolle`",
+
+	// /bdset dateformat, timeformat, language, leapday, time catchup_hours
+	"dateformat.success": "✅ Datumsformat auf %s gesetzt",
+	"timeformat.success": "✅ Zeitformat auf %s gesetzt",
+	"language.invalid":   "Unbekannte Sprache",
+	"language.success":   "✅ Sprache auf %s gesetzt",
+	"leapday.invalid":    "Ungültige Regel für den 29. Februar",
+	"leapday.success":    "✅ Geburtstage am 29. Februar werden so gefeiert: %s",
+	"catchup.disabled":   "✅ Verpasste Geburtstagsankündigungen werden nicht mehr nachgeholt",
+	"catchup.success":    "✅ Ankündigungen, die verpasst wurden, während der Bot offline war, werden bis zu %d Stunden später nachgeholt",
+
+	// /bdset messages digest and the daily digest
+	"digest.mode":               "**Ankündigungen:** %s",
+	"digest.posted_at":          "Die Zusammenfassung wird um %02d:00 in der Standard-Zeitzone des Servers (%s) gepostet.",
+	"digest.weekly":             "**Wochenübersicht:** %s",
+	"digest.weekly_title":       "🎈 Geburtstage in dieser Woche",
+	"digest.daily_title":        "🎉 **Heutige Geburtstage** 🎉",
+	"digest.daily_line":         "🎂 <@%s>",
+	"digest.daily_line_age":     "🎂 <@%s> wird %d",
+	"digest.daily_closing_one":  "Alles Gute zum Geburtstag!",
+	"digest.daily_closing_many": "Alles Gute zum Geburtstag euch allen!",
+
+	// /bdset embed
+	"embed.get_failed":          "Embed-Einstellungen konnten nicht geladen werden",
+	"embed.update_failed":       "Embed-Einstellungen konnten nicht gespeichert werden",
+	"embed.save_failed":         "Embed konnte nicht gespeichert werden",
+	"embed.saved":               "✅ Embed gespeichert",
+	"embed.designer_title":      "Embed für Geburtstagsankündigungen",
+	"embed.field_title":         "Titel ({name}, {new_age})",
+	"embed.field_description":   "Beschreibung (leer = Geburtstagsnachricht)",
+	"embed.field_color":         "Farbe (Hex)",
+	"embed.field_image":         "Bild-URL",
+	"embed.field_footer":        "Fußzeile ({name}, {new_age})",
+	"embed.example_title":       "🎉 Alles Gute zum Geburtstag, {name}!",
+	"embed.example_description": "{mention} ist {new_age} geworden!",
+	"embed.example_footer":      "Von allen hier auf dem Server",
+	"embed.preview_enabled":     "Geburtstage werden mit diesem Embed angekündigt. Vorschau:",
+	"embed.preview_disabled":    "Geburtstage werden als Text angekündigt; mit `/bdset embed stil: Embed` wechselst du. Embed-Vorschau:",
+
+	// /bdset messages
+	"messages.fetch_failed":          "Nachrichten konnten nicht geladen werden",
+	"messages.limit":                 "Dieser Server hat bereits %d Nachrichten. Entferne zuerst eine.",
+	"messages.add_failed":            "Nachricht konnte nicht hinzugefügt werden",
+	"messages.added":                 "✅ Nachricht `%d` hinzugefügt (%s, Gewicht %d). Vorschau:\n> %s",
+	"messages.first_added":           "Geburtstage verwenden jetzt die Nachrichten dieses Servers statt `/bdset nachrichtmitjahr` und `/bdset nachrichtohnejahr`.",
+	"messages.remove_failed":         "Nachricht konnte nicht entfernt werden",
+	"messages.not_found":             "Keine Nachricht mit der ID `%d` auf diesem Server.",
+	"messages.removed":               "✅ Nachricht `%d` entfernt.",
+	"messages.none":                  "Keine Nachrichten hinzugefügt, daher werden `/bdset nachrichtmitjahr` und `/bdset nachrichtohnejahr` verwendet. Füge mit `/bdset nachrichten hinzufügen` welche hinzu.",
+	"messages.none_to_preview":       "Keine Nachrichten hinzugefügt. Füge mit `/bdset nachrichten hinzufügen` welche hinzu.",
+	"messages.list_title":            "💬 **Geburtstagsnachrichten (%d/%d)**",
+	"messages.line":                  "`%d` · %s · Gewicht %d\n> %s",
+	"messages.preview":               "Nachricht `%d` (%s):\n> %s",
+	"messages.audience_with_year":    "mit Jahr",
+	"messages.audience_without_year": "ohne Jahr",
+	"messages.audience_everyone":     "alle",
+
+	// /bdset history
+	"history.failed": "Ankündigungsverlauf konnte nicht geladen werden",
+	"history.none":   "Es wurden noch keine Geburtstagsankündigungen aufgezeichnet.",
+	"history.title":  "📜 Verlauf der Geburtstagsankündigungen",
+	"history.jump":   "Anzeigen",
+
+	// /bdset calendar
+	"feed.update_failed": "Kalender-Feed konnte nicht gespeichert werden",
+	"feed.get_failed":    "Kalender-Feed konnte nicht geladen werden",
+	"feed.disabled":      "✅ Kalender-Feed deaktiviert. Bestehende Abonnements werden nicht mehr aktualisiert.",
+	"feed.no_http":       "Der Kalender-Feed braucht den HTTP-Server des Bots. Bitte den Bot-Host, HTTP_ADDR und PUBLIC_URL zu setzen.",
+	"feed.success":       "✅ Kalender-Feed: <%s>\nMitglieder sehen diesen Link auch in `/geburtstag kalender`.",
+	"feed.rotated":       "Der bisherige Link funktioniert nicht mehr.",
+
+	// /bdset export
+	"export.failed":   "Export konnte nicht erstellt werden",
+	"export.success":  "📦 **%d** Geburtstage exportiert.",
+	"export.csv_note": "CSV-Exporte enthalten nur Geburtstage; verwende JSON, um auch die Servereinstellungen zu exportieren.",
+
+	// /bdset import
+	"import.verify_failed":         "Berechtigungen konnten nicht geprüft werden",
+	"import.owner_only":            "Dieser Befehl steht nur dem Bot-Besitzer zur Verfügung",
+	"import.no_file":               "Die angehängte Datei wurde nicht gefunden",
+	"import.wrong_type":            "Bitte hänge eine JSON-, CSV-, TSV- oder iCalendar-Datei (.ics) an",
+	"import.download_failed":       "Datei konnte nicht heruntergeladen werden: %s",
+	"import.read_failed":           "Datei konnte nicht gelesen werden: %s",
+	"import.parse_failed":          "Datei konnte nicht verarbeitet werden: %s",
+	"import.confirm_button":        "Import bestätigen",
+	"import.expired":               "❌ Diese Import-Vorschau ist abgelaufen. Führe `/bdset import` erneut aus.",
+	"import.cancelled":             "Import abgebrochen. Es wurde nichts geändert.",
+	"import.preview_title":         "📥 Import-Vorschau",
+	"import.preview_description":   "Erkanntes Format: **%s**\nEs wurde noch nichts geändert.",
+	"import.new":                   "Neu",
+	"import.changed":               "Geändert",
+	"import.unchanged":             "Unverändert",
+	"import.invalid":               "Ungültig",
+	"import.settings":              "Einstellungen",
+	"import.invalid_rows":          "Ungültige Zeilen",
+	"import.more_errors":           "…und %d weitere",
+	"import.row":                   "Zeile %d",
+	"import.settings_replaced":     "werden ersetzt",
+	"import.settings_not_included": "nicht enthalten",
+	"import.settings_other_server": "übersprungen (von einem anderen Server exportiert)",
+	"import.settings_invalid":      "übersprungen (ungültig, siehe unten)",
+	"import.settings_failed":       "fehlgeschlagen",
+	"import.settings_restored":     "wiederhergestellt",
+	"import.result":                "✅ Import abgeschlossen!\n\n**Format:** %s\n**Importierte Geburtstage:** %d (%d neu, %d geändert)\n**Unverändert:** %d\n**Ungültige Zeilen:** %d\n**Fehler:** %d\n**Einstellungen:** %s",
+
+	// /bdset webhook
+	"webhook.invalid_url":          "Ungültige Webhook-URL: %s",
+	"webhook.fetch_failed":         "Webhooks konnten nicht geladen werden",
+	"webhook.limit":                "Dieser Server hat bereits %d Webhooks. Entferne zuerst einen.",
+	"webhook.secret_create_failed": "Webhook-Secret konnte nicht erstellt werden",
+	"webhook.secret_get_failed":    "Webhook-Secret konnte nicht geladen werden",
+	"webhook.add_failed":           "Webhook konnte nicht hinzugefügt werden",
+	"webhook.added":                "✅ Webhook `%d` hinzugefügt: <%s>\nPayloads werden mit dem Secret dieses Servers signiert:\n```\n%s\n```\nMit `/bdset webhook test` sendest du einen Ping.",
+	"webhook.remove_failed":        "Webhook konnte nicht entfernt werden",
+	"webhook.not_found":            "Kein Webhook mit der ID `%d` auf diesem Server.",
+	"webhook.removed":              "✅ Webhook `%d` entfernt.",
+	"webhook.none":                 "Keine Webhooks eingerichtet. Füge mit `/bdset webhook hinzufügen` einen hinzu.",
+	"webhook.none_configured":      "Keine Webhooks eingerichtet.",
+	"webhook.list_title":           "🪝 **Webhooks**",
+	"webhook.line":                 "`%d` · <%s> · hinzugefügt von <@%s> <t:%d:R>",
+	"webhook.secret":               "🔑 Webhook-Signatur-Secret:\n```\n%s\n```",
+	"webhook.secret_rotated":       "✅ Secret erneuert. Aktualisiere deine Empfänger; Payloads werden jetzt signiert mit:\n```\n%s\n```",
+	"webhook.ping_sent":            "📨 Ping an %d Webhook(s) gesendet. Das Ergebnis siehst du mit `/bdset webhook protokoll`.",
+	"webhook.log_failed":           "Webhook-Zustellungen konnten nicht geladen werden",
+	"webhook.log_none":             "Es wurden noch keine Webhook-Zustellungen aufgezeichnet.",
+	"webhook.log_title":            "📜 **Letzte Webhook-Zustellungen**",
+	"webhook.delivery_target":      "Webhook `%d`",
+	"webhook.delivery_attempts":    "%d Versuche",
+
+	// /bdset apikey
+	"apikey.create_failed": "API-Schlüssel konnte nicht erstellt werden",
+	"apikey.created":       "✅ API-Schlüssel `%s` (**%s**) erstellt. Kopiere ihn jetzt, er wird nicht noch einmal angezeigt:\n```\n%s\n```\nSende ihn als `Authorization: Bearer <key>`.",
+	"apikey.no_http":       "⚠️ Der HTTP-Server des Bots ist nicht aktiviert, daher ist die API erst erreichbar, wenn der Bot-Host HTTP_ADDR setzt.",
+	"apikey.fetch_failed":  "API-Schlüssel konnten nicht geladen werden",
+	"apikey.none":          "Es wurden noch keine API-Schlüssel erstellt. Füge mit `/bdset apikey erstellen` einen hinzu.",
+	"apikey.list_title":    "🔑 **API-Schlüssel**",
+	"apikey.line":          "`%s` · **%s** · erstellt von <@%s> <t:%d:R>",
+	"apikey.last_used":     "zuletzt verwendet <t:%d:R>",
+	"apikey.never_used":    "nie verwendet",
+	"apikey.revoke_failed": "API-Schlüssel konnte nicht widerrufen werden",
+	"apikey.not_found":     "Kein API-Schlüssel mit der ID `%s` auf diesem Server.",
+	"apikey.revoked":       "✅ API-Schlüssel `%s` widerrufen.",
+
+	// /bdset admin
+	"admin.add_missing":        "Bitte gib ein Mitglied oder eine Rolle an, die Admin werden soll",
+	"admin.add_user_failed":    "Mitglied konnte nicht als Admin hinzugefügt werden",
+	"admin.add_role_failed":    "Rolle konnte nicht als Admin hinzugefügt werden",
+	"admin.user_added":         "✅ <@%s> ist jetzt Bot-Admin",
+	"admin.role_added":         "✅ <@&%s> ist jetzt eine Bot-Admin-Rolle",
+	"admin.remove_missing":     "Bitte gib ein Mitglied oder eine Rolle an, die kein Admin mehr sein soll",
+	"admin.remove_user_failed": "Mitglied konnte nicht aus den Admins entfernt werden",
+	"admin.remove_role_failed": "Rolle konnte nicht aus den Admins entfernt werden",
+	"admin.user_removed":       "✅ <@%s> ist kein Bot-Admin mehr",
+	"admin.role_removed":       "✅ <@&%s> ist keine Bot-Admin-Rolle mehr",
+	"admin.fetch_failed":       "Bot-Admins konnten nicht geladen werden",
+	"admin.none":               "Keine Bot-Admins eingerichtet. Nur Mitglieder mit „Server verwalten“ können Admin-Befehle verwenden.",
+	"admin.title":              "🔐 Bot-Admins",
+	"admin.users":              "Mitglieder",
+	"admin.roles":              "Rollen",
+	"admin.footer":             "Mitglieder mit „Server verwalten“ haben immer Admin-Zugriff",
+
+	// Slash commands
+	"command.birthday.name":                                 "geburtstag",
+	"command.birthday.description":                          "Deinen Geburtstag festlegen und verwalten",
+	"command.birthday.set.name":                             "festlegen",
+	"command.birthday.set.description":                      "Deinen Geburtstag festlegen",
+	"command.birthday.set.birthday.name":                    "geburtstag",
+	"command.birthday.set.birthday.description":             "Dein Geburtstag (z. B. 24. September oder 24. September 2002)",
+	"command.birthday.set.timezone.name":                    "zeitzone",
+	"command.birthday.set.timezone.description":             "Deine Zeitzone",
+	"command.birthday.remove.name":                          "entfernen",
+	"command.birthday.remove.description":                   "Deinen Geburtstag entfernen",
+	"command.birthday.upcoming.name":                        "demnächst",
+	"command.birthday.upcoming.description":                 "Anstehende Geburtstage anzeigen",
+	"command.birthday.upcoming.days.name":                   "tage",
+	"command.birthday.upcoming.days.description":            "Wie viele Tage vorausgeschaut wird (Standard: 7)",
+	"command.birthday.calendar.name":                        "kalender",
+	"command.birthday.calendar.description":                 "Die Geburtstage dieses Servers als Kalenderdatei erhalten",
+	"command.birthday.calendar.show_me.name":                "mich_anzeigen",
+	"command.birthday.calendar.show_me.description":         "Stattdessen festlegen, ob dein Geburtstag im Kalender erscheint",
+	"command.birthday.notifications.name":                   "benachrichtigungen",
+	"command.birthday.notifications.description":            "Auswählen, welche Geburtstags-DMs dir der Bot schickt",
+	"command.birthday.notifications.greeting.name":          "glückwunsch",
+	"command.birthday.notifications.greeting.description":   "Eine DM mit Glückwünschen, wenn dein Geburtstag angekündigt wird",
+	"command.birthday.notifications.reminder.name":          "erinnerung",
+	"command.birthday.notifications.reminder.description":   "Eine DM am Tag vor deinem Geburtstag",
+	"command.birthday.follow.name":                          "folgen",
+	"command.birthday.follow.description":                   "Eine DM vor dem Geburtstag eines anderen Mitglieds erhalten",
+	"command.birthday.follow.user.name":                     "mitglied",
+	"command.birthday.follow.user.description":              "Das Mitglied, dessen Geburtstag du folgen möchtest",
+	"command.birthday.follow.days.name":                     "tage",
+	"command.birthday.follow.days.description":              "Wie viele Tage vor dem Geburtstag du erinnert wirst (Standard: 1)",
+	"command.birthday.following.name":                       "gefolgt",
+	"command.birthday.following.description":                "Die Geburtstage anzeigen, denen du folgst, oder einem entfolgen",
+	"command.birthday.following.unfollow.name":              "entfolgen",
+	"command.birthday.following.unfollow.description":       "Dem Geburtstag dieses Mitglieds nicht mehr folgen",
+	"command.bdset.name":                                    "bdset",
+	"command.bdset.description":                             "Geburtstagseinstellungen für Admins",
+	"command.bdset.channel.name":                            "kanal",
+	"command.bdset.channel.description":                     "Den Kanal für Geburtstagsankündigungen festlegen",
+	"command.bdset.channel.channel.name":                    "kanal",
+	"command.bdset.channel.channel.description":             "Der Kanal für Geburtstagsankündigungen",
+	"command.bdset.role.name":                               "rolle",
+	"command.bdset.role.description":                        "Die Geburtstagsrolle festlegen",
+	"command.bdset.role.role.name":                          "rolle",
+	"command.bdset.role.role.description":                   "Die Rolle, die es am Geburtstag gibt",
+	"command.bdset.time.name":                               "uhrzeit",
+	"command.bdset.time.description":                        "Die Ankündigungsstunde festlegen (0-23 in der Standard-Zeitzone des Servers)",
+	"command.bdset.time.hour.name":                          "stunde",
+	"command.bdset.time.hour.description":                   "Stunde des Tages (0-23)",
+	"command.bdset.time.catchup_hours.name":                 "nachholstunden",
+	"command.bdset.time.catchup_hours.description":          "Wie viele Stunden verspätet eine verpasste Ankündigung noch gesendet wird (0 zum Deaktivieren)",
+	"command.bdset.msgwithyear.name":                        "nachrichtmitjahr",
+	"command.bdset.msgwithyear.description":                 "Die Geburtstagsnachricht festlegen (mit Alter)",
+	"command.bdset.msgwithyear.message.name":                "nachricht",
+	"command.bdset.msgwithyear.message.description":         "Nachricht mit Platzhaltern wie {mention}, {display_name}, {ordinal_age}, {server}",
+	"command.bdset.msgwithoutyear.name":                     "nachrichtohnejahr",
+	"command.bdset.msgwithoutyear.description":              "Die Geburtstagsnachricht festlegen (ohne Alter)",
+	"command.bdset.msgwithoutyear.message.name":             "nachricht",
+	"command.bdset.msgwithoutyear.message.description":      "Nachricht mit Platzhaltern wie {mention}, {display_name}, {server}",
+	"command.bdset.rolemention.name":                        "rollenerwähnung",
+	"command.bdset.rolemention.description":                 "Rollenerwähnungen in Geburtstagsnachrichten erlauben oder verbieten",
+	"command.bdset.rolemention.enabled.name":                "aktiviert",
+	"command.bdset.rolemention.enabled.description":         "Rollenerwähnungen erlauben?",
+	"command.bdset.requiredrole.name":                       "pflichtrolle",
+	"command.bdset.requiredrole.description":                "Eine Rolle festlegen, die für Geburtstagsankündigungen nötig ist",
+	"command.bdset.requiredrole.role.name":                  "rolle",
+	"command.bdset.requiredrole.role.description":           "Die erforderliche Rolle (leer lassen zum Entfernen)",
+	"command.bdset.defaulttimezone.name":                    "standardzeitzone",
+	"command.bdset.defaulttimezone.description":             "Die Standard-Zeitzone für Mitglieder festlegen",
+	"command.bdset.defaulttimezone.timezone.name":           "zeitzone",
+	"command.bdset.defaulttimezone.timezone.description":    "Nach einer Zeitzone suchen",
+	"command.bdset.force.name":                              "erzwingen",
+	"command.bdset.force.description":                       "Den Geburtstag eines Mitglieds festlegen",
+	"command.bdset.force.user.name":                         "mitglied",
+	"command.bdset.force.user.description":                  "Das Mitglied, dessen Geburtstag festgelegt wird",
+	"command.bdset.force.birthday.name":                     "geburtstag",
+	"command.bdset.force.birthday.description":              "Geburtstag (z. B. 24. September oder 24. September 2002)",
+	"command.bdset.force.timezone.name":                     "zeitzone",
+	"command.bdset.force.timezone.description":              "Zeitzone des Mitglieds",
+	"command.bdset.settings.name":                           "einstellungen",
+	"command.bdset.settings.description":                    "Die aktuellen Geburtstagseinstellungen anzeigen",
+	"command.bdset.stop.name":                               "stopp",
+	"command.bdset.stop.description":                        "Alle Geburtstagseinstellungen dieses Servers löschen",
+	"command.bdset.interactive.name":                        "einrichtung",
+	"command.bdset.interactive.description":                 "Den Einrichtungsassistenten starten",
+	"command.bdset.dateformat.name":                         "datumsformat",
+	"command.bdset.dateformat.description":                  "Europäisches Datumsformat umschalten (TT/MM statt MM/TT)",
+	"command.bdset.dateformat.european.name":                "europäisch",
+	"command.bdset.dateformat.european.description":         "Format TT/MM/JJJJ verwenden?",
+	"command.bdset.timeformat.name":                         "zeitformat",
+	"command.bdset.timeformat.description":                  "24-Stunden-Anzeige umschalten",
+	"command.bdset.timeformat.use24h.name":                  "24h",
+	"command.bdset.timeformat.use24h.description":           "24-Stunden-Format verwenden?",
+	"command.bdset.language.name":                           "sprache",
+	"command.bdset.language.description":                    "Die Sprache der Antworten und Ankündigungen des Bots festlegen",
+	"command.bdset.language.language.name":                  "sprache",
+	"command.bdset.language.language.description":           "Zu verwendende Sprache",
+	"command.bdset.leapday.name":                            "schalttag",
+	"command.bdset.leapday.description":                     "Festlegen, wann Geburtstage am 29. Februar in Nicht-Schaltjahren gefeiert werden",
+	"command.bdset.leapday.policy.name":                     "regel",
+	"command.bdset.leapday.policy.description":              "Wann Geburtstage am 29. Februar gefeiert werden",
+	"command.bdset.embed.name":                              "embed",
+	"command.bdset.embed.description":                       "Das Embed für Geburtstagsankündigungen gestalten oder zu reinem Text wechseln",
+	"command.bdset.embed.style.name":                        "stil",
+	"command.bdset.embed.style.description":                 "Wie Geburtstage angekündigt werden",
+	"command.bdset.embed.show_avatar.name":                  "avatar_anzeigen",
+	"command.bdset.embed.show_avatar.description":           "Den Avatar des Mitglieds im Embed anzeigen",
+	"command.bdset.messages.name":                           "nachrichten",
+	"command.bdset.messages.description":                    "Zusätzliche, zufällig gewählte Geburtstagsnachrichten und die Zusammenfassungen verwalten",
+	"command.bdset.messages.add.name":                       "hinzufügen",
+	"command.bdset.messages.add.description":                "Eine Geburtstagsnachricht hinzufügen",
+	"command.bdset.messages.add.message.name":               "nachricht",
+	"command.bdset.messages.add.message.description":        "Nachricht mit Platzhaltern wie {mention}, {display_name}, {new_age}, {server}",
+	"command.bdset.messages.add.weight.name":                "gewicht",
+	"command.bdset.messages.add.weight.description":         "Wie oft sie im Vergleich zu den anderen gewählt wird (Standard: 1)",
+	"command.bdset.messages.add.audience.name":              "zielgruppe",
+	"command.bdset.messages.add.audience.description":       "Für welche Mitglieder sie gilt (Standard: mit Jahr, wenn sie {new_age} verwendet)",
+	"command.bdset.messages.list.name":                      "liste",
+	"command.bdset.messages.list.description":               "Die Geburtstagsnachrichten dieses Servers anzeigen",
+	"command.bdset.messages.remove.name":                    "entfernen",
+	"command.bdset.messages.remove.description":             "Eine Geburtstagsnachricht entfernen",
+	"command.bdset.messages.remove.id.name":                 "id",
+	"command.bdset.messages.remove.id.description":          "Die ID der Nachricht aus /bdset nachrichten liste",
+	"command.bdset.messages.preview.name":                   "vorschau",
+	"command.bdset.messages.preview.description":            "Eine Geburtstagsnachricht mit dir als Mitglied anzeigen",
+	"command.bdset.messages.preview.id.name":                "id",
+	"command.bdset.messages.preview.id.description":         "Die ID der Nachricht (Standard: eine zufällige)",
+	"command.bdset.messages.digest.name":                    "zusammenfassung",
+	"command.bdset.messages.digest.description":             "Eine tägliche Zusammenfassung statt einzelner Ankündigungen und eine Wochenübersicht posten",
+	"command.bdset.messages.digest.mode.name":               "modus",
+	"command.bdset.messages.digest.mode.description":        "Wie die heutigen Geburtstage angekündigt werden",
+	"command.bdset.messages.digest.weekly_day.name":         "wochentag",
+	"command.bdset.messages.digest.weekly_day.description":  "Tag, an dem die Geburtstage der Woche gepostet werden",
+	"command.bdset.messages.digest.weekly_hour.name":        "wochenstunde",
+	"command.bdset.messages.digest.weekly_hour.description": "Stunde für die Wochenübersicht in der Standard-Zeitzone des Servers (0-23)",
+	"command.bdset.history.name":                            "verlauf",
+	"command.bdset.history.description":                     "Die letzten Geburtstagsankündigungen anzeigen",
+	"command.bdset.history.user.name":                       "mitglied",
+	"command.bdset.history.user.description":                "Nur Ankündigungen für dieses Mitglied anzeigen",
+	"command.bdset.calendar.name":                           "kalender",
+	"command.bdset.calendar.description":                    "Den abonnierbaren Kalender-Feed mit den Geburtstagen des Servers verwalten",
+	"command.bdset.calendar.action.name":                    "aktion",
+	"command.bdset.calendar.action.description":             "Was mit dem Feed passieren soll",
+	"command.bdset.export.name":                             "export",
+	"command.bdset.export.description":                      "Die Geburtstage und Einstellungen dieses Servers als Datei exportieren",
+	"command.bdset.export.format.name":                      "format",
+	"command.bdset.export.format.description":               "Dateiformat (Standard: JSON)",
+	"command.bdset.import.name":                             "import",
+	"command.bdset.import.description":                      "Geburtstage aus einer Datei oder einem anderen Geburtstags-Bot importieren (nur Bot-Besitzer)",
+	"command.bdset.import.file.name":                        "datei",
+	"command.bdset.import.file.description":                 "Ein /bdset-Export, CSV, Tabelle (TSV), iCalendar (.ics) oder eine RedBot-Cog-Datei",
+	"command.bdset.import.format.name":                      "format",
+	"command.bdset.import.format.description":               "Dateiformat (Standard: automatisch erkennen)",
+	"command.bdset.import.dry_run.name":                     "testlauf",
+	"command.bdset.import.dry_run.description":              "Änderungen vorab anzeigen und vor dem Speichern bestätigen",
+	"command.bdset.webhook.name":                            "webhook",
+	"command.bdset.webhook.description":                     "Ausgehende Webhooks für Geburtstagsereignisse verwalten",
+	"command.bdset.webhook.add.name":                        "hinzufügen",
+	"command.bdset.webhook.add.description":                 "Geburtstagsereignisse an eine URL senden",
+	"command.bdset.webhook.add.url.name":                    "url",
+	"command.bdset.webhook.add.url.description":             "Die https://-URL, an die Ereignisse gesendet werden",
+	"command.bdset.webhook.remove.name":                     "entfernen",
+	"command.bdset.webhook.remove.description":              "Keine Ereignisse mehr an einen Webhook senden",
+	"command.bdset.webhook.remove.id.name":                  "id",
+	"command.bdset.webhook.remove.id.description":           "Die ID des Webhooks aus /bdset webhook liste",
+	"command.bdset.webhook.list.name":                       "liste",
+	"command.bdset.webhook.list.description":                "Die Webhooks dieses Servers anzeigen",
+	"command.bdset.webhook.secret.name":                     "secret",
+	"command.bdset.webhook.secret.description":              "Das Secret anzeigen, mit dem Webhook-Payloads signiert werden",
+	"command.bdset.webhook.secret.rotate.name":              "erneuern",
+	"command.bdset.webhook.secret.rotate.description":       "Das Secret durch ein neues ersetzen",
+	"command.bdset.webhook.test.name":                       "test",
+	"command.bdset.webhook.test.description":                "Ein Ping-Ereignis an jeden Webhook senden",
+	"command.bdset.webhook.log.name":                        "protokoll",
+	"command.bdset.webhook.log.description":                 "Die letzten Webhook-Zustellungen anzeigen",
+	"command.bdset.apikey.name":                             "apikey",
+	"command.bdset.apikey.description":                      "API-Schlüssel für die Geburtstags-REST-API verwalten",
+	"command.bdset.apikey.create.name":                      "erstellen",
+	"command.bdset.apikey.create.description":               "Einen API-Schlüssel erstellen (wird einmal angezeigt)",
+	"command.bdset.apikey.create.name.name":                 "name",
+	"command.bdset.apikey.create.name.description":          "Wofür der Schlüssel ist, z. B. \"Website-Widget\"",
+	"command.bdset.apikey.list.name":                        "liste",
+	"command.bdset.apikey.list.description":                 "Die API-Schlüssel dieses Servers anzeigen",
+	"command.bdset.apikey.revoke.name":                      "widerrufen",
+	"command.bdset.apikey.revoke.description":               "Einen API-Schlüssel widerrufen",
+	"command.bdset.apikey.revoke.key_id.name":               "schlüssel_id",
+	"command.bdset.apikey.revoke.key_id.description":        "Die ID des Schlüssels aus /bdset apikey liste",
+	"command.bdset.admin.name":                              "admin",
+	"command.bdset.admin.description":                       "Bot-Admins verwalten",
+	"command.bdset.admin.add.name":                          "hinzufügen",
+	"command.bdset.admin.add.description":                   "Ein Mitglied oder eine Rolle als Bot-Admin hinzufügen",
+	"command.bdset.admin.add.user.name":                     "mitglied",
+	"command.bdset.admin.add.user.description":              "Mitglied, das Admin werden soll",
+	"command.bdset.admin.add.role.name":                     "rolle",
+	"command.bdset.admin.add.role.description":              "Rolle, die Admin werden soll",
+	"command.bdset.admin.remove.name":                       "entfernen",
+	"command.bdset.admin.remove.description":                "Ein Mitglied oder eine Rolle aus den Bot-Admins entfernen",
+	"command.bdset.admin.remove.user.name":                  "mitglied",
+	"command.bdset.admin.remove.user.description":           "Mitglied, das kein Admin mehr sein soll",
+	"command.bdset.admin.remove.role.name":                  "rolle",
+	"command.bdset.admin.remove.role.description":           "Rolle, die kein Admin mehr sein soll",
+	"command.bdset.admin.list.name":                         "liste",
+	"command.bdset.admin.list.description":                  "Alle Bot-Admins anzeigen",
+}
@@ -0,0 +1,362 @@
+package i18n
+
+// english is the reference catalog. Messages are fmt format strings; translations may reorder their
+// arguments with explicit indexes such as %[2]s. Slash command text isn't here: commands.go holds the
+// English, and the other catalogs translate it under command.<path>.name and .description keys.
+var english = map[string]string{
+	"language.name": "English",
+
+	// Dates
+	"month.1":             "January",
+	"month.2":             "February",
+	"month.3":             "March",
+	"month.4":             "April",
+	"month.5":             "May",
+	"month.6":             "June",
+	"month.7":             "July",
+	"month.8":             "August",
+	"month.9":             "September",
+	"month.10":            "October",
+	"month.11":            "November",
+	"month.12":            "December",
+	"weekday.0":           "Sunday",
+	"weekday.1":           "Monday",
+	"weekday.2":           "Tuesday",
+	"weekday.3":           "Wednesday",
+	"weekday.4":           "Thursday",
+	"weekday.5":           "Friday",
+	"weekday.6":           "Saturday",
+	"date.month_day":      "%[2]s %[1]d",
+	"date.month_day_year": "%[2]s %[1]d, %[3]d",
+	"date.day_month":      "%[1]d %[2]s",
+	"date.day_month_year": "%[1]d %[2]s, %[3]d",
+	"date.hint_month_day": "MM/DD",
+	"date.hint_day_month": "DD/MM",
+
+	// Shared
+	"button.cancel":               "Cancel",
+	"button.edit":                 "Edit",
+	"common.cancelled":            "Cancelled.",
+	"common.updated":              "✅ Updated",
+	"error.fetch_birthdays":       "Failed to fetch birthdays",
+	"error.date.no_such_day":      "%s only has %d days",
+	"error.date.not_leap_year":    "%d wasn't a leap year, so it had no February 29",
+	"error.date.future":           "**%s** is in the future. Please enter the date you were born.",
+	"error.date.implausible_age":  "A birth year of %d doesn't look right. It must give an age between %d and %d, or you can leave the year out.",
+	"error.invalid_date":          "Invalid date format. Use formats like: %[1]s, %[2]s, or %[1]s/2002",
+	"error.invalid_timezone":      "Invalid timezone: %s. Please select from the autocomplete list.",
+	"error.invalid_timezone_iana": "Invalid timezone: %s. Use IANA format like America/New_York",
+	"error.no_birthday":           "You haven't set a birthday in this server yet",
+	"error.no_permission":         "You don't have permission to use this command. You need Manage Server permission or be a bot admin.",
+	"error.update_setting":        "Failed to update setting",
+	"error.user_required":         "User is required",
+
+	// Setting values
+	"value.yes":             "yes",
+	"value.no":              "no",
+	"value.on":              "✅ On",
+	"value.off":             "❌ Off",
+	"value.enabled":         "✅ Enabled",
+	"value.disabled":        "❌ Disabled",
+	"value.not_set":         "Not set",
+	"value.date_american":   "MM/DD/YYYY (American)",
+	"value.date_european":   "DD/MM/YYYY (European)",
+	"value.time_12h":        "12-hour (2:00 PM)",
+	"value.time_24h":        "24-hour (14:00)",
+	"value.hours":           "%d hours",
+	"value.mode_individual": "One message per birthday",
+	"value.mode_digest":     "One daily digest",
+	"value.weekly_digest":   "%ss at %02d:00",
+	"value.leap_feb28":      "February 28 in non-leap years",
+	"value.leap_mar1":       "March 1 in non-leap years",
+	"value.leap_only":       "Only in leap years",
+	"value.language_auto":   "Automatic (each member's Discord language)",
+
+	// /birthday set and remove
+	"birthday.set.success":    "🎂 Your birthday has been set to **%s**!\nTimezone: %s (current time: %s)",
+	"birthday.set.failed":     "Failed to save your birthday",
+	"birthday.set.ambiguous":  "🤔 **%s** could be more than one date. Which one is your birthday?",
+	"birthday.remove.confirm": "Are you sure you want to remove your birthday?",
+	"birthday.remove.button":  "Yes, remove it",
+	"birthday.remove.failed":  "Failed to remove birthday",
+	"birthday.remove.success": "✅ Your birthday has been removed.",
+
+	// /birthday upcoming and the weekly digest
+	"upcoming.none_set": "No birthdays have been set in this server yet.",
+	"upcoming.none":     "No upcoming birthdays in the next %d days.",
+	"upcoming.title":    "🎂 Upcoming Birthdays (Next %d Days)",
+	"upcoming.today":    "Today!",
+	"upcoming.tomorrow": "Tomorrow",
+	"upcoming.in_days":  "In %d days",
+	"upcoming.turning":  "<@%s> (turning %d) - %s",
+	"upcoming.now":      "<@%s> (now %d) - %s",
+
+	// /birthday calendar
+	"calendar.visibility.failed": "Failed to update calendar visibility",
+	"calendar.visibility.shown":  "✅ Your birthday will be shown in this server's calendar",
+	"calendar.visibility.hidden": "✅ Your birthday is now hidden from this server's calendar",
+	"calendar.build_failed":      "Failed to build the birthday calendar",
+	"calendar.file":              "📅 Birthday calendar with **%d** birthdays. Open the file to add it to your calendar app.",
+	"calendar.subscribe":         "To stay up to date, subscribe to <%s>",
+	"calendar.hide_hint":         "Use `/birthday calendar show_me:False` to hide your own birthday.",
+	"calendar.name":              "Birthdays",
+	"calendar.name_guild":        "%s Birthdays",
+	"calendar.event_summary":     "🎂 %s's birthday",
+
+	// /birthday notifications
+	"notifications.update_failed": "Failed to update your notifications",
+	"notifications.get_failed":    "Failed to get your notifications",
+	"notifications.status":        "**Birthday DMs**\nGreeting on your birthday: %s\nReminder the day before: %s\n\nDMs are only delivered if you allow direct messages from members of this server.",
+
+	// /birthday follow and following
+	"follow.self":               "Use `/birthday notifications` to be reminded of your own birthday",
+	"follow.bot":                "Bots don't have birthdays",
+	"follow.failed":             "Failed to follow birthday",
+	"follow.limit":              "You can follow at most %d birthdays. Use `/birthday following unfollow:` to remove one.",
+	"follow.success":            "✅ You'll get a DM %s <@%s>'s birthday.",
+	"follow.no_birthday_yet":    "They haven't set a birthday yet, so you'll be reminded once they do.",
+	"follow.allow_dms":          "Make sure you allow direct messages from members of this server.",
+	"follow.days_before_one":    "1 day before",
+	"follow.days_before":        "%d days before",
+	"following.unfollow_failed": "Failed to unfollow birthday",
+	"following.not_following":   "You don't follow <@%s>'s birthday",
+	"following.unfollowed":      "✅ You no longer follow <@%s>'s birthday",
+	"following.failed":          "Failed to get the birthdays you follow",
+	"following.none":            "You don't follow any birthdays. Use `/birthday follow` to get a DM before someone's birthday.",
+	"following.title":           "**Birthdays you follow (%d/%d)**",
+	"following.no_birthday":     "no birthday set",
+	"following.line":            "• <@%s> — %s, reminded %s",
+	"following.unfollow_hint":   "Use `/birthday following unfollow:` to stop following someone.",
+
+	// Birthday DMs
+	"dm.greeting":        "🎂 Happy birthday from **%s**! Have a wonderful day. 🎉",
+	"dm.greeting_age":    "🎂 Happy %s birthday from **%s**! Have a wonderful day. 🎉",
+	"dm.reminder":        "🎈 Your birthday is tomorrow! **%s** will celebrate it <t:%d:R>.",
+	"dm.your_server":     "your server",
+	"dm.follow":          "🎁 **%s** (<@%s>) from **%s** has a birthday %s, on %s, %s!",
+	"dm.follow_age":      "🎁 **%s** (<@%s>) from **%s** has a birthday %s, on %s, %s and turns %d!",
+	"dm.follow_tomorrow": "tomorrow",
+	"dm.follow_in_days":  "in %d days",
+
+	// /bdset channel, role, time, rolemention, requiredrole, defaulttimezone, force
+	"channel.failed":          "Failed to update channel",
+	"channel.success":         "✅ Birthday channel set to <#%s>",
+	"role.failed":             "Failed to update role",
+	"role.success":            "✅ Birthday role set to <@&%s>",
+	"time.failed":             "Failed to update time",
+	"time.success":            "✅ Birthday announcements will be sent at %02d:00 (in each user's timezone)",
+	"rolemention.enabled":     "✅ Role mentions in birthday messages are now enabled",
+	"rolemention.disabled":    "✅ Role mentions in birthday messages are now disabled",
+	"requiredrole.set":        "✅ Users must have <@&%s> to have their birthday announced",
+	"requiredrole.removed":    "✅ Required role removed. All users can have their birthday announced.",
+	"defaulttimezone.success": "✅ Default timezone set to %s (current time: %s)",
+	"force.failed":            "Failed to save birthday",
+	"force.success":           "🎂 Birthday for <@%s> has been set to **%s**!\nTimezone: %s (current time: %s)",
+
+	// /bdset msgwithyear and msgwithoutyear
+	"message.invalid":       "Invalid message: %s",
+	"message.update_failed": "Failed to update message",
+	"message.updated":       "✅ Message updated! Preview:\n> %s",
+
+	// /bdset settings
+	"settings.none":                 "No settings configured yet. Use `/bdset interactive` to start setup.",
+	"settings.fetch_failed":         "Failed to fetch settings",
+	"settings.title":                "🎂 Birthday Bot Settings",
+	"settings.channel":              "Channel",
+	"settings.role":                 "Role",
+	"settings.hour":                 "Announcement Hour",
+	"settings.default_timezone":     "Default Timezone",
+	"settings.role_mentions":        "Role Mentions",
+	"settings.required_role":        "Required Role",
+	"settings.date_format":          "Date Format",
+	"settings.time_format":          "Time Format",
+	"settings.language":             "Language",
+	"settings.leap_day":             "Leap Day Birthdays",
+	"settings.catchup":              "Catch-up Window",
+	"settings.announcements":        "Announcements",
+	"settings.weekly_digest":        "Weekly Digest",
+	"settings.setup_complete":       "Setup Complete",
+	"settings.message_with_year":    "Message (with age)",
+	"settings.message_without_year": "Message (without age)",
+
+	// /bdset stop
+	"stop.confirm": "⚠️ Are you sure you want to clear all birthday settings? This will stop birthday messages and role assignments.",
+	"stop.button":  "Yes, clear settings",
+	"stop.failed":  "Failed to clear settings",
+	"stop.success": "✅ All birthday settings have been cleared.",
+
+	// /bdset interactive
+	"setup.title":                "Birthday Bot Setup",
+	"setup.message_with_year":    "Message with age ({mention}, {name}, {new_age})",
+	"setup.message_without_year": "Message without age ({mention}, {name})",
+	"setup.hour":                 "Announcement hour (0-23)",
+	"setup.date_format":          "European date format DD/MM? (yes/no)",
+	"setup.time_format":          "24-hour time? (yes/no)",
+	"setup.example_with_year":    "{mention} has turned {new_age}, happy birthday!",
+	"setup.example_without_year": "Happy birthday {mention}!",
+	"setup.invalid_with_year":    "Invalid message with year: %s",
+	"setup.invalid_without_year": "Invalid message without year: %s",
+	"setup.invalid_hour":         "Invalid hour. Please enter a number between 0 and 23.",
+	"setup.failed":               "Failed to update settings",
+	"setup.success":              "✅ Settings saved!\n\n**Announcement hour:** %02d:00\n**Date format:** %s\n**Time format:** %s\n\nNow set the channel and role:\n• `/bdset channel #channel`\n• `/bdset role @role`",
+
+	// /bdset dateformat, timeformat, language, leapday, time catchup_hours
+	"dateformat.success": "✅ Date format set to %s",
+	"timeformat.success": "✅ Time format set to %s",
+	"language.invalid":   "Unknown language",
+	"language.success":   "✅ Language set to %s",
+	"leapday.invalid":    "Invalid leap day policy",
+	"leapday.success":    "✅ Feb 29 birthdays will be celebrated: %s",
+	"catchup.disabled":   "✅ Missed birthday announcements will no longer be caught up",
+	"catchup.success":    "✅ Birthday announcements missed while the bot was offline will be sent up to %d hours late",
+
+	// /bdset messages digest and the daily digest
+	"digest.mode":               "**Announcements:** %s",
+	"digest.posted_at":          "The digest is posted at %02d:00 in the server's default timezone (%s).",
+	"digest.weekly":             "**Weekly digest:** %s",
+	"digest.weekly_title":       "🎈 Birthdays Coming Up This Week",
+	"digest.daily_title":        "🎉 **Today's birthdays** 🎉",
+	"digest.daily_line":         "🎂 <@%s>",
+	"digest.daily_line_age":     "🎂 <@%s> turns %d",
+	"digest.daily_closing_one":  "Happy birthday!",
+	"digest.daily_closing_many": "Happy birthday to all of you!",
+
+	// /bdset embed
+	"embed.get_failed":          "Failed to get embed settings",
+	"embed.update_failed":       "Failed to update embed settings",
+	"embed.save_failed":         "Failed to save embed",
+	"embed.saved":               "✅ Embed saved",
+	"embed.designer_title":      "Birthday Announcement Embed",
+	"embed.field_title":         "Title ({name}, {new_age})",
+	"embed.field_description":   "Description (empty uses the birthday message)",
+	"embed.field_color":         "Color (hex)",
+	"embed.field_image":         "Image URL",
+	"embed.field_footer":        "Footer ({name}, {new_age})",
+	"embed.example_title":       "🎉 Happy Birthday, {name}!",
+	"embed.example_description": "{mention} has turned {new_age}!",
+	"embed.example_footer":      "From everyone at the server",
+	"embed.preview_enabled":     "Birthdays are announced with this embed. Preview:",
+	"embed.preview_disabled":    "Birthdays are announced in plain text; use `/bdset embed style: Embed` to switch. Embed preview:",
+
+	// /bdset messages
+	"messages.fetch_failed":          "Failed to fetch messages",
+	"messages.limit":                 "This server already has %d messages. Remove one first.",
+	"messages.add_failed":            "Failed to add message",
+	"messages.added":                 "✅ Message `%d` added (%s, weight %d). Preview:\n> %s",
+	"messages.first_added":           "Birthdays now pick from this server's messages instead of `/bdset msgwithyear` and `/bdset msgwithoutyear`.",
+	"messages.remove_failed":         "Failed to remove message",
+	"messages.not_found":             "No message with ID `%d` in this server.",
+	"messages.removed":               "✅ Message `%d` removed.",
+	"messages.none":                  "No messages added, so birthdays use `/bdset msgwithyear` and `/bdset msgwithoutyear`. Use `/bdset messages add` to add some.",
+	"messages.none_to_preview":       "No messages added. Use `/bdset messages add` to add some.",
+	"messages.list_title":            "💬 **Birthday messages (%d/%d)**",
+	"messages.line":                  "`%d` · %s · weight %d\n> %s",
+	"messages.preview":               "Message `%d` (%s):\n> %s",
+	"messages.audience_with_year":    "with year",
+	"messages.audience_without_year": "without year",
+	"messages.audience_everyone":     "everyone",
+
+	// /bdset history
+	"history.failed": "Failed to fetch announcement history",
+	"history.none":   "No birthday announcements have been recorded yet.",
+	"history.title":  "📜 Birthday Announcement History",
+	"history.jump":   "Jump",
+
+	// /bdset calendar
+	"feed.update_failed": "Failed to update calendar feed",
+	"feed.get_failed":    "Failed to get calendar feed",
+	"feed.disabled":      "✅ Calendar feed disabled. Existing subscriptions will stop updating.",
+	"feed.no_http":       "The calendar feed needs the bot's HTTP server. Ask the bot host to set HTTP_ADDR and PUBLIC_URL.",
+	"feed.success":       "✅ Calendar feed: <%s>\nMembers also see this link in `/birthday calendar`.",
+	"feed.rotated":       "The previous link no longer works.",
+
+	// /bdset export
+	"export.failed":   "Failed to create export",
+	"export.success":  "📦 Exported **%d** birthdays.",
+	"export.csv_note": "CSV exports contain birthdays only; use JSON to include server settings.",
+
+	// /bdset import
+	"import.verify_failed":         "Failed to verify permissions",
+	"import.owner_only":            "This command is only available to the bot owner",
+	"import.no_file":               "Could not find the attached file",
+	"import.wrong_type":            "Please attach a JSON, CSV, TSV or iCalendar (.ics) file",
+	"import.download_failed":       "Failed to download file: %s",
+	"import.read_failed":           "Failed to read file: %s",
+	"import.parse_failed":          "Failed to parse file: %s",
+	"import.confirm_button":        "Confirm import",
+	"import.expired":               "❌ This import preview has expired. Run `/bdset import` again.",
+	"import.cancelled":             "Import cancelled. Nothing was changed.",
+	"import.preview_title":         "📥 Import Preview",
+	"import.preview_description":   "Detected format: **%s**\nNothing has been changed yet.",
+	"import.new":                   "New",
+	"import.changed":               "Changed",
+	"import.unchanged":             "Unchanged",
+	"import.invalid":               "Invalid",
+	"import.settings":              "Settings",
+	"import.invalid_rows":          "Invalid Rows",
+	"import.more_errors":           "…and %d more",
+	"import.row":                   "Row %d",
+	"import.settings_replaced":     "will be replaced",
+	"import.settings_not_included": "not included",
+	"import.settings_other_server": "skipped (exported from another server)",
+	"import.settings_invalid":      "skipped (invalid, see below)",
+	"import.settings_failed":       "failed",
+	"import.settings_restored":     "restored",
+	"import.result":                "✅ Import complete!\n\n**Format:** %s\n**Birthdays imported:** %d (%d new, %d changed)\n**Unchanged:** %d\n**Invalid rows:** %d\n**Errors:** %d\n**Settings:** %s",
+
+	// /bdset webhook
+	"webhook.invalid_url":          "Invalid webhook URL: %s",
+	"webhook.fetch_failed":         "Failed to fetch webhooks",
+	"webhook.limit":                "This server already has %d webhooks. Remove one first.",
+	"webhook.secret_create_failed": "Failed to create webhook secret",
+	"webhook.secret_get_failed":    "Failed to get webhook secret",
+	"webhook.add_failed":           "Failed to add webhook",
+	"webhook.added":                "✅ Webhook `%d` added: <%s>\nPayloads are signed with this server's secret:\n```\n%s\n```\nUse `/bdset webhook test` to send a ping.",
+	"webhook.remove_failed":        "Failed to remove webhook",
+	"webhook.not_found":            "No webhook with ID `%d` in this server.",
+	"webhook.removed":              "✅ Webhook `%d` removed.",
+	"webhook.none":                 "No webhooks configured. Use `/bdset webhook add` to add one.",
+	"webhook.none_configured":      "No webhooks configured.",
+	"webhook.list_title":           "🪝 **Webhooks**",
+	"webhook.line":                 "`%d` · <%s> · added by <@%s> <t:%d:R>",
+	"webhook.secret":               "🔑 Webhook signing secret:\n```\n%s\n```",
+	"webhook.secret_rotated":       "✅ Secret rotated. Update your receivers; payloads are now signed with:\n```\n%s\n```",
+	"webhook.ping_sent":            "📨 Sent a ping to %d webhook(s). Check `/bdset webhook log` for the result.",
+	"webhook.log_failed":           "Failed to fetch webhook deliveries",
+	"webhook.log_none":             "No webhook deliveries have been recorded yet.",
+	"webhook.log_title":            "📜 **Recent webhook deliveries**",
+	"webhook.delivery_target":      "webhook `%d`",
+	"webhook.delivery_attempts":    "%d attempts",
+
+	// /bdset apikey
+	"apikey.create_failed": "Failed to create API key",
+	"apikey.created":       "✅ Created API key `%s` (**%s**). Copy it now, it won't be shown again:\n```\n%s\n```\nSend it as `Authorization: Bearer <key>`.",
+	"apikey.no_http":       "⚠️ The bot's HTTP server isn't enabled, so the API can't be reached until the bot host sets HTTP_ADDR.",
+	"apikey.fetch_failed":  "Failed to fetch API keys",
+	"apikey.none":          "No API keys have been created. Use `/bdset apikey create` to add one.",
+	"apikey.list_title":    "🔑 **API keys**",
+	"apikey.line":          "`%s` · **%s** · created by <@%s> <t:%d:R>",
+	"apikey.last_used":     "last used <t:%d:R>",
+	"apikey.never_used":    "never used",
+	"apikey.revoke_failed": "Failed to revoke API key",
+	"apikey.not_found":     "No API key with ID `%s` in this server.",
+	"apikey.revoked":       "✅ API key `%s` revoked.",
+
+	// /bdset admin
+	"admin.add_missing":        "Please specify a user or role to add as admin",
+	"admin.add_user_failed":    "Failed to add user as admin",
+	"admin.add_role_failed":    "Failed to add role as admin",
+	"admin.user_added":         "✅ <@%s> has been added as a bot admin",
+	"admin.role_added":         "✅ <@&%s> has been added as a bot admin role",
+	"admin.remove_missing":     "Please specify a user or role to remove from admins",
+	"admin.remove_user_failed": "Failed to remove user from admins",
+	"admin.remove_role_failed": "Failed to remove role from admins",
+	"admin.user_removed":       "✅ <@%s> has been removed from bot admins",
+	"admin.role_removed":       "✅ <@&%s> has been removed from bot admin roles",
+	"admin.fetch_failed":       "Failed to fetch bot admins",
+	"admin.none":               "No bot admins configured. Only users with Manage Server permission can use admin commands.",
+	"admin.title":              "🔐 Bot Admins",
+	"admin.users":              "Users",
+	"admin.roles":              "Roles",
+	"admin.footer":             "Users with Manage Server permission always have admin access",
+}
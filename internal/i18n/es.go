@@ -0,0 +1,563 @@
+package i18n
+
+var spanish = map[string]string{
+	"language.name": "Español",
+
+	// Dates
+	"month.1":             "enero",
+	"month.2":             "febrero",
+	"month.3":             "marzo",
+	"month.4":             "abril",
+	"month.5":             "mayo",
+	"month.6":             "junio",
+	"month.7":             "julio",
+	"month.8":             "agosto",
+	"month.9":             "septiembre",
+	"month.10":            "octubre",
+	"month.11":            "noviembre",
+	"month.12":            "diciembre",
+	"weekday.0":           "domingo",
+	"weekday.1":           "lunes",
+	"weekday.2":           "martes",
+	"weekday.3":           "miércoles",
+	"weekday.4":           "jueves",
+	"weekday.5":           "viernes",
+	"weekday.6":           "sábado",
+	"date.month_day":      "%d de %s",
+	"date.month_day_year": "%d de %s de %d",
+	"date.day_month":      "%d de %s",
+	"date.day_month_year": "%d de %s de %d",
+	"date.hint_month_day": "MM/DD",
+	"date.hint_day_month": "DD/MM",
+
+	// Shared
+	"button.cancel":               "Cancelar",
+	"button.edit":                 "Editar",
+	"common.cancelled":            "Cancelado.",
+	"common.updated":              "✅ Actualizado",
+	"error.fetch_birthdays":       "No se pudieron obtener los cumpleaños",
+	"error.date.no_such_day":      "El mes de %s solo tiene %d días",
+	"error.date.not_leap_year":    "%d no fue un año bisiesto, así que no tuvo 29 de febrero",
+	"error.date.future":           "**%s** está en el futuro. Introduce tu fecha de nacimiento.",
+	"error.date.implausible_age":  "El año de nacimiento %d no parece correcto. Debe dar una edad entre %d y %d, o puedes omitir el año.",
+	"error.invalid_date":          "Formato de fecha no válido. Usa formatos como: %[1]s, %[2]s o %[1]s/2002",
+	"error.invalid_timezone":      "Zona horaria no válida: %s. Elige una de la lista de sugerencias.",
+	"error.invalid_timezone_iana": "Zona horaria no válida: %s. Usa el formato IANA, por ejemplo Europe/Madrid",
+	"error.no_birthday":           "Aún no has registrado tu cumpleaños en este servidor",
+	"error.no_permission":         "No tienes permiso para usar este comando. Necesitas el permiso Gestionar servidor o ser admin del bot.",
+	"error.update_setting":        "No se pudo actualizar el ajuste",
+	"error.user_required":         "Se necesita un miembro",
+
+	// Setting values
+	"value.yes":             "sí",
+	"value.no":              "no",
+	"value.on":              "✅ Activado",
+	"value.off":             "❌ Desactivado",
+	"value.enabled":         "✅ Activado",
+	"value.disabled":        "❌ Desactivado",
+	"value.not_set":         "Sin configurar",
+	"value.date_american":   "MM/DD/AAAA (estadounidense)",
+	"value.date_european":   "DD/MM/AAAA (europeo)",
+	"value.time_12h":        "12 horas (2:00 PM)",
+	"value.time_24h":        "24 horas (14:00)",
+	"value.hours":           "%d horas",
+	"value.mode_individual": "Un mensaje por cumpleaños",
+	"value.mode_digest":     "Un resumen diario",
+	"value.weekly_digest":   "Cada %s a las %02d:00",
+	"value.leap_feb28":      "28 de febrero en años no bisiestos",
+	"value.leap_mar1":       "1 de marzo en años no bisiestos",
+	"value.leap_only":       "Solo en años bisiestos",
+	"value.language_auto":   "Automático (idioma de Discord de cada miembro)",
+
+	// /birthday set and remove
+	"birthday.set.success":    "🎂 ¡Tu cumpleaños se ha guardado como **%s**!\nZona horaria: %s (hora actual: %s)",
+	"birthday.set.failed":     "No se pudo guardar tu cumpleaños",
+	"birthday.set.ambiguous":  "🤔 **%s** puede ser más de una fecha. ¿Cuál es tu cumpleaños?",
+	"birthday.remove.confirm": "¿Seguro que quieres eliminar tu cumpleaños?",
+	"birthday.remove.button":  "Sí, eliminarlo",
+	"birthday.remove.failed":  "No se pudo eliminar el cumpleaños",
+	"birthday.remove.success": "✅ Tu cumpleaños se ha eliminado.",
+
+	// /birthday upcoming and the weekly digest
+	"upcoming.none_set": "Todavía no se ha registrado ningún cumpleaños en este servidor.",
+	"upcoming.none":     "No hay cumpleaños en los próximos %d días.",
+	"upcoming.title":    "🎂 Próximos cumpleaños (próximos %d días)",
+	"upcoming.today":    "¡Hoy!",
+	"upcoming.tomorrow": "Mañana",
+	"upcoming.in_days":  "En %d días",
+	"upcoming.turning":  "<@%s> (cumple %d) - %s",
+	"upcoming.now":      "<@%s> (ya tiene %d) - %s",
+
+	// /birthday calendar
+	"calendar.visibility.failed": "No se pudo cambiar la visibilidad en el calendario",
+	"calendar.visibility.shown":  "✅ Tu cumpleaños se mostrará en el calendario de este servidor",
+	"calendar.visibility.hidden": "✅ Tu cumpleaños ahora está oculto en el calendario de este servidor",
+	"calendar.build_failed":      "No se pudo crear el calendario de cumpleaños",
+	"calendar.file":              "📅 Calendario con **%d** cumpleaños. Abre el archivo para añadirlo a tu aplicación de calendario.",
+	"calendar.subscribe":         "Para mantenerte al día, suscríbete a <%s>",
+	"calendar.hide_hint":         "Usa `/cumpleaños calendario mostrarme:False` para ocultar tu propio cumpleaños.",
+	"calendar.name":              "Cumpleaños",
+	"calendar.name_guild":        "Cumpleaños de %s",
+	"calendar.event_summary":     "🎂 Cumpleaños de %s",
+
+	// /birthday notifications
+	"notifications.update_failed": "No se pudieron actualizar tus notificaciones",
+	"notifications.get_failed":    "No se pudieron obtener tus notificaciones",
+	"notifications.status":        "**MD de cumpleaños**\nFelicitación en tu cumpleaños: %s\nRecordatorio el día anterior: %s\n\nLos MD solo llegan si permites mensajes directos de los miembros de este servidor.",
+
+	// /birthday follow and following
+	"follow.self":               "Usa `/cumpleaños notificaciones` para recibir un recordatorio de tu propio cumpleaños",
+	"follow.bot":                "Los bots no tienen cumpleaños",
+	"follow.failed":             "No se pudo seguir el cumpleaños",
+	"follow.limit":              "Puedes seguir como máximo %d cumpleaños. Usa `/cumpleaños seguidos dejar_de_seguir:` para quitar uno.",
+	"follow.success":            "✅ Recibirás un MD %[1]s del cumpleaños de <@%[2]s>.",
+	"follow.no_birthday_yet":    "Aún no ha registrado su cumpleaños, así que se te avisará cuando lo haga.",
+	"follow.allow_dms":          "Asegúrate de permitir mensajes directos de los miembros de este servidor.",
+	"follow.days_before_one":    "1 día antes",
+	"follow.days_before":        "%d días antes",
+	"following.unfollow_failed": "No se pudo dejar de seguir el cumpleaños",
+	"following.not_following":   "No sigues el cumpleaños de <@%s>",
+	"following.unfollowed":      "✅ Ya no sigues el cumpleaños de <@%s>",
+	"following.failed":          "No se pudieron obtener los cumpleaños que sigues",
+	"following.none":            "No sigues ningún cumpleaños. Usa `/cumpleaños seguir` para recibir un MD antes del cumpleaños de alguien.",
+	"following.title":           "**Cumpleaños que sigues (%d/%d)**",
+	"following.no_birthday":     "sin cumpleaños registrado",
+	"following.line":            "• <@%s> — %s, aviso %s",
+	"following.unfollow_hint":   "Usa `/cumpleaños seguidos dejar_de_seguir:` para dejar de seguir a alguien.",
+
+	// Birthday DMs
+	"dm.greeting":        "🎂 ¡Feliz cumpleaños de parte de **%s**! Que tengas un día maravilloso. 🎉",
+	"dm.greeting_age":    "🎂 ¡Feliz %s cumpleaños de parte de **%s**! Que tengas un día maravilloso. 🎉",
+	"dm.reminder":        "🎈 ¡Mañana es tu cumpleaños! **%s** lo celebrará <t:%d:R>.",
+	"dm.your_server":     "tu servidor",
+	"dm.follow":          "🎁 ¡**%s** (<@%s>) de **%s** cumple años %s, el %s %s!",
+	"dm.follow_age":      "🎁 ¡**%s** (<@%s>) de **%s** cumple años %s, el %s %s, y cumplirá %d!",
+	"dm.follow_tomorrow": "mañana",
+	"dm.follow_in_days":  "en %d días",
+
+	// /bdset channel, role, time, rolemention, requiredrole, defaulttimezone, force
+	"channel.failed":          "No se pudo actualizar el canal",
+	"channel.success":         "✅ Canal de cumpleaños establecido en <#%s>",
+	"role.failed":             "No se pudo actualizar el rol",
+	"role.success":            "✅ Rol de cumpleaños establecido en <@&%s>",
+	"time.failed":             "No se pudo actualizar la hora",
+	"time.success":            "✅ Los anuncios de cumpleaños se enviarán a las %02d:00 (en la zona horaria de cada miembro)",
+	"rolemention.enabled":     "✅ Las menciones de roles en los mensajes de cumpleaños ahora están activadas",
+	"rolemention.disabled":    "✅ Las menciones de roles en los mensajes de cumpleaños ahora están desactivadas",
+	"requiredrole.set":        "✅ Solo se anunciará el cumpleaños de los miembros con <@&%s>",
+	"requiredrole.removed":    "✅ Rol requerido eliminado. Se anunciará el cumpleaños de todos los miembros.",
+	"defaulttimezone.success": "✅ Zona horaria predeterminada establecida en %s (hora actual: %s)",
+	"force.failed":            "No se pudo guardar el cumpleaños",
+	"force.success":           "🎂 ¡El cumpleaños de <@%s> se ha guardado como **%s**!\nZona horaria: %s (hora actual: %s)",
+
+	// /bdset msgwithyear and msgwithoutyear
+	"message.invalid":       "Mensaje no válido: %s",
+	"message.update_failed": "No se pudo actualizar el mensaje",
+	"message.updated":       "✅ ¡Mensaje actualizado! Vista previa:\n> %s",
+
+	// /bdset settings
+	"settings.none":                 "Aún no hay ajustes configurados. Usa `/bdset asistente` para empezar.",
+	"settings.fetch_failed":         "No se pudieron obtener los ajustes",
+	"settings.title":                "🎂 Ajustes del bot de cumpleaños",
+	"settings.channel":              "Canal",
+	"settings.role":                 "Rol",
+	"settings.hour":                 "Hora del anuncio",
+	"settings.default_timezone":     "Zona horaria predeterminada",
+	"settings.role_mentions":        "Menciones de roles",
+	"settings.required_role":        "Rol requerido",
+	"settings.date_format":          "Formato de fecha",
+	"settings.time_format":          "Formato de hora",
+	"settings.language":             "Idioma",
+	"settings.leap_day":             "Cumpleaños el 29 de febrero",
+	"settings.catchup":              "Margen de recuperación",
+	"settings.announcements":        "Anuncios",
+	"settings.weekly_digest":        "Resumen semanal",
+	"settings.setup_complete":       "Configuración completada",
+	"settings.message_with_year":    "Mensaje (con edad)",
+	"settings.message_without_year": "Mensaje (sin edad)",
+
+	// /bdset stop
+	"stop.confirm": "⚠️ ¿Seguro que quieres borrar todos los ajustes de cumpleaños? Se dejarán de enviar mensajes y asignar roles de cumpleaños.",
+	"stop.button":  "Sí, borrar ajustes",
+	"stop.failed":  "No se pudieron borrar los ajustes",
+	"stop.success": "✅ Se han borrado todos los ajustes de cumpleaños.",
+
+	// /bdset interactive
+	"setup.title":                "Configuración del bot de cumpleaños",
+	"setup.message_with_year":    "Con edad ({mention}, {name}, {new_age})",
+	"setup.message_without_year": "Sin edad ({mention}, {name})",
+	"setup.hour":                 "Hora del anuncio (0-23)",
+	"setup.date_format":          "¿Fecha europea DD/MM? (sí/no)",
+	"setup.time_format":          "¿Formato de 24 horas? (sí/no)",
+	"setup.example_with_year":    "¡{mention} cumple {new_age}, feliz cumpleaños!",
+	"setup.example_without_year": "¡Feliz cumpleaños, {mention}!",
+	"setup.invalid_with_year":    "Mensaje con año no válido: %s",
+	"setup.invalid_without_year": "Mensaje sin año no válido: %s",
+	"setup.invalid_hour":         "Hora no válida. Introduce un número entre 0 y 23.",
+	"setup.failed":               "No se pudieron actualizar los ajustes",
+	"setup.success":              "✅ ¡Ajustes guardados!\n\n**Hora del anuncio:** %02d:00\n**Formato de fecha:** %s\n**Formato de hora:** %s\n\nAhora configura el canal y el rol:\n• `/bdset canal #canal`\n• `/bdset rol @rol`",
+
+	// /bdset dateformat, timeformat, language, leapday, time catchup_hours
+	"dateformat.success": "✅ Formato de fecha establecido en %s",
+	"timeformat.success": "✅ Formato de hora establecido en %s",
+	"language.invalid":   "Idioma desconocido",
+	"language.success":   "✅ Idioma establecido en %s",
+	"leapday.invalid":    "Regla para el 29 de febrero no válida",
+	"leapday.success":    "✅ Los cumpleaños del 29 de febrero se celebrarán: %s",
+	"catchup.disabled":   "✅ Los anuncios de cumpleaños perdidos ya no se recuperarán",
+	"catchup.success":    "✅ Los anuncios perdidos mientras el bot estaba desconectado se enviarán con hasta %d horas de retraso",
+
+	// /bdset messages digest and the daily digest
+	"digest.mode":               "**Anuncios:** %s",
+	"digest.posted_at":          "El resumen se publica a las %02d:00 en la zona horaria predeterminada del servidor (%s).",
+	"digest.weekly":             "**Resumen semanal:** %s",
+	"digest.weekly_title":       "🎈 Cumpleaños de esta semana",
+	"digest.daily_title":        "🎉 **Cumpleaños de hoy** 🎉",
+	"digest.daily_line":         "🎂 <@%s>",
+	"digest.daily_line_age":     "🎂 <@%s> cumple %d",
+	"digest.daily_closing_one":  "¡Feliz cumpleaños!",
+	"digest.daily_closing_many": "¡Feliz cumpleaños a todos!",
+
+	// /bdset embed
+	"embed.get_failed":          "No se pudieron obtener los ajustes del embed",
+	"embed.update_failed":       "No se pudieron actualizar los ajustes del embed",
+	"embed.save_failed":         "No se pudo guardar el embed",
+	"embed.saved":               "✅ Embed guardado",
+	"embed.designer_title":      "Embed de anuncio de cumpleaños",
+	"embed.field_title":         "Título ({name}, {new_age})",
+	"embed.field_description":   "Descripción (vacía = mensaje de cumpleaños)",
+	"embed.field_color":         "Color (hex)",
+	"embed.field_image":         "URL de la imagen",
+	"embed.field_footer":        "Pie ({name}, {new_age})",
+	"embed.example_title":       "🎉 ¡Feliz cumpleaños, {name}!",
+	"embed.example_description": "¡{mention} cumple {new_age}!",
+	"embed.example_footer":      "De parte de todo el servidor",
+	"embed.preview_enabled":     "Los cumpleaños se anuncian con este embed. Vista previa:",
+	"embed.preview_disabled":    "Los cumpleaños se anuncian como texto; usa `/bdset embed estilo: Embed` para cambiarlo. Vista previa del embed:",
+
+	// /bdset messages
+	"messages.fetch_failed":          "No se pudieron obtener los mensajes",
+	"messages.limit":                 "Este servidor ya tiene %d mensajes. Elimina uno primero.",
+	"messages.add_failed":            "No se pudo añadir el mensaje",
+	"messages.added":                 "✅ Mensaje `%d` añadido (%s, peso %d). Vista previa:\n> %s",
+	"messages.first_added":           "Los cumpleaños ahora usan los mensajes de este servidor en lugar de `/bdset mensajeconaño` y `/bdset mensajesinaño`.",
+	"messages.remove_failed":         "No se pudo eliminar el mensaje",
+	"messages.not_found":             "No hay ningún mensaje con el ID `%d` en este servidor.",
+	"messages.removed":               "✅ Mensaje `%d` eliminado.",
+	"messages.none":                  "No hay mensajes añadidos, así que los cumpleaños usan `/bdset mensajeconaño` y `/bdset mensajesinaño`. Usa `/bdset mensajes añadir` para añadir alguno.",
+	"messages.none_to_preview":       "No hay mensajes añadidos. Usa `/bdset mensajes añadir` para añadir alguno.",
+	"messages.list_title":            "💬 **Mensajes de cumpleaños (%d/%d)**",
+	"messages.line":                  "`%d` · %s · peso %d\n> %s",
+	"messages.preview":               "Mensaje `%d` (%s):\n> %s",
+	"messages.audience_with_year":    "con año",
+	"messages.audience_without_year": "sin año",
+	"messages.audience_everyone":     "todos",
+
+	// /bdset history
+	"history.failed": "No se pudo obtener el historial de anuncios",
+	"history.none":   "Todavía no se ha registrado ningún anuncio de cumpleaños.",
+	"history.title":  "📜 Historial de anuncios de cumpleaños",
+	"history.jump":   "Ir",
+
+	// /bdset calendar
+	"feed.update_failed": "No se pudo actualizar el feed del calendario",
+	"feed.get_failed":    "No se pudo obtener el feed del calendario",
+	"feed.disabled":      "✅ Feed del calendario desactivado. Las suscripciones existentes dejarán de actualizarse.",
+	"feed.no_http":       "El feed del calendario necesita el servidor HTTP del bot. Pide a quien aloja el bot que configure HTTP_ADDR y PUBLIC_URL.",
+	"feed.success":       "✅ Feed del calendario: <%s>\nLos miembros también ven este enlace en `/cumpleaños calendario`.",
+	"feed.rotated":       "El enlace anterior ya no funciona.",
+
+	// /bdset export
+	"export.failed":   "No se pudo crear la exportación",
+	"export.success":  "📦 **%d** cumpleaños exportados.",
+	"export.csv_note": "Las exportaciones CSV solo contienen cumpleaños; usa JSON para incluir los ajustes del servidor.",
+
+	// /bdset import
+	"import.verify_failed":         "No se pudieron comprobar los permisos",
+	"import.owner_only":            "Este comando solo está disponible para el propietario del bot",
+	"import.no_file":               "No se encontró el archivo adjunto",
+	"import.wrong_type":            "Adjunta un archivo JSON, CSV, TSV o iCalendar (.ics)",
+	"import.download_failed":       "No se pudo descargar el archivo: %s",
+	"import.read_failed":           "No se pudo leer el archivo: %s",
+	"import.parse_failed":          "No se pudo procesar el archivo: %s",
+	"import.confirm_button":        "Confirmar importación",
+	"import.expired":               "❌ Esta vista previa de importación ha caducado. Vuelve a ejecutar `/bdset importar`.",
+	"import.cancelled":             "Importación cancelada. No se ha cambiado nada.",
+	"import.preview_title":         "📥 Vista previa de importación",
+	"import.preview_description":   "Formato detectado: **%s**\nTodavía no se ha cambiado nada.",
+	"import.new":                   "Nuevos",
+	"import.changed":               "Cambiados",
+	"import.unchanged":             "Sin cambios",
+	"import.invalid":               "No válidos",
+	"import.settings":              "Ajustes",
+	"import.invalid_rows":          "Filas no válidas",
+	"import.more_errors":           "…y %d más",
+	"import.row":                   "Fila %d",
+	"import.settings_replaced":     "se reemplazarán",
+	"import.settings_not_included": "no incluidos",
+	"import.settings_other_server": "omitidos (exportados desde otro servidor)",
+	"import.settings_invalid":      "omitidos (no válidos, ver abajo)",
+	"import.settings_failed":       "error",
+	"import.settings_restored":     "restaurados",
+	"import.result":                "✅ ¡Importación completada!\n\n**Formato:** %s\n**Cumpleaños importados:** %d (%d nuevos, %d cambiados)\n**Sin cambios:** %d\n**Filas no válidas:** %d\n**Errores:** %d\n**Ajustes:** %s",
+
+	// /bdset webhook
+	"webhook.invalid_url":          "URL de webhook no válida: %s",
+	"webhook.fetch_failed":         "No se pudieron obtener los webhooks",
+	"webhook.limit":                "Este servidor ya tiene %d webhooks. Elimina uno primero.",
+	"webhook.secret_create_failed": "No se pudo crear el secreto del webhook",
+	"webhook.secret_get_failed":    "No se pudo obtener el secreto del webhook",
+	"webhook.add_failed":           "No se pudo añadir el webhook",
+	"webhook.added":                "✅ Webhook `%d` añadido: <%s>\nLos payloads se firman con el secreto de este servidor:\n```\n%s\n```\nUsa `/bdset webhook probar` para enviar un ping.",
+	"webhook.remove_failed":        "No se pudo eliminar el webhook",
+	"webhook.not_found":            "No hay ningún webhook con el ID `%d` en este servidor.",
+	"webhook.removed":              "✅ Webhook `%d` eliminado.",
+	"webhook.none":                 "No hay webhooks configurados. Usa `/bdset webhook añadir` para añadir uno.",
+	"webhook.none_configured":      "No hay webhooks configurados.",
+	"webhook.list_title":           "🪝 **Webhooks**",
+	"webhook.line":                 "`%d` · <%s> · añadido por <@%s> <t:%d:R>",
+	"webhook.secret":               "🔑 Secreto de firma de webhooks:\n```\n%s\n```",
+	"webhook.secret_rotated":       "✅ Secreto renovado. Actualiza tus receptores; los payloads ahora se firman con:\n```\n%s\n```",
+	"webhook.ping_sent":            "📨 Ping enviado a %d webhook(s). Consulta `/bdset webhook registro` para ver el resultado.",
+	"webhook.log_failed":           "No se pudieron obtener los envíos de webhooks",
+	"webhook.log_none":             "Todavía no se ha registrado ningún envío de webhook.",
+	"webhook.log_title":            "📜 **Últimos envíos de webhooks**",
+	"webhook.delivery_target":      "webhook `%d`",
+	"webhook.delivery_attempts":    "%d intentos",
+
+	// /bdset apikey
+	"apikey.create_failed": "No se pudo crear la clave de API",
+	"apikey.created":       "✅ Clave de API `%s` (**%s**) creada. Cópiala ahora, no se volverá a mostrar:\n```\n%s\n```\nEnvíala como `Authorization: Bearer <key>`.",
+	"apikey.no_http":       "⚠️ El servidor HTTP del bot no está activado, así que no se puede acceder a la API hasta que quien aloja el bot configure HTTP_ADDR.",
+	"apikey.fetch_failed":  "No se pudieron obtener las claves de API",
+	"apikey.none":          "No se ha creado ninguna clave de API. Usa `/bdset claveapi crear` para añadir una.",
+	"apikey.list_title":    "🔑 **Claves de API**",
+	"apikey.line":          "`%s` · **%s** · creada por <@%s> <t:%d:R>",
+	"apikey.last_used":     "usada por última vez <t:%d:R>",
+	"apikey.never_used":    "nunca usada",
+	"apikey.revoke_failed": "No se pudo revocar la clave de API",
+	"apikey.not_found":     "No hay ninguna clave de API con el ID `%s` en este servidor.",
+	"apikey.revoked":       "✅ Clave de API `%s` revocada.",
+
+	// /bdset admin
+	"admin.add_missing":        "Indica un miembro o un rol para añadir como admin",
+	"admin.add_user_failed":    "No se pudo añadir al miembro como admin",
+	"admin.add_role_failed":    "No se pudo añadir el rol como admin",
+	"admin.user_added":         "✅ <@%s> ahora es admin del bot",
+	"admin.role_added":         "✅ <@&%s> ahora es un rol admin del bot",
+	"admin.remove_missing":     "Indica un miembro o un rol para quitar de los admins",
+	"admin.remove_user_failed": "No se pudo quitar al miembro de los admins",
+	"admin.remove_role_failed": "No se pudo quitar el rol de los admins",
+	"admin.user_removed":       "✅ <@%s> ya no es admin del bot",
+	"admin.role_removed":       "✅ <@&%s> ya no es un rol admin del bot",
+	"admin.fetch_failed":       "No se pudieron obtener los admins del bot",
+	"admin.none":               "No hay admins del bot configurados. Solo los miembros con Gestionar servidor pueden usar los comandos de admin.",
+	"admin.title":              "🔐 Admins del bot",
+	"admin.users":              "Miembros",
+	"admin.roles":              "Roles",
+	"admin.footer":             "Los miembros con Gestionar servidor siempre tienen acceso de admin",
+
+	// Slash commands
+	"command.birthday.name":                                 "cumpleaños",
+	"command.birthday.description":                          "Configura y gestiona tu cumpleaños",
+	"command.birthday.set.name":                             "establecer",
+	"command.birthday.set.description":                      "Establece tu cumpleaños",
+	"command.birthday.set.birthday.name":                    "fecha",
+	"command.birthday.set.birthday.description":             "Tu cumpleaños (p. ej. 24 de septiembre o 24 de septiembre de 2002)",
+	"command.birthday.set.timezone.name":                    "zona_horaria",
+	"command.birthday.set.timezone.description":             "Tu zona horaria",
+	"command.birthday.remove.name":                          "eliminar",
+	"command.birthday.remove.description":                   "Elimina tu cumpleaños",
+	"command.birthday.upcoming.name":                        "próximos",
+	"command.birthday.upcoming.description":                 "Ver los próximos cumpleaños",
+	"command.birthday.upcoming.days.name":                   "días",
+	"command.birthday.upcoming.days.description":            "Cuántos días mirar hacia adelante (predeterminado: 7)",
+	"command.birthday.calendar.name":                        "calendario",
+	"command.birthday.calendar.description":                 "Obtén los cumpleaños de este servidor como archivo de calendario",
+	"command.birthday.calendar.show_me.name":                "mostrarme",
+	"command.birthday.calendar.show_me.description":         "En su lugar, elige si tu cumpleaños aparece en el calendario",
+	"command.birthday.notifications.name":                   "notificaciones",
+	"command.birthday.notifications.description":            "Elige qué MD de cumpleaños te envía el bot",
+	"command.birthday.notifications.greeting.name":          "felicitación",
+	"command.birthday.notifications.greeting.description":   "Recibir un MD de felicitación cuando se anuncie tu cumpleaños",
+	"command.birthday.notifications.reminder.name":          "recordatorio",
+	"command.birthday.notifications.reminder.description":   "Recibir un MD el día antes de tu cumpleaños",
+	"command.birthday.follow.name":                          "seguir",
+	"command.birthday.follow.description":                   "Recibe un MD antes del cumpleaños de otro miembro",
+	"command.birthday.follow.user.name":                     "miembro",
+	"command.birthday.follow.user.description":              "El miembro cuyo cumpleaños quieres seguir",
+	"command.birthday.follow.days.name":                     "días",
+	"command.birthday.follow.days.description":              "Cuántos días antes de su cumpleaños avisarte (predeterminado: 1)",
+	"command.birthday.following.name":                       "seguidos",
+	"command.birthday.following.description":                "Lista los cumpleaños que sigues o deja de seguir uno",
+	"command.birthday.following.unfollow.name":              "dejar_de_seguir",
+	"command.birthday.following.unfollow.description":       "Dejar de seguir el cumpleaños de este miembro",
+	"command.bdset.name":                                    "bdset",
+	"command.bdset.description":                             "Ajustes de cumpleaños para admins",
+	"command.bdset.channel.name":                            "canal",
+	"command.bdset.channel.description":                     "Establece el canal de anuncios de cumpleaños",
+	"command.bdset.channel.channel.name":                    "canal",
+	"command.bdset.channel.channel.description":             "El canal para los anuncios de cumpleaños",
+	"command.bdset.role.name":                               "rol",
+	"command.bdset.role.description":                        "Establece el rol de cumpleaños",
+	"command.bdset.role.role.name":                          "rol",
+	"command.bdset.role.role.description":                   "El rol que se da en los cumpleaños",
+	"command.bdset.time.name":                               "hora",
+	"command.bdset.time.description":                        "Establece la hora del anuncio (0-23 en la zona horaria predeterminada del servidor)",
+	"command.bdset.time.hour.name":                          "hora",
+	"command.bdset.time.hour.description":                   "Hora del día (0-23)",
+	"command.bdset.time.catchup_hours.name":                 "horas_recuperación",
+	"command.bdset.time.catchup_hours.description":          "Horas de retraso con las que aún se envía un anuncio perdido (0 para desactivar)",
+	"command.bdset.msgwithyear.name":                        "mensajeconaño",
+	"command.bdset.msgwithyear.description":                 "Establece el mensaje de cumpleaños (con edad)",
+	"command.bdset.msgwithyear.message.name":                "mensaje",
+	"command.bdset.msgwithyear.message.description":         "Mensaje con variables como {mention}, {display_name}, {ordinal_age}, {server}",
+	"command.bdset.msgwithoutyear.name":                     "mensajesinaño",
+	"command.bdset.msgwithoutyear.description":              "Establece el mensaje de cumpleaños (sin edad)",
+	"command.bdset.msgwithoutyear.message.name":             "mensaje",
+	"command.bdset.msgwithoutyear.message.description":      "Mensaje con variables como {mention}, {display_name}, {server}",
+	"command.bdset.rolemention.name":                        "menciónrol",
+	"command.bdset.rolemention.description":                 "Permite o no las menciones de roles en los mensajes de cumpleaños",
+	"command.bdset.rolemention.enabled.name":                "activado",
+	"command.bdset.rolemention.enabled.description":         "¿Permitir menciones de roles?",
+	"command.bdset.requiredrole.name":                       "rolrequerido",
+	"command.bdset.requiredrole.description":                "Establece un rol necesario para los anuncios de cumpleaños",
+	"command.bdset.requiredrole.role.name":                  "rol",
+	"command.bdset.requiredrole.role.description":           "El rol requerido (déjalo vacío para quitarlo)",
+	"command.bdset.defaulttimezone.name":                    "zonapredeterminada",
+	"command.bdset.defaulttimezone.description":             "Establece la zona horaria predeterminada de los miembros",
+	"command.bdset.defaulttimezone.timezone.name":           "zona_horaria",
+	"command.bdset.defaulttimezone.timezone.description":    "Busca una zona horaria",
+	"command.bdset.force.name":                              "forzar",
+	"command.bdset.force.description":                       "Establece el cumpleaños de un miembro",
+	"command.bdset.force.user.name":                         "miembro",
+	"command.bdset.force.user.description":                  "El miembro cuyo cumpleaños se establece",
+	"command.bdset.force.birthday.name":                     "fecha",
+	"command.bdset.force.birthday.description":              "Cumpleaños (p. ej. 24 de septiembre o 24 de septiembre de 2002)",
+	"command.bdset.force.timezone.name":                     "zona_horaria",
+	"command.bdset.force.timezone.description":              "Zona horaria del miembro",
+	"command.bdset.settings.name":                           "ajustes",
+	"command.bdset.settings.description":                    "Ver los ajustes de cumpleaños actuales",
+	"command.bdset.stop.name":                               "detener",
+	"command.bdset.stop.description":                        "Borra todos los ajustes de cumpleaños de este servidor",
+	"command.bdset.interactive.name":                        "asistente",
+	"command.bdset.interactive.description":                 "Inicia el asistente de configuración",
+	"command.bdset.dateformat.name":                         "formatofecha",
+	"command.bdset.dateformat.description":                  "Activa el formato de fecha europeo (DD/MM en lugar de MM/DD)",
+	"command.bdset.dateformat.european.name":                "europeo",
+	"command.bdset.dateformat.european.description":         "¿Usar el formato DD/MM/AAAA?",
+	"command.bdset.timeformat.name":                         "formatohora",
+	"command.bdset.timeformat.description":                  "Activa el formato de 24 horas",
+	"command.bdset.timeformat.use24h.name":                  "24h",
+	"command.bdset.timeformat.use24h.description":           "¿Usar el formato de 24 horas?",
+	"command.bdset.language.name":                           "idioma",
+	"command.bdset.language.description":                    "Establece el idioma de las respuestas y anuncios del bot",
+	"command.bdset.language.language.name":                  "idioma",
+	"command.bdset.language.language.description":           "Idioma que se usará",
+	"command.bdset.leapday.name":                            "29febrero",
+	"command.bdset.leapday.description":                     "Elige cuándo se celebran los cumpleaños del 29 de febrero en años no bisiestos",
+	"command.bdset.leapday.policy.name":                     "regla",
+	"command.bdset.leapday.policy.description":              "Cuándo celebrar los cumpleaños del 29 de febrero",
+	"command.bdset.embed.name":                              "embed",
+	"command.bdset.embed.description":                       "Diseña el embed de los anuncios de cumpleaños o vuelve al texto simple",
+	"command.bdset.embed.style.name":                        "estilo",
+	"command.bdset.embed.style.description":                 "Cómo se anuncian los cumpleaños",
+	"command.bdset.embed.show_avatar.name":                  "mostrar_avatar",
+	"command.bdset.embed.show_avatar.description":           "Mostrar el avatar del miembro en el embed",
+	"command.bdset.messages.name":                           "mensajes",
+	"command.bdset.messages.description":                    "Gestiona mensajes de cumpleaños adicionales elegidos al azar y los resúmenes",
+	"command.bdset.messages.add.name":                       "añadir",
+	"command.bdset.messages.add.description":                "Añade un mensaje de cumpleaños",
+	"command.bdset.messages.add.message.name":               "mensaje",
+	"command.bdset.messages.add.message.description":        "Mensaje con variables como {mention}, {display_name}, {new_age}, {server}",
+	"command.bdset.messages.add.weight.name":                "peso",
+	"command.bdset.messages.add.weight.description":         "Con qué frecuencia se elige frente a los demás (predeterminado: 1)",
+	"command.bdset.messages.add.audience.name":              "público",
+	"command.bdset.messages.add.audience.description":       "Para qué miembros se usa (predeterminado: con año si usa {new_age})",
+	"command.bdset.messages.list.name":                      "lista",
+	"command.bdset.messages.list.description":               "Lista los mensajes de cumpleaños de este servidor",
+	"command.bdset.messages.remove.name":                    "eliminar",
+	"command.bdset.messages.remove.description":             "Elimina un mensaje de cumpleaños",
+	"command.bdset.messages.remove.id.name":                 "id",
+	"command.bdset.messages.remove.id.description":          "El ID del mensaje, como aparece en /bdset mensajes lista",
+	"command.bdset.messages.preview.name":                   "vista_previa",
+	"command.bdset.messages.preview.description":            "Previsualiza un mensaje de cumpleaños contigo como miembro",
+	"command.bdset.messages.preview.id.name":                "id",
+	"command.bdset.messages.preview.id.description":         "El ID del mensaje (predeterminado: uno al azar)",
+	"command.bdset.messages.digest.name":                    "resumen",
+	"command.bdset.messages.digest.description":             "Publica un resumen diario en lugar de anuncios separados, y un avance semanal",
+	"command.bdset.messages.digest.mode.name":               "modo",
+	"command.bdset.messages.digest.mode.description":        "Cómo se anuncian los cumpleaños de hoy",
+	"command.bdset.messages.digest.weekly_day.name":         "día_semanal",
+	"command.bdset.messages.digest.weekly_day.description":  "Día para publicar los cumpleaños de la semana",
+	"command.bdset.messages.digest.weekly_hour.name":        "hora_semanal",
+	"command.bdset.messages.digest.weekly_hour.description": "Hora del resumen semanal, en la zona horaria predeterminada del servidor (0-23)",
+	"command.bdset.history.name":                            "historial",
+	"command.bdset.history.description":                     "Ver los últimos anuncios de cumpleaños",
+	"command.bdset.history.user.name":                       "miembro",
+	"command.bdset.history.user.description":                "Mostrar solo los anuncios de este miembro",
+	"command.bdset.calendar.name":                           "calendario",
+	"command.bdset.calendar.description":                    "Gestiona el feed de calendario con los cumpleaños del servidor",
+	"command.bdset.calendar.action.name":                    "acción",
+	"command.bdset.calendar.action.description":             "Qué hacer con el feed",
+	"command.bdset.export.name":                             "exportar",
+	"command.bdset.export.description":                      "Exporta los cumpleaños y ajustes de este servidor como archivo",
+	"command.bdset.export.format.name":                      "formato",
+	"command.bdset.export.format.description":               "Formato del archivo (predeterminado: JSON)",
+	"command.bdset.import.name":                             "importar",
+	"command.bdset.import.description":                      "Importa cumpleaños desde un archivo u otro bot de cumpleaños (solo el propietario del bot)",
+	"command.bdset.import.file.name":                        "archivo",
+	"command.bdset.import.file.description":                 "Una exportación de /bdset, CSV, hoja de cálculo (TSV), iCalendar (.ics) o archivo de RedBot",
+	"command.bdset.import.format.name":                      "formato",
+	"command.bdset.import.format.description":               "Formato del archivo (predeterminado: detectar automáticamente)",
+	"command.bdset.import.dry_run.name":                     "simulación",
+	"command.bdset.import.dry_run.description":              "Previsualiza los cambios y confirma antes de guardar nada",
+	"command.bdset.webhook.name":                            "webhook",
+	"command.bdset.webhook.description":                     "Gestiona los webhooks salientes para eventos de cumpleaños",
+	"command.bdset.webhook.add.name":                        "añadir",
+	"command.bdset.webhook.add.description":                 "Envía los eventos de cumpleaños a una URL",
+	"command.bdset.webhook.add.url.name":                    "url",
+	"command.bdset.webhook.add.url.description":             "La URL https:// a la que enviar los eventos",
+	"command.bdset.webhook.remove.name":                     "eliminar",
+	"command.bdset.webhook.remove.description":              "Deja de enviar eventos a un webhook",
+	"command.bdset.webhook.remove.id.name":                  "id",
+	"command.bdset.webhook.remove.id.description":           "El ID del webhook, como aparece en /bdset webhook lista",
+	"command.bdset.webhook.list.name":                       "lista",
+	"command.bdset.webhook.list.description":                "Lista los webhooks de este servidor",
+	"command.bdset.webhook.secret.name":                     "secreto",
+	"command.bdset.webhook.secret.description":              "Muestra el secreto usado para firmar los payloads de los webhooks",
+	"command.bdset.webhook.secret.rotate.name":              "renovar",
+	"command.bdset.webhook.secret.rotate.description":       "Sustituir el secreto por uno nuevo",
+	"command.bdset.webhook.test.name":                       "probar",
+	"command.bdset.webhook.test.description":                "Envía un evento ping a cada webhook",
+	"command.bdset.webhook.log.name":                        "registro",
+	"command.bdset.webhook.log.description":                 "Ver los últimos envíos de webhooks",
+	"command.bdset.apikey.name":                             "claveapi",
+	"command.bdset.apikey.description":                      "Gestiona las claves de la API REST de cumpleaños",
+	"command.bdset.apikey.create.name":                      "crear",
+	"command.bdset.apikey.create.description":               "Crea una clave de API (se muestra una sola vez)",
+	"command.bdset.apikey.create.name.name":                 "nombre",
+	"command.bdset.apikey.create.name.description":          "Para qué es la clave, p. ej. \"widget de la web\"",
+	"command.bdset.apikey.list.name":                        "lista",
+	"command.bdset.apikey.list.description":                 "Lista las claves de API de este servidor",
+	"command.bdset.apikey.revoke.name":                      "revocar",
+	"command.bdset.apikey.revoke.description":               "Revoca una clave de API",
+	"command.bdset.apikey.revoke.key_id.name":               "id_clave",
+	"command.bdset.apikey.revoke.key_id.description":        "El ID de la clave, como aparece en /bdset claveapi lista",
+	"command.bdset.admin.name":                              "admin",
+	"command.bdset.admin.description":                       "Gestiona los admins del bot",
+	"command.bdset.admin.add.name":                          "añadir",
+	"command.bdset.admin.add.description":                   "Añade un miembro o rol como admin del bot",
+	"command.bdset.admin.add.user.name":                     "miembro",
+	"command.bdset.admin.add.user.description":              "Miembro que se añadirá como admin",
+	"command.bdset.admin.add.role.name":                     "rol",
+	"command.bdset.admin.add.role.description":              "Rol que se añadirá como admin",
+	"command.bdset.admin.remove.name":                       "quitar",
+	"command.bdset.admin.remove.description":                "Quita un miembro o rol de los admins del bot",
+	"command.bdset.admin.remove.user.name":                  "miembro",
+	"command.bdset.admin.remove.user.description":           "Miembro que se quitará de los admins",
+	"command.bdset.admin.remove.role.name":                  "rol",
+	"command.bdset.admin.remove.role.description":           "Rol que se quitará de los admins",
+	"command.bdset.admin.list.name":                         "lista",
+	"command.bdset.admin.list.description":                  "Lista todos los admins del bot",
+}
@@ -0,0 +1,563 @@
+package i18n
+
+var french = map[string]string{
+	"language.name": "Français",
+
+	// Dates
+	"month.1":             "janvier",
+	"month.2":             "février",
+	"month.3":             "mars",
+	"month.4":             "avril",
+	"month.5":             "mai",
+	"month.6":             "juin",
+	"month.7":             "juillet",
+	"month.8":             "août",
+	"month.9":             "septembre",
+	"month.10":            "octobre",
+	"month.11":            "novembre",
+	"month.12":            "décembre",
+	"weekday.0":           "dimanche",
+	"weekday.1":           "lundi",
+	"weekday.2":           "mardi",
+	"weekday.3":           "mercredi",
+	"weekday.4":           "jeudi",
+	"weekday.5":           "vendredi",
+	"weekday.6":           "samedi",
+	"date.month_day":      "%d %s",
+	"date.month_day_year": "%d %s %d",
+	"date.day_month":      "%d %s",
+	"date.day_month_year": "%d %s %d",
+	"date.hint_month_day": "MM/JJ",
+	"date.hint_day_month": "JJ/MM",
+
+	// Shared
+	"button.cancel":               "Annuler",
+	"button.edit":                 "Modifier",
+	"common.cancelled":            "Annulé.",
+	"common.updated":              "✅ Mis à jour",
+	"error.fetch_birthdays":       "Impossible de récupérer les anniversaires",
+	"error.date.no_such_day":      "Il n'y a que %[2]d jours en %[1]s",
+	"error.date.not_leap_year":    "%d n'était pas une année bissextile, il n'y a donc pas eu de 29 février",
+	"error.date.future":           "**%s** est dans le futur. Indique ta date de naissance.",
+	"error.date.implausible_age":  "L'année de naissance %d semble incorrecte. Elle doit donner un âge entre %d et %d, ou tu peux omettre l'année.",
+	"error.invalid_date":          "Format de date invalide. Utilise des formats comme : %[1]s, %[2]s ou %[1]s/2002",
+	"error.invalid_timezone":      "Fuseau horaire invalide : %s. Choisis-en un dans la liste de suggestions.",
+	"error.invalid_timezone_iana": "Fuseau horaire invalide : %s. Utilise le format IANA, par exemple Europe/Paris",
+	"error.no_birthday":           "Tu n'as pas encore enregistré d'anniversaire sur ce serveur",
+	"error.no_permission":         "Tu n'as pas la permission d'utiliser cette commande. Il faut la permission « Gérer le serveur » ou être admin du bot.",
+	"error.update_setting":        "Impossible de mettre à jour le paramètre",
+	"error.user_required":         "Un membre est requis",
+
+	// Setting values
+	"value.yes":             "oui",
+	"value.no":              "non",
+	"value.on":              "✅ Activé",
+	"value.off":             "❌ Désactivé",
+	"value.enabled":         "✅ Activé",
+	"value.disabled":        "❌ Désactivé",
+	"value.not_set":         "Non défini",
+	"value.date_american":   "MM/JJ/AAAA (américain)",
+	"value.date_european":   "JJ/MM/AAAA (européen)",
+	"value.time_12h":        "12 heures (2:00 PM)",
+	"value.time_24h":        "24 heures (14:00)",
+	"value.hours":           "%d heures",
+	"value.mode_individual": "Un message par anniversaire",
+	"value.mode_digest":     "Un récapitulatif quotidien",
+	"value.weekly_digest":   "Le %s à %02d:00",
+	"value.leap_feb28":      "28 février les années non bissextiles",
+	"value.leap_mar1":       "1er mars les années non bissextiles",
+	"value.leap_only":       "Uniquement les années bissextiles",
+	"value.language_auto":   "Automatique (langue Discord de chaque membre)",
+
+	// /birthday set and remove
+	"birthday.set.success":    "🎂 Ton anniversaire a été fixé au **%s** !\nFuseau horaire : %s (heure actuelle : %s)",
+	"birthday.set.failed":     "Impossible d'enregistrer ton anniversaire",
+	"birthday.set.ambiguous":  "🤔 **%s** peut désigner plusieurs dates. Laquelle est ton anniversaire ?",
+	"birthday.remove.confirm": "Veux-tu vraiment supprimer ton anniversaire ?",
+	"birthday.remove.button":  "Oui, le supprimer",
+	"birthday.remove.failed":  "Impossible de supprimer l'anniversaire",
+	"birthday.remove.success": "✅ Ton anniversaire a été supprimé.",
+
+	// /birthday upcoming and the weekly digest
+	"upcoming.none_set": "Aucun anniversaire n'a encore été enregistré sur ce serveur.",
+	"upcoming.none":     "Aucun anniversaire dans les %d prochains jours.",
+	"upcoming.title":    "🎂 Prochains anniversaires (%d prochains jours)",
+	"upcoming.today":    "Aujourd'hui !",
+	"upcoming.tomorrow": "Demain",
+	"upcoming.in_days":  "Dans %d jours",
+	"upcoming.turning":  "<@%s> (va avoir %d ans) - %s",
+	"upcoming.now":      "<@%s> (a %d ans) - %s",
+
+	// /birthday calendar
+	"calendar.visibility.failed": "Impossible de modifier la visibilité dans le calendrier",
+	"calendar.visibility.shown":  "✅ Ton anniversaire sera affiché dans le calendrier de ce serveur",
+	"calendar.visibility.hidden": "✅ Ton anniversaire est maintenant masqué dans le calendrier de ce serveur",
+	"calendar.build_failed":      "Impossible de créer le calendrier des anniversaires",
+	"calendar.file":              "📅 Calendrier avec **%d** anniversaires. Ouvre le fichier pour l'ajouter à ton application de calendrier.",
+	"calendar.subscribe":         "Pour rester à jour, abonne-toi à <%s>",
+	"calendar.hide_hint":         "Utilise `/anniversaire calendrier me_montrer:False` pour masquer ton propre anniversaire.",
+	"calendar.name":              "Anniversaires",
+	"calendar.name_guild":        "Anniversaires %s",
+	"calendar.event_summary":     "🎂 Anniversaire de %s",
+
+	// /birthday notifications
+	"notifications.update_failed": "Impossible de mettre à jour tes notifications",
+	"notifications.get_failed":    "Impossible de récupérer tes notifications",
+	"notifications.status":        "**MP d'anniversaire**\nMessage le jour de ton anniversaire : %s\nRappel la veille : %s\n\nLes MP ne sont envoyés que si tu autorises les messages privés des membres de ce serveur.",
+
+	// /birthday follow and following
+	"follow.self":               "Utilise `/anniversaire notifications` pour recevoir un rappel de ton propre anniversaire",
+	"follow.bot":                "Les bots n'ont pas d'anniversaire",
+	"follow.failed":             "Impossible de suivre cet anniversaire",
+	"follow.limit":              "Tu peux suivre au maximum %d anniversaires. Utilise `/anniversaire suivis ne_plus_suivre:` pour en retirer un.",
+	"follow.success":            "✅ Tu recevras un MP %[1]s l'anniversaire de <@%[2]s>.",
+	"follow.no_birthday_yet":    "Ce membre n'a pas encore enregistré son anniversaire, tu seras prévenu dès qu'il le fera.",
+	"follow.allow_dms":          "Vérifie que tu autorises les messages privés des membres de ce serveur.",
+	"follow.days_before_one":    "1 jour avant",
+	"follow.days_before":        "%d jours avant",
+	"following.unfollow_failed": "Impossible de ne plus suivre cet anniversaire",
+	"following.not_following":   "Tu ne suis pas l'anniversaire de <@%s>",
+	"following.unfollowed":      "✅ Tu ne suis plus l'anniversaire de <@%s>",
+	"following.failed":          "Impossible de récupérer les anniversaires que tu suis",
+	"following.none":            "Tu ne suis aucun anniversaire. Utilise `/anniversaire suivre` pour recevoir un MP avant l'anniversaire de quelqu'un.",
+	"following.title":           "**Anniversaires suivis (%d/%d)**",
+	"following.no_birthday":     "pas d'anniversaire enregistré",
+	"following.line":            "• <@%s> — %s, rappel %s",
+	"following.unfollow_hint":   "Utilise `/anniversaire suivis ne_plus_suivre:` pour ne plus suivre quelqu'un.",
+
+	// Birthday DMs
+	"dm.greeting":        "🎂 Joyeux anniversaire de la part de **%s** ! Passe une excellente journée. 🎉",
+	"dm.greeting_age":    "🎂 Joyeux %s anniversaire de la part de **%s** ! Passe une excellente journée. 🎉",
+	"dm.reminder":        "🎈 Ton anniversaire, c'est demain ! **%s** le fêtera <t:%d:R>.",
+	"dm.your_server":     "ton serveur",
+	"dm.follow":          "🎁 **%s** (<@%s>) de **%s** fête son anniversaire %s, le %s %s !",
+	"dm.follow_age":      "🎁 **%s** (<@%s>) de **%s** fête son anniversaire %s, le %s %s, et aura %d ans !",
+	"dm.follow_tomorrow": "demain",
+	"dm.follow_in_days":  "dans %d jours",
+
+	// /bdset channel, role, time, rolemention, requiredrole, defaulttimezone, force
+	"channel.failed":          "Impossible de mettre à jour le salon",
+	"channel.success":         "✅ Salon des anniversaires défini sur <#%s>",
+	"role.failed":             "Impossible de mettre à jour le rôle",
+	"role.success":            "✅ Rôle d'anniversaire défini sur <@&%s>",
+	"time.failed":             "Impossible de mettre à jour l'heure",
+	"time.success":            "✅ Les annonces d'anniversaire seront envoyées à %02d:00 (dans le fuseau horaire de chaque membre)",
+	"rolemention.enabled":     "✅ Les mentions de rôles dans les messages d'anniversaire sont maintenant activées",
+	"rolemention.disabled":    "✅ Les mentions de rôles dans les messages d'anniversaire sont maintenant désactivées",
+	"requiredrole.set":        "✅ Seuls les membres ayant <@&%s> verront leur anniversaire annoncé",
+	"requiredrole.removed":    "✅ Rôle requis supprimé. Tous les membres peuvent voir leur anniversaire annoncé.",
+	"defaulttimezone.success": "✅ Fuseau horaire par défaut défini sur %s (heure actuelle : %s)",
+	"force.failed":            "Impossible d'enregistrer l'anniversaire",
+	"force.success":           "🎂 L'anniversaire de <@%s> a été fixé au **%s** !\nFuseau horaire : %s (heure actuelle : %s)",
+
+	// /bdset msgwithyear and msgwithoutyear
+	"message.invalid":       "Message invalide : %s",
+	"message.update_failed": "Impossible de mettre à jour le message",
+	"message.updated":       "✅ Message mis à jour ! Aperçu :\n> %s",
+
+	// /bdset settings
+	"settings.none":                 "Aucun paramètre configuré. Utilise `/bdset configuration` pour commencer.",
+	"settings.fetch_failed":         "Impossible de récupérer les paramètres",
+	"settings.title":                "🎂 Paramètres du bot d'anniversaire",
+	"settings.channel":              "Salon",
+	"settings.role":                 "Rôle",
+	"settings.hour":                 "Heure d'annonce",
+	"settings.default_timezone":     "Fuseau horaire par défaut",
+	"settings.role_mentions":        "Mentions de rôles",
+	"settings.required_role":        "Rôle requis",
+	"settings.date_format":          "Format de date",
+	"settings.time_format":          "Format d'heure",
+	"settings.language":             "Langue",
+	"settings.leap_day":             "Anniversaires du 29 février",
+	"settings.catchup":              "Fenêtre de rattrapage",
+	"settings.announcements":        "Annonces",
+	"settings.weekly_digest":        "Récapitulatif hebdomadaire",
+	"settings.setup_complete":       "Configuration terminée",
+	"settings.message_with_year":    "Message (avec âge)",
+	"settings.message_without_year": "Message (sans âge)",
+
+	// /bdset stop
+	"stop.confirm": "⚠️ Veux-tu vraiment effacer tous les paramètres d'anniversaire ? Les messages et les rôles d'anniversaire ne seront plus attribués.",
+	"stop.button":  "Oui, effacer les paramètres",
+	"stop.failed":  "Impossible d'effacer les paramètres",
+	"stop.success": "✅ Tous les paramètres d'anniversaire ont été effacés.",
+
+	// /bdset interactive
+	"setup.title":                "Configuration du bot d'anniversaire",
+	"setup.message_with_year":    "Avec âge ({mention}, {name}, {new_age})",
+	"setup.message_without_year": "Sans âge ({mention}, {name})",
+	"setup.hour":                 "Heure d'annonce (0-23)",
+	"setup.date_format":          "Date européenne JJ/MM ? (oui/non)",
+	"setup.time_format":          "Format 24 heures ? (oui/non)",
+	"setup.example_with_year":    "{mention} a maintenant {new_age} ans, joyeux anniversaire !",
+	"setup.example_without_year": "Joyeux anniversaire {mention} !",
+	"setup.invalid_with_year":    "Message avec année invalide : %s",
+	"setup.invalid_without_year": "Message sans année invalide : %s",
+	"setup.invalid_hour":         "Heure invalide. Saisis un nombre entre 0 et 23.",
+	"setup.failed":               "Impossible de mettre à jour les paramètres",
+	"setup.success":              "✅ Paramètres enregistrés !\n\n**Heure d'annonce :** %02d:00\n**Format de date :** %s\n**Format d'heure :** %s\n\nDéfinis maintenant le salon et le rôle :\n• `/bdset salon #salon`\n• `/bdset rôle @rôle`",
+
+	// /bdset dateformat, timeformat, language, leapday, time catchup_hours
+	"dateformat.success": "✅ Format de date défini sur %s",
+	"timeformat.success": "✅ Format d'heure défini sur %s",
+	"language.invalid":   "Langue inconnue",
+	"language.success":   "✅ Langue définie sur %s",
+	"leapday.invalid":    "Règle du 29 février invalide",
+	"leapday.success":    "✅ Les anniversaires du 29 février seront fêtés : %s",
+	"catchup.disabled":   "✅ Les annonces d'anniversaire manquées ne seront plus rattrapées",
+	"catchup.success":    "✅ Les annonces manquées pendant que le bot était hors ligne seront envoyées jusqu'à %d heures en retard",
+
+	// /bdset messages digest and the daily digest
+	"digest.mode":               "**Annonces :** %s",
+	"digest.posted_at":          "Le récapitulatif est publié à %02d:00 dans le fuseau horaire par défaut du serveur (%s).",
+	"digest.weekly":             "**Récapitulatif hebdomadaire :** %s",
+	"digest.weekly_title":       "🎈 Anniversaires de la semaine",
+	"digest.daily_title":        "🎉 **Anniversaires du jour** 🎉",
+	"digest.daily_line":         "🎂 <@%s>",
+	"digest.daily_line_age":     "🎂 <@%s> fête ses %d ans",
+	"digest.daily_closing_one":  "Joyeux anniversaire !",
+	"digest.daily_closing_many": "Joyeux anniversaire à vous tous !",
+
+	// /bdset embed
+	"embed.get_failed":          "Impossible de récupérer les paramètres de l'embed",
+	"embed.update_failed":       "Impossible de mettre à jour les paramètres de l'embed",
+	"embed.save_failed":         "Impossible d'enregistrer l'embed",
+	"embed.saved":               "✅ Embed enregistré",
+	"embed.designer_title":      "Embed d'annonce d'anniversaire",
+	"embed.field_title":         "Titre ({name}, {new_age})",
+	"embed.field_description":   "Description (vide = message d'anniversaire)",
+	"embed.field_color":         "Couleur (hex)",
+	"embed.field_image":         "URL de l'image",
+	"embed.field_footer":        "Pied de page ({name}, {new_age})",
+	"embed.example_title":       "🎉 Joyeux anniversaire, {name} !",
+	"embed.example_description": "{mention} a maintenant {new_age} ans !",
+	"embed.example_footer":      "De la part de tout le serveur",
+	"embed.preview_enabled":     "Les anniversaires sont annoncés avec cet embed. Aperçu :",
+	"embed.preview_disabled":    "Les anniversaires sont annoncés en texte ; utilise `/bdset embed style: Embed` pour changer. Aperçu de l'embed :",
+
+	// /bdset messages
+	"messages.fetch_failed":          "Impossible de récupérer les messages",
+	"messages.limit":                 "Ce serveur a déjà %d messages. Supprimes-en un d'abord.",
+	"messages.add_failed":            "Impossible d'ajouter le message",
+	"messages.added":                 "✅ Message `%d` ajouté (%s, poids %d). Aperçu :\n> %s",
+	"messages.first_added":           "Les anniversaires utilisent maintenant les messages de ce serveur au lieu de `/bdset msgavecannée` et `/bdset msgsansannée`.",
+	"messages.remove_failed":         "Impossible de supprimer le message",
+	"messages.not_found":             "Aucun message avec l'ID `%d` sur ce serveur.",
+	"messages.removed":               "✅ Message `%d` supprimé.",
+	"messages.none":                  "Aucun message ajouté, les anniversaires utilisent donc `/bdset msgavecannée` et `/bdset msgsansannée`. Utilise `/bdset messages ajouter` pour en ajouter.",
+	"messages.none_to_preview":       "Aucun message ajouté. Utilise `/bdset messages ajouter` pour en ajouter.",
+	"messages.list_title":            "💬 **Messages d'anniversaire (%d/%d)**",
+	"messages.line":                  "`%d` · %s · poids %d\n> %s",
+	"messages.preview":               "Message `%d` (%s) :\n> %s",
+	"messages.audience_with_year":    "avec année",
+	"messages.audience_without_year": "sans année",
+	"messages.audience_everyone":     "tout le monde",
+
+	// /bdset history
+	"history.failed": "Impossible de récupérer l'historique des annonces",
+	"history.none":   "Aucune annonce d'anniversaire n'a encore été enregistrée.",
+	"history.title":  "📜 Historique des annonces d'anniversaire",
+	"history.jump":   "Voir",
+
+	// /bdset calendar
+	"feed.update_failed": "Impossible de mettre à jour le flux du calendrier",
+	"feed.get_failed":    "Impossible de récupérer le flux du calendrier",
+	"feed.disabled":      "✅ Flux du calendrier désactivé. Les abonnements existants ne seront plus mis à jour.",
+	"feed.no_http":       "Le flux du calendrier nécessite le serveur HTTP du bot. Demande à l'hébergeur du bot de définir HTTP_ADDR et PUBLIC_URL.",
+	"feed.success":       "✅ Flux du calendrier : <%s>\nLes membres voient aussi ce lien dans `/anniversaire calendrier`.",
+	"feed.rotated":       "L'ancien lien ne fonctionne plus.",
+
+	// /bdset export
+	"export.failed":   "Impossible de créer l'export",
+	"export.success":  "📦 **%d** anniversaires exportés.",
+	"export.csv_note": "Les exports CSV ne contiennent que les anniversaires ; utilise JSON pour inclure les paramètres du serveur.",
+
+	// /bdset import
+	"import.verify_failed":         "Impossible de vérifier les permissions",
+	"import.owner_only":            "Cette commande est réservée au propriétaire du bot",
+	"import.no_file":               "Fichier joint introuvable",
+	"import.wrong_type":            "Joins un fichier JSON, CSV, TSV ou iCalendar (.ics)",
+	"import.download_failed":       "Impossible de télécharger le fichier : %s",
+	"import.read_failed":           "Impossible de lire le fichier : %s",
+	"import.parse_failed":          "Impossible d'analyser le fichier : %s",
+	"import.confirm_button":        "Confirmer l'import",
+	"import.expired":               "❌ Cet aperçu d'import a expiré. Relance `/bdset importer`.",
+	"import.cancelled":             "Import annulé. Rien n'a été modifié.",
+	"import.preview_title":         "📥 Aperçu de l'import",
+	"import.preview_description":   "Format détecté : **%s**\nRien n'a encore été modifié.",
+	"import.new":                   "Nouveaux",
+	"import.changed":               "Modifiés",
+	"import.unchanged":             "Inchangés",
+	"import.invalid":               "Invalides",
+	"import.settings":              "Paramètres",
+	"import.invalid_rows":          "Lignes invalides",
+	"import.more_errors":           "…et %d de plus",
+	"import.row":                   "Ligne %d",
+	"import.settings_replaced":     "seront remplacés",
+	"import.settings_not_included": "non inclus",
+	"import.settings_other_server": "ignorés (exportés depuis un autre serveur)",
+	"import.settings_invalid":      "ignorés (invalides, voir ci-dessous)",
+	"import.settings_failed":       "échec",
+	"import.settings_restored":     "restaurés",
+	"import.result":                "✅ Import terminé !\n\n**Format :** %s\n**Anniversaires importés :** %d (%d nouveaux, %d modifiés)\n**Inchangés :** %d\n**Lignes invalides :** %d\n**Erreurs :** %d\n**Paramètres :** %s",
+
+	// /bdset webhook
+	"webhook.invalid_url":          "URL de webhook invalide : %s",
+	"webhook.fetch_failed":         "Impossible de récupérer les webhooks",
+	"webhook.limit":                "Ce serveur a déjà %d webhooks. Supprimes-en un d'abord.",
+	"webhook.secret_create_failed": "Impossible de créer le secret du webhook",
+	"webhook.secret_get_failed":    "Impossible de récupérer le secret du webhook",
+	"webhook.add_failed":           "Impossible d'ajouter le webhook",
+	"webhook.added":                "✅ Webhook `%d` ajouté : <%s>\nLes payloads sont signés avec le secret de ce serveur :\n```\n%s\n```\nUtilise `/bdset webhook tester` pour envoyer un ping.",
+	"webhook.remove_failed":        "Impossible de supprimer le webhook",
+	"webhook.not_found":            "Aucun webhook avec l'ID `%d` sur ce serveur.",
+	"webhook.removed":              "✅ Webhook `%d` supprimé.",
+	"webhook.none":                 "Aucun webhook configuré. Utilise `/bdset webhook ajouter` pour en ajouter un.",
+	"webhook.none_configured":      "Aucun webhook configuré.",
+	"webhook.list_title":           "🪝 **Webhooks**",
+	"webhook.line":                 "`%d` · <%s> · ajouté par <@%s> <t:%d:R>",
+	"webhook.secret":               "🔑 Secret de signature des webhooks :\n```\n%s\n```",
+	"webhook.secret_rotated":       "✅ Secret renouvelé. Mets à jour tes récepteurs ; les payloads sont maintenant signés avec :\n```\n%s\n```",
+	"webhook.ping_sent":            "📨 Ping envoyé à %d webhook(s). Consulte `/bdset webhook journal` pour le résultat.",
+	"webhook.log_failed":           "Impossible de récupérer les envois de webhooks",
+	"webhook.log_none":             "Aucun envoi de webhook n'a encore été enregistré.",
+	"webhook.log_title":            "📜 **Derniers envois de webhooks**",
+	"webhook.delivery_target":      "webhook `%d`",
+	"webhook.delivery_attempts":    "%d tentatives",
+
+	// /bdset apikey
+	"apikey.create_failed": "Impossible de créer la clé d'API",
+	"apikey.created":       "✅ Clé d'API `%s` (**%s**) créée. Copie-la maintenant, elle ne sera plus affichée :\n```\n%s\n```\nEnvoie-la sous la forme `Authorization: Bearer <key>`.",
+	"apikey.no_http":       "⚠️ Le serveur HTTP du bot n'est pas activé, l'API restera inaccessible tant que l'hébergeur du bot n'aura pas défini HTTP_ADDR.",
+	"apikey.fetch_failed":  "Impossible de récupérer les clés d'API",
+	"apikey.none":          "Aucune clé d'API n'a été créée. Utilise `/bdset cléapi créer` pour en ajouter une.",
+	"apikey.list_title":    "🔑 **Clés d'API**",
+	"apikey.line":          "`%s` · **%s** · créée par <@%s> <t:%d:R>",
+	"apikey.last_used":     "utilisée <t:%d:R>",
+	"apikey.never_used":    "jamais utilisée",
+	"apikey.revoke_failed": "Impossible de révoquer la clé d'API",
+	"apikey.not_found":     "Aucune clé d'API avec l'ID `%s` sur ce serveur.",
+	"apikey.revoked":       "✅ Clé d'API `%s` révoquée.",
+
+	// /bdset admin
+	"admin.add_missing":        "Indique un membre ou un rôle à ajouter comme admin",
+	"admin.add_user_failed":    "Impossible d'ajouter le membre comme admin",
+	"admin.add_role_failed":    "Impossible d'ajouter le rôle comme admin",
+	"admin.user_added":         "✅ <@%s> est maintenant admin du bot",
+	"admin.role_added":         "✅ <@&%s> est maintenant un rôle admin du bot",
+	"admin.remove_missing":     "Indique un membre ou un rôle à retirer des admins",
+	"admin.remove_user_failed": "Impossible de retirer le membre des admins",
+	"admin.remove_role_failed": "Impossible de retirer le rôle des admins",
+	"admin.user_removed":       "✅ <@%s> n'est plus admin du bot",
+	"admin.role_removed":       "✅ <@&%s> n'est plus un rôle admin du bot",
+	"admin.fetch_failed":       "Impossible de récupérer les admins du bot",
+	"admin.none":               "Aucun admin du bot configuré. Seuls les membres ayant « Gérer le serveur » peuvent utiliser les commandes d'admin.",
+	"admin.title":              "🔐 Admins du bot",
+	"admin.users":              "Membres",
+	"admin.roles":              "Rôles",
+	"admin.footer":             "Les membres ayant « Gérer le serveur » ont toujours l'accès admin",
+
+	// Slash commands
+	"command.birthday.name":                                 "anniversaire",
+	"command.birthday.description":                          "Définir et gérer ton anniversaire",
+	"command.birthday.set.name":                             "définir",
+	"command.birthday.set.description":                      "Définir ton anniversaire",
+	"command.birthday.set.birthday.name":                    "date",
+	"command.birthday.set.birthday.description":             "Ton anniversaire (par ex. 24 septembre ou 24 septembre 2002)",
+	"command.birthday.set.timezone.name":                    "fuseau",
+	"command.birthday.set.timezone.description":             "Ton fuseau horaire",
+	"command.birthday.remove.name":                          "supprimer",
+	"command.birthday.remove.description":                   "Supprimer ton anniversaire",
+	"command.birthday.upcoming.name":                        "prochains",
+	"command.birthday.upcoming.description":                 "Voir les prochains anniversaires",
+	"command.birthday.upcoming.days.name":                   "jours",
+	"command.birthday.upcoming.days.description":            "Nombre de jours à afficher (par défaut : 7)",
+	"command.birthday.calendar.name":                        "calendrier",
+	"command.birthday.calendar.description":                 "Obtenir les anniversaires de ce serveur sous forme de fichier calendrier",
+	"command.birthday.calendar.show_me.name":                "me_montrer",
+	"command.birthday.calendar.show_me.description":         "Choisir plutôt si ton anniversaire apparaît dans le calendrier",
+	"command.birthday.notifications.name":                   "notifications",
+	"command.birthday.notifications.description":            "Choisir les MP d'anniversaire que le bot t'envoie",
+	"command.birthday.notifications.greeting.name":          "voeux",
+	"command.birthday.notifications.greeting.description":   "T'envoyer un MP de vœux quand ton anniversaire est annoncé",
+	"command.birthday.notifications.reminder.name":          "rappel",
+	"command.birthday.notifications.reminder.description":   "T'envoyer un MP la veille de ton anniversaire",
+	"command.birthday.follow.name":                          "suivre",
+	"command.birthday.follow.description":                   "Recevoir un MP avant l'anniversaire d'un autre membre",
+	"command.birthday.follow.user.name":                     "membre",
+	"command.birthday.follow.user.description":              "Le membre dont tu veux suivre l'anniversaire",
+	"command.birthday.follow.days.name":                     "jours",
+	"command.birthday.follow.days.description":              "Combien de jours avant son anniversaire te le rappeler (par défaut : 1)",
+	"command.birthday.following.name":                       "suivis",
+	"command.birthday.following.description":                "Voir les anniversaires que tu suis, ou arrêter d'en suivre un",
+	"command.birthday.following.unfollow.name":              "ne_plus_suivre",
+	"command.birthday.following.unfollow.description":       "Ne plus suivre l'anniversaire de ce membre",
+	"command.bdset.name":                                    "bdset",
+	"command.bdset.description":                             "Paramètres d'anniversaire pour les admins",
+	"command.bdset.channel.name":                            "salon",
+	"command.bdset.channel.description":                     "Définir le salon des annonces d'anniversaire",
+	"command.bdset.channel.channel.name":                    "salon",
+	"command.bdset.channel.channel.description":             "Le salon des annonces d'anniversaire",
+	"command.bdset.role.name":                               "rôle",
+	"command.bdset.role.description":                        "Définir le rôle d'anniversaire",
+	"command.bdset.role.role.name":                          "rôle",
+	"command.bdset.role.role.description":                   "Le rôle attribué le jour de l'anniversaire",
+	"command.bdset.time.name":                               "heure",
+	"command.bdset.time.description":                        "Définir l'heure d'annonce (0-23 dans le fuseau horaire par défaut du serveur)",
+	"command.bdset.time.hour.name":                          "heure",
+	"command.bdset.time.hour.description":                   "Heure de la journée (0-23)",
+	"command.bdset.time.catchup_hours.name":                 "heures_rattrapage",
+	"command.bdset.time.catchup_hours.description":          "Heures de retard pendant lesquelles une annonce manquée est encore envoyée (0 pour désactiver)",
+	"command.bdset.msgwithyear.name":                        "msgavecannée",
+	"command.bdset.msgwithyear.description":                 "Définir le message d'anniversaire (avec l'âge)",
+	"command.bdset.msgwithyear.message.name":                "message",
+	"command.bdset.msgwithyear.message.description":         "Message avec des variables comme {mention}, {display_name}, {ordinal_age}, {server}",
+	"command.bdset.msgwithoutyear.name":                     "msgsansannée",
+	"command.bdset.msgwithoutyear.description":              "Définir le message d'anniversaire (sans l'âge)",
+	"command.bdset.msgwithoutyear.message.name":             "message",
+	"command.bdset.msgwithoutyear.message.description":      "Message avec des variables comme {mention}, {display_name}, {server}",
+	"command.bdset.rolemention.name":                        "mentionrôle",
+	"command.bdset.rolemention.description":                 "Autoriser ou non les mentions de rôles dans les messages d'anniversaire",
+	"command.bdset.rolemention.enabled.name":                "activé",
+	"command.bdset.rolemention.enabled.description":         "Autoriser les mentions de rôles ?",
+	"command.bdset.requiredrole.name":                       "rôlerequis",
+	"command.bdset.requiredrole.description":                "Définir un rôle requis pour les annonces d'anniversaire",
+	"command.bdset.requiredrole.role.name":                  "rôle",
+	"command.bdset.requiredrole.role.description":           "Le rôle requis (laisser vide pour le supprimer)",
+	"command.bdset.defaulttimezone.name":                    "fuseaupardéfaut",
+	"command.bdset.defaulttimezone.description":             "Définir le fuseau horaire par défaut des membres",
+	"command.bdset.defaulttimezone.timezone.name":           "fuseau",
+	"command.bdset.defaulttimezone.timezone.description":    "Rechercher un fuseau horaire",
+	"command.bdset.force.name":                              "forcer",
+	"command.bdset.force.description":                       "Définir l'anniversaire d'un membre",
+	"command.bdset.force.user.name":                         "membre",
+	"command.bdset.force.user.description":                  "Le membre dont l'anniversaire est défini",
+	"command.bdset.force.birthday.name":                     "date",
+	"command.bdset.force.birthday.description":              "Anniversaire (par ex. 24 septembre ou 24 septembre 2002)",
+	"command.bdset.force.timezone.name":                     "fuseau",
+	"command.bdset.force.timezone.description":              "Fuseau horaire du membre",
+	"command.bdset.settings.name":                           "paramètres",
+	"command.bdset.settings.description":                    "Voir les paramètres d'anniversaire actuels",
+	"command.bdset.stop.name":                               "arrêter",
+	"command.bdset.stop.description":                        "Effacer tous les paramètres d'anniversaire de ce serveur",
+	"command.bdset.interactive.name":                        "configuration",
+	"command.bdset.interactive.description":                 "Lancer l'assistant de configuration",
+	"command.bdset.dateformat.name":                         "formatdate",
+	"command.bdset.dateformat.description":                  "Activer le format de date européen (JJ/MM au lieu de MM/JJ)",
+	"command.bdset.dateformat.european.name":                "européen",
+	"command.bdset.dateformat.european.description":         "Utiliser le format JJ/MM/AAAA ?",
+	"command.bdset.timeformat.name":                         "formatheure",
+	"command.bdset.timeformat.description":                  "Activer l'affichage sur 24 heures",
+	"command.bdset.timeformat.use24h.name":                  "24h",
+	"command.bdset.timeformat.use24h.description":           "Utiliser le format 24 heures ?",
+	"command.bdset.language.name":                           "langue",
+	"command.bdset.language.description":                    "Définir la langue des réponses et des annonces du bot",
+	"command.bdset.language.language.name":                  "langue",
+	"command.bdset.language.language.description":           "Langue à utiliser",
+	"command.bdset.leapday.name":                            "29février",
+	"command.bdset.leapday.description":                     "Choisir quand fêter les anniversaires du 29 février les années non bissextiles",
+	"command.bdset.leapday.policy.name":                     "règle",
+	"command.bdset.leapday.policy.description":              "Quand fêter les anniversaires du 29 février",
+	"command.bdset.embed.name":                              "embed",
+	"command.bdset.embed.description":                       "Concevoir l'embed des annonces d'anniversaire, ou revenir au texte simple",
+	"command.bdset.embed.style.name":                        "style",
+	"command.bdset.embed.style.description":                 "Comment les anniversaires sont annoncés",
+	"command.bdset.embed.show_avatar.name":                  "afficher_avatar",
+	"command.bdset.embed.show_avatar.description":           "Afficher l'avatar du membre dans l'embed",
+	"command.bdset.messages.name":                           "messages",
+	"command.bdset.messages.description":                    "Gérer des messages d'anniversaire supplémentaires choisis au hasard, et les récapitulatifs",
+	"command.bdset.messages.add.name":                       "ajouter",
+	"command.bdset.messages.add.description":                "Ajouter un message d'anniversaire",
+	"command.bdset.messages.add.message.name":               "message",
+	"command.bdset.messages.add.message.description":        "Message avec des variables comme {mention}, {display_name}, {new_age}, {server}",
+	"command.bdset.messages.add.weight.name":                "poids",
+	"command.bdset.messages.add.weight.description":         "Fréquence de sélection par rapport aux autres (par défaut : 1)",
+	"command.bdset.messages.add.audience.name":              "public",
+	"command.bdset.messages.add.audience.description":       "Membres concernés (par défaut : avec année s'il utilise {new_age})",
+	"command.bdset.messages.list.name":                      "liste",
+	"command.bdset.messages.list.description":               "Lister les messages d'anniversaire de ce serveur",
+	"command.bdset.messages.remove.name":                    "supprimer",
+	"command.bdset.messages.remove.description":             "Supprimer un message d'anniversaire",
+	"command.bdset.messages.remove.id.name":                 "id",
+	"command.bdset.messages.remove.id.description":          "L'ID du message, affiché dans /bdset messages liste",
+	"command.bdset.messages.preview.name":                   "aperçu",
+	"command.bdset.messages.preview.description":            "Prévisualiser un message d'anniversaire avec toi comme membre",
+	"command.bdset.messages.preview.id.name":                "id",
+	"command.bdset.messages.preview.id.description":         "L'ID du message (par défaut : un message au hasard)",
+	"command.bdset.messages.digest.name":                    "récapitulatif",
+	"command.bdset.messages.digest.description":             "Publier un récapitulatif quotidien au lieu d'annonces séparées, et un aperçu hebdomadaire",
+	"command.bdset.messages.digest.mode.name":               "mode",
+	"command.bdset.messages.digest.mode.description":        "Comment les anniversaires du jour sont annoncés",
+	"command.bdset.messages.digest.weekly_day.name":         "jour_hebdo",
+	"command.bdset.messages.digest.weekly_day.description":  "Jour de publication des anniversaires de la semaine",
+	"command.bdset.messages.digest.weekly_hour.name":        "heure_hebdo",
+	"command.bdset.messages.digest.weekly_hour.description": "Heure du récapitulatif hebdomadaire, dans le fuseau par défaut du serveur (0-23)",
+	"command.bdset.history.name":                            "historique",
+	"command.bdset.history.description":                     "Voir les dernières annonces d'anniversaire",
+	"command.bdset.history.user.name":                       "membre",
+	"command.bdset.history.user.description":                "N'afficher que les annonces de ce membre",
+	"command.bdset.calendar.name":                           "calendrier",
+	"command.bdset.calendar.description":                    "Gérer le flux de calendrier des anniversaires du serveur",
+	"command.bdset.calendar.action.name":                    "action",
+	"command.bdset.calendar.action.description":             "Que faire du flux",
+	"command.bdset.export.name":                             "exporter",
+	"command.bdset.export.description":                      "Exporter les anniversaires et les paramètres de ce serveur dans un fichier",
+	"command.bdset.export.format.name":                      "format",
+	"command.bdset.export.format.description":               "Format du fichier (par défaut : JSON)",
+	"command.bdset.import.name":                             "importer",
+	"command.bdset.import.description":                      "Importer des anniversaires depuis un fichier ou un autre bot (propriétaire du bot uniquement)",
+	"command.bdset.import.file.name":                        "fichier",
+	"command.bdset.import.file.description":                 "Un export /bdset, un CSV, un tableur (TSV), un iCalendar (.ics) ou un fichier RedBot",
+	"command.bdset.import.format.name":                      "format",
+	"command.bdset.import.format.description":               "Format du fichier (par défaut : détection automatique)",
+	"command.bdset.import.dry_run.name":                     "simulation",
+	"command.bdset.import.dry_run.description":              "Prévisualiser les changements et confirmer avant l'enregistrement",
+	"command.bdset.webhook.name":                            "webhook",
+	"command.bdset.webhook.description":                     "Gérer les webhooks sortants pour les événements d'anniversaire",
+	"command.bdset.webhook.add.name":                        "ajouter",
+	"command.bdset.webhook.add.description":                 "Envoyer les événements d'anniversaire à une URL",
+	"command.bdset.webhook.add.url.name":                    "url",
+	"command.bdset.webhook.add.url.description":             "L'URL https:// à laquelle envoyer les événements",
+	"command.bdset.webhook.remove.name":                     "supprimer",
+	"command.bdset.webhook.remove.description":              "Ne plus envoyer d'événements à un webhook",
+	"command.bdset.webhook.remove.id.name":                  "id",
+	"command.bdset.webhook.remove.id.description":           "L'ID du webhook, affiché dans /bdset webhook liste",
+	"command.bdset.webhook.list.name":                       "liste",
+	"command.bdset.webhook.list.description":                "Lister les webhooks de ce serveur",
+	"command.bdset.webhook.secret.name":                     "secret",
+	"command.bdset.webhook.secret.description":              "Afficher le secret utilisé pour signer les payloads des webhooks",
+	"command.bdset.webhook.secret.rotate.name":              "renouveler",
+	"command.bdset.webhook.secret.rotate.description":       "Remplacer le secret par un nouveau",
+	"command.bdset.webhook.test.name":                       "tester",
+	"command.bdset.webhook.test.description":                "Envoyer un événement ping à chaque webhook",
+	"command.bdset.webhook.log.name":                        "journal",
+	"command.bdset.webhook.log.description":                 "Voir les derniers envois de webhooks",
+	"command.bdset.apikey.name":                             "cléapi",
+	"command.bdset.apikey.description":                      "Gérer les clés de l'API REST des anniversaires",
+	"command.bdset.apikey.create.name":                      "créer",
+	"command.bdset.apikey.create.description":               "Créer une clé d'API (affichée une seule fois)",
+	"command.bdset.apikey.create.name.name":                 "nom",
+	"command.bdset.apikey.create.name.description":          "À quoi sert la clé, par ex. « widget du site »",
+	"command.bdset.apikey.list.name":                        "liste",
+	"command.bdset.apikey.list.description":                 "Lister les clés d'API de ce serveur",
+	"command.bdset.apikey.revoke.name":                      "révoquer",
+	"command.bdset.apikey.revoke.description":               "Révoquer une clé d'API",
+	"command.bdset.apikey.revoke.key_id.name":               "id_clé",
+	"command.bdset.apikey.revoke.key_id.description":        "L'ID de la clé, affiché dans /bdset cléapi liste",
+	"command.bdset.admin.name":                              "admin",
+	"command.bdset.admin.description":                       "Gérer les admins du bot",
+	"command.bdset.admin.add.name":                          "ajouter",
+	"command.bdset.admin.add.description":                   "Ajouter un membre ou un rôle comme admin du bot",
+	"command.bdset.admin.add.user.name":                     "membre",
+	"command.bdset.admin.add.user.description":              "Membre à ajouter comme admin",
+	"command.bdset.admin.add.role.name":                     "rôle",
+	"command.bdset.admin.add.role.description":              "Rôle à ajouter comme admin",
+	"command.bdset.admin.remove.name":                       "retirer",
+	"command.bdset.admin.remove.description":                "Retirer un membre ou un rôle des admins du bot",
+	"command.bdset.admin.remove.user.name":                  "membre",
+	"command.bdset.admin.remove.user.description":           "Membre à retirer des admins",
+	"command.bdset.admin.remove.role.name":                  "rôle",
+	"command.bdset.admin.remove.role.description":           "Rôle à retirer des admins",
+	"command.bdset.admin.list.name":                         "liste",
+	"command.bdset.admin.list.description":                  "Lister tous les admins du bot",
+}
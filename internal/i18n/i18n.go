@@ -0,0 +1,158 @@
+// Package i18n holds the bot's message catalogs and locale helpers.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Locale identifies one of the bot's languages
+type Locale string
+
+// Supported locales
+const (
+	English Locale = "en"
+	German  Locale = "de"
+	French  Locale = "fr"
+	Spanish Locale = "es"
+)
+
+// Locales lists the supported locales, English first
+var Locales = []Locale{English, German, French, Spanish}
+
+// catalogs maps each locale to its messages. English is the reference catalog every other locale
+// must translate in full.
+var catalogs = map[Locale]map[string]string{
+	English: english,
+	German:  german,
+	French:  french,
+	Spanish: spanish,
+}
+
+// Match returns the supported locale for a language tag such as "de" or Discord's "es-ES"
+func Match(tag string) (Locale, bool) {
+	base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	loc := Locale(base)
+	_, ok := catalogs[loc]
+	return loc, ok
+}
+
+// T returns the message for key in loc, formatted with args if any are given. Messages missing from
+// loc fall back to English, and unknown keys are returned as-is.
+func T(loc Locale, key string, args ...any) string {
+	msg, ok := catalogs[loc][key]
+	if !ok {
+		if msg, ok = english[key]; !ok {
+			msg = key
+		}
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// Has reports whether loc has its own translation of key
+func Has(loc Locale, key string) bool {
+	_, ok := catalogs[loc][key]
+	return ok
+}
+
+// Keys returns every message key, sorted
+func Keys() []string {
+	keys := make([]string, 0, len(english))
+	for k := range english {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// MonthName returns the name of month (1-12) in loc
+func MonthName(loc Locale, month int) string {
+	return T(loc, fmt.Sprintf("month.%d", month))
+}
+
+// WeekdayName returns the name of a time.Weekday (0 is Sunday) in loc
+func WeekdayName(loc Locale, weekday int) string {
+	return T(loc, fmt.Sprintf("weekday.%d", weekday))
+}
+
+// Ordinal formats n as an ordinal number in loc, e.g. 21st, 21., 21e or 21º
+func Ordinal(loc Locale, n int) string {
+	switch loc {
+	case German:
+		return fmt.Sprintf("%d.", n)
+	case French:
+		if n == 1 {
+			return "1er"
+		}
+		return fmt.Sprintf("%de", n)
+	case Spanish:
+		return fmt.Sprintf("%dº", n)
+	}
+
+	suffix := "th"
+	switch n % 100 {
+	case 11, 12, 13:
+	default:
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return fmt.Sprintf("%d%s", n, suffix)
+}
+
+// monthAbbreviations are month spellings that aren't prefixes of a catalog month name
+var monthAbbreviations = map[string]int{
+	"mrz":   3,
+	"maerz": 3,
+}
+
+// ParseMonth recognizes a month name in any supported locale, ignoring case and accents. Prefixes of
+// at least three letters are accepted when they only match one month, so "dez", "févr." and "sept"
+// all work.
+func ParseMonth(word string) (int, bool) {
+	word = foldAccents(strings.TrimSuffix(strings.ToLower(strings.TrimSpace(word)), "."))
+	if m, ok := monthAbbreviations[word]; ok {
+		return m, true
+	}
+	if len([]rune(word)) < 3 {
+		return 0, false
+	}
+
+	match := 0
+	for _, loc := range Locales {
+		for m := 1; m <= 12; m++ {
+			name := foldAccents(strings.ToLower(MonthName(loc, m)))
+			if !strings.HasPrefix(name, word) {
+				continue
+			}
+			if match != 0 && match != m {
+				return 0, false // e.g. "jui" could be juin or juillet
+			}
+			match = m
+		}
+	}
+	return match, match != 0
+}
+
+// foldAccents replaces accented letters used in month names with their plain forms
+func foldAccents(s string) string {
+	return accentFolder.Replace(s)
+}
+
+var accentFolder = strings.NewReplacer(
+	"ä", "a", "à", "a", "â", "a", "á", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "î", "i", "ï", "i",
+	"ó", "o", "ô", "o", "ö", "o",
+	"ú", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
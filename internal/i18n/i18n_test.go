@@ -0,0 +1,169 @@
+package i18n
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+var verbPattern = regexp.MustCompile(`%(?:\[(\d+)\])?[-+# 0]*\d*([a-zA-Z%])`)
+
+// sampleArgs builds one argument per verb in an English message, so translations can be formatted
+// with the arguments the bot actually passes
+func sampleArgs(msg string) []any {
+	var args []any
+	next := 1
+	for _, m := range verbPattern.FindAllStringSubmatch(msg, -1) {
+		if m[2] == "%" {
+			continue
+		}
+		idx := next
+		if m[1] != "" {
+			idx, _ = strconv.Atoi(m[1])
+		}
+		for len(args) < idx {
+			args = append(args, nil)
+		}
+		if m[2] == "d" {
+			args[idx-1] = 7
+		} else {
+			args[idx-1] = "x"
+		}
+		next = idx + 1
+	}
+	return args
+}
+
+func TestCatalogsAreComplete(t *testing.T) {
+	for _, loc := range Locales[1:] {
+		for _, key := range Keys() {
+			if !Has(loc, key) {
+				t.Errorf("%s is missing %q", loc, key)
+			}
+		}
+		for key := range catalogs[loc] {
+			// Slash commands are written in English in internal/bot/commands.go, which checks them
+			if strings.HasPrefix(key, "command.") {
+				continue
+			}
+			if _, ok := english[key]; !ok {
+				t.Errorf("%s has %q, which English doesn't", loc, key)
+			}
+		}
+	}
+}
+
+func TestCatalogsTakeTheSameArguments(t *testing.T) {
+	for _, key := range Keys() {
+		args := sampleArgs(english[key])
+		if len(args) == 0 {
+			continue
+		}
+		for _, loc := range Locales {
+			if got := T(loc, key, args...); strings.Contains(got, "%!") {
+				t.Errorf("%s %q formats badly: %s", loc, key, got)
+			}
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(German, "upcoming.tomorrow"); got != "Morgen" {
+		t.Errorf("German tomorrow = %q", got)
+	}
+	if got := T(Locale("xx"), "upcoming.in_days", 3); got != "In 3 days" {
+		t.Errorf("unknown locale = %q, want the English fallback", got)
+	}
+	if got := T(French, "no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key = %q", got)
+	}
+	if got := T(Spanish, "date.day_month_year", 24, MonthName(Spanish, 9), 2002); got != "24 de septiembre de 2002" {
+		t.Errorf("Spanish date = %q", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want Locale
+		ok   bool
+	}{
+		{"de", German, true},
+		{"es-ES", Spanish, true},
+		{"en-US", English, true},
+		{"FR", French, true},
+		{"ja", "", false},
+		{"", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := Match(tt.tag)
+		if ok != tt.ok || (ok && got != tt.want) {
+			t.Errorf("Match(%q) = %q, %v, want %q, %v", tt.tag, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseMonth(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"September", 9},
+		{"septembre", 9},
+		{"septiembre", 9},
+		{"sept", 9},
+		{"März", 3},
+		{"maerz", 3},
+		{"mrz", 3},
+		{"févr.", 2},
+		{"fevrier", 2},
+		{"dez", 12},
+		{"diciembre", 12},
+		{"août", 8},
+		{"mayo", 5},
+		{"juillet", 7},
+		{"jui", 0},
+		{"ma", 0},
+		{"smarch", 0},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseMonth(tt.word)
+		if got != tt.want || ok != (tt.want != 0) {
+			t.Errorf("ParseMonth(%q) = %d, %v, want %d", tt.word, got, ok, tt.want)
+		}
+	}
+}
+
+func TestOrdinal(t *testing.T) {
+	tests := []struct {
+		loc  Locale
+		n    int
+		want string
+	}{
+		{English, 1, "1st"},
+		{English, 2, "2nd"},
+		{English, 3, "3rd"},
+		{English, 4, "4th"},
+		{English, 11, "11th"},
+		{English, 12, "12th"},
+		{English, 13, "13th"},
+		{English, 21, "21st"},
+		{English, 22, "22nd"},
+		{English, 102, "102nd"},
+		{English, 111, "111th"},
+		{English, 113, "113th"},
+		{German, 3, "3."},
+		{French, 1, "1er"},
+		{French, 2, "2e"},
+		{Spanish, 5, "5º"},
+	}
+
+	for _, tt := range tests {
+		if got := Ordinal(tt.loc, tt.n); got != tt.want {
+			t.Errorf("Ordinal(%s, %d) = %q, want %q", tt.loc, tt.n, got, tt.want)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
 	"github.com/zlasd/tzloc"
 )
 
@@ -45,12 +46,12 @@ var PopularTimezones = []string{
 }
 
 // GetCurrentTime returns the current time in the given timezone
-func GetCurrentTime(ianaName string) (time.Time, error) {
+func GetCurrentTime(c clock.Clock, ianaName string) (time.Time, error) {
 	loc, err := time.LoadLocation(ianaName)
 	if err != nil {
 		return time.Time{}, err
 	}
-	return time.Now().In(loc), nil
+	return c.Now().In(loc), nil
 }
 
 // formatOffset formats seconds offset into a readable string like "UTC-5" or "UTC+5:30"
@@ -71,13 +72,13 @@ func formatOffset(offsetSeconds int) string {
 }
 
 // GetTimezoneInfo creates a TimezoneInfo with dynamically calculated offset
-func GetTimezoneInfo(ianaName string) TimezoneInfo {
+func GetTimezoneInfo(c clock.Clock, ianaName string) TimezoneInfo {
 	loc, err := time.LoadLocation(ianaName)
 	if err != nil {
 		return TimezoneInfo{IANA: ianaName, Offset: "UTC"}
 	}
 
-	_, offsetSeconds := time.Now().In(loc).Zone()
+	_, offsetSeconds := c.Now().In(loc).Zone()
 	return TimezoneInfo{
 		IANA:   ianaName,
 		Offset: formatOffset(offsetSeconds),
@@ -85,8 +86,8 @@ func GetTimezoneInfo(ianaName string) TimezoneInfo {
 }
 
 // FormatTimezoneChoice formats a timezone for the Discord autocomplete
-func FormatTimezoneChoice(tz TimezoneInfo, use24hTime bool) string {
-	currentTime, err := GetCurrentTime(tz.IANA)
+func FormatTimezoneChoice(c clock.Clock, tz TimezoneInfo, use24hTime bool) string {
+	currentTime, err := GetCurrentTime(c, tz.IANA)
 	if err != nil {
 		return tz.IANA + " (" + tz.Offset + ")"
 	}
@@ -100,12 +101,12 @@ func FormatTimezoneChoice(tz TimezoneInfo, use24hTime bool) string {
 }
 
 // SearchTimezones filters timezones based on a search query
-func SearchTimezones(query string) []TimezoneInfo {
+func SearchTimezones(c clock.Clock, query string) []TimezoneInfo {
 	if query == "" {
 		// Return popular timezones when no search query
 		results := make([]TimezoneInfo, 0, len(PopularTimezones))
 		for _, iana := range PopularTimezones {
-			results = append(results, GetTimezoneInfo(iana))
+			results = append(results, GetTimezoneInfo(c, iana))
 		}
 		// Limit to 25 (Discord limit)
 		if len(results) > 25 {
@@ -122,13 +123,13 @@ func SearchTimezones(query string) []TimezoneInfo {
 	// Search through all IANA timezones
 	for _, iana := range allLocations {
 		if strings.Contains(strings.ToLower(iana), query) {
-			results = append(results, GetTimezoneInfo(iana))
+			results = append(results, GetTimezoneInfo(c, iana))
 		}
 	}
 
 	// Also search by offset (e.g., "utc-5", "+5:30")
 	for _, iana := range allLocations {
-		tzInfo := GetTimezoneInfo(iana)
+		tzInfo := GetTimezoneInfo(c, iana)
 		if strings.Contains(strings.ToLower(tzInfo.Offset), query) {
 			// Avoid duplicates
 			found := false
@@ -162,51 +163,122 @@ func ValidateTimezone(ianaName string) bool {
 }
 
 // GetOffset returns the UTC offset for a timezone at the current time
-func GetOffset(ianaName string) (int, error) {
+func GetOffset(c clock.Clock, ianaName string) (int, error) {
 	loc, err := time.LoadLocation(ianaName)
 	if err != nil {
 		return 0, err
 	}
-	_, offset := time.Now().In(loc).Zone()
+	_, offset := c.Now().In(loc).Zone()
 	return offset / 3600, nil // Return hours
 }
 
 // GetLocalHour returns the current hour in the given timezone
-func GetLocalHour(ianaName string) (int, error) {
+func GetLocalHour(c clock.Clock, ianaName string) (int, error) {
 	loc, err := time.LoadLocation(ianaName)
 	if err != nil {
 		return 0, err
 	}
-	return time.Now().In(loc).Hour(), nil
+	return c.Now().In(loc).Hour(), nil
 }
 
 // GetLocalDate returns the current month and day in the given timezone
-func GetLocalDate(ianaName string) (month, day int, err error) {
+func GetLocalDate(c clock.Clock, ianaName string) (month, day int, err error) {
 	loc, err := time.LoadLocation(ianaName)
 	if err != nil {
 		return 0, 0, err
 	}
-	now := time.Now().In(loc)
+	now := c.Now().In(loc)
 	return int(now.Month()), now.Day(), nil
 }
 
-// IsBirthdayToday checks if a birthday (month/day) is today in the given timezone
-func IsBirthdayToday(month, day int, ianaName string) (bool, error) {
-	currentMonth, currentDay, err := GetLocalDate(ianaName)
+// Leap-day policies decide when Feb 29 birthdays are celebrated in common years
+const (
+	LeapDayFeb28    = "feb28"
+	LeapDayMar1     = "mar1"
+	LeapDayLeapOnly = "leaponly"
+)
+
+// IsLeapYear reports whether year has a Feb 29
+func IsLeapYear(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// ObservedBirthday returns the month and day a birthday is celebrated on in the given year.
+// ok is false when a Feb 29 birthday is not celebrated that year under the leap-day policy.
+func ObservedBirthday(month, day, year int, leapPolicy string) (observedMonth, observedDay int, ok bool) {
+	if month != 2 || day != 29 || IsLeapYear(year) {
+		return month, day, true
+	}
+	switch leapPolicy {
+	case LeapDayMar1:
+		return 3, 1, true
+	case LeapDayLeapOnly:
+		return 0, 0, false
+	default:
+		return 2, 28, true
+	}
+}
+
+// NextBirthday returns the first date on or after today (a midnight date) that the
+// birthday is celebrated on, in today's location
+func NextBirthday(today time.Time, month, day int, leapPolicy string) time.Time {
+	// A leap-only Feb 29 birthday can be up to four years away
+	for year := today.Year(); year <= today.Year()+4; year++ {
+		m, d, ok := ObservedBirthday(month, day, year, leapPolicy)
+		if !ok {
+			continue
+		}
+		date := time.Date(year, time.Month(m), d, 0, 0, 0, 0, today.Location())
+		if !date.Before(today) {
+			return date
+		}
+	}
+	return time.Time{}
+}
+
+// IsBirthdayToday checks if a birthday (month/day) is celebrated on now's date in the given timezone
+func IsBirthdayToday(now time.Time, month, day int, leapPolicy, ianaName string) (bool, error) {
+	loc, err := time.LoadLocation(ianaName)
 	if err != nil {
 		slog.Debug("IsBirthdayToday error", "timezone", ianaName, "error", err)
 		return false, err
 	}
-	result := currentMonth == month && currentDay == day
+	now = now.In(loc)
+	currentMonth, currentDay := int(now.Month()), now.Day()
+
+	observedMonth, observedDay, ok := ObservedBirthday(month, day, now.Year(), leapPolicy)
+	result := ok && currentMonth == observedMonth && currentDay == observedDay
 	if result {
 		slog.Debug("IsBirthdayToday", "timezone", ianaName, "current", currentMonth*100+currentDay, "birthday", month*100+day, "result", result)
 	}
 	return result, nil
 }
 
+// AnnouncementTime returns the announcement instant (targetHour:00 local time) on now's date in the
+// given timezone. A target hour skipped by a DST transition resolves to the first instant after the gap.
+func AnnouncementTime(now time.Time, targetHour int, ianaName string) (time.Time, error) {
+	loc, err := time.LoadLocation(ianaName)
+	if err != nil {
+		return time.Time{}, err
+	}
+	now = now.In(loc)
+	if t := time.Date(now.Year(), now.Month(), now.Day(), targetHour, 0, 0, 0, loc); t.Hour() == targetHour {
+		return t, nil
+	}
+
+	// The hour was skipped, and time.Date doesn't say which side of the gap it picked. Walk forward
+	// from midnight to the first instant that reaches the target hour, which is the end of the gap.
+	// Offsets change on quarter hours, so the walk can't step over it.
+	t := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	for t.Hour() < targetHour && t.Day() == now.Day() {
+		t = t.Add(15 * time.Minute)
+	}
+	return t, nil
+}
+
 // ShouldAnnounce checks if we should announce now (user's local hour matches target hour)
-func ShouldAnnounce(targetHour int, userTimezone string) (bool, error) {
-	currentHour, err := GetLocalHour(userTimezone)
+func ShouldAnnounce(c clock.Clock, targetHour int, userTimezone string) (bool, error) {
+	currentHour, err := GetLocalHour(c, userTimezone)
 	if err != nil {
 		slog.Debug("ShouldAnnounce error", "timezone", userTimezone, "error", err)
 		return false, err
@@ -0,0 +1,163 @@
+package timezone
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Johnnycyan/cyan-birthdays/internal/clock"
+)
+
+func TestIsBirthdayToday(t *testing.T) {
+	tests := []struct {
+		name       string
+		now        time.Time
+		month, day int
+		tz         string
+		want       bool
+	}{
+		{"Auckland is a day ahead of UTC", time.Date(2026, 2, 28, 10, 0, 0, 0, time.UTC), 2, 28, "Pacific/Auckland", true},
+		{"Auckland rolls over before UTC", time.Date(2026, 2, 28, 11, 0, 0, 0, time.UTC), 3, 1, "Pacific/Auckland", true},
+		{"Honolulu is still yesterday", time.Date(2026, 1, 1, 5, 0, 0, 0, time.UTC), 12, 31, "Pacific/Honolulu", true},
+		{"Honolulu is not yet new year", time.Date(2026, 1, 1, 5, 0, 0, 0, time.UTC), 1, 1, "Pacific/Honolulu", false},
+		{"UTC matches exactly", time.Date(2026, 9, 24, 0, 0, 0, 0, time.UTC), 9, 24, "UTC", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IsBirthdayToday(tt.now, tt.month, tt.day, LeapDayFeb28, tt.tz)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsBirthdayToday = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := IsBirthdayToday(time.Now(), 1, 1, LeapDayFeb28, "Not/AZone"); err == nil {
+		t.Error("expected error for invalid timezone")
+	}
+}
+
+func TestShouldAnnounceAcrossDST(t *testing.T) {
+	// America/New_York springs forward at 2026-03-08 07:00 UTC (02:00 EST -> 03:00 EDT)
+	clk := clock.NewFake(time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC))
+
+	var hours []int
+	for i := 0; i < 12; i++ {
+		h, err := GetLocalHour(clk, "America/New_York")
+		if err != nil {
+			t.Fatal(err)
+		}
+		hours = append(hours, h)
+		clk.Advance(time.Hour)
+	}
+
+	want := []int{19, 20, 21, 22, 23, 0, 1, 3, 4, 5, 6, 7}
+	for i := range want {
+		if hours[i] != want[i] {
+			t.Fatalf("local hours = %v, want %v", hours, want)
+		}
+	}
+
+	// The skipped 02:00 hour can never match
+	clk.Set(time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC))
+	if ok, _ := ShouldAnnounce(clk, 2, "America/New_York"); ok {
+		t.Error("ShouldAnnounce matched a non-existent local hour")
+	}
+	if ok, _ := ShouldAnnounce(clk, 3, "America/New_York"); !ok {
+		t.Error("ShouldAnnounce should match 03:00 EDT")
+	}
+}
+
+func TestAnnouncementTimeInSkippedHour(t *testing.T) {
+	tests := []struct {
+		zone string
+		now  time.Time // a moment on the transition day
+		hour int
+		want time.Time
+	}{
+		// 02:00 EST -> 03:00 EDT
+		{"America/New_York", time.Date(2026, 3, 8, 17, 0, 0, 0, time.UTC), 2, time.Date(2026, 3, 8, 7, 0, 0, 0, time.UTC)},
+		// 02:00 CET -> 03:00 CEST
+		{"Europe/Berlin", time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), 2, time.Date(2024, 3, 31, 1, 0, 0, 0, time.UTC)},
+		{"Europe/Berlin", time.Date(2024, 3, 31, 12, 0, 0, 0, time.UTC), 9, time.Date(2024, 3, 31, 7, 0, 0, 0, time.UTC)},
+		// 02:00 NZST -> 03:00 NZDT
+		{"Pacific/Auckland", time.Date(2024, 9, 29, 0, 0, 0, 0, time.UTC), 2, time.Date(2024, 9, 28, 14, 0, 0, 0, time.UTC)},
+		{"Pacific/Auckland", time.Date(2024, 9, 29, 0, 0, 0, 0, time.UTC), 1, time.Date(2024, 9, 28, 13, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		got, err := AnnouncementTime(tt.now, tt.hour, tt.zone)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("AnnouncementTime(%d, %s) = %s, want %s", tt.hour, tt.zone, got, tt.want.In(got.Location()))
+		}
+	}
+}
+
+func TestGetTimezoneInfoUsesClock(t *testing.T) {
+	winter := GetTimezoneInfo(clock.NewFake(time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)), "Europe/Berlin")
+	summer := GetTimezoneInfo(clock.NewFake(time.Date(2026, 7, 15, 12, 0, 0, 0, time.UTC)), "Europe/Berlin")
+
+	if winter.Offset != "UTC+1" {
+		t.Errorf("winter offset = %s, want UTC+1", winter.Offset)
+	}
+	if summer.Offset != "UTC+2" {
+		t.Errorf("summer offset = %s, want UTC+2", summer.Offset)
+	}
+}
+
+func TestObservedBirthday(t *testing.T) {
+	tests := []struct {
+		name         string
+		month, day   int
+		year         int
+		policy       string
+		wantM, wantD int
+		wantOK       bool
+	}{
+		{"regular birthday is unaffected", 9, 24, 2026, LeapDayLeapOnly, 9, 24, true},
+		{"leap day in a leap year", 2, 29, 2028, LeapDayMar1, 2, 29, true},
+		{"feb28 policy in a common year", 2, 29, 2026, LeapDayFeb28, 2, 28, true},
+		{"mar1 policy in a common year", 2, 29, 2026, LeapDayMar1, 3, 1, true},
+		{"leaponly policy in a common year", 2, 29, 2026, LeapDayLeapOnly, 0, 0, false},
+		{"unset policy defaults to feb28", 2, 29, 2026, "", 2, 28, true},
+		{"2100 is not a leap year", 2, 29, 2100, LeapDayMar1, 3, 1, true},
+		{"2000 is a leap year", 2, 29, 2000, LeapDayLeapOnly, 2, 29, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, d, ok := ObservedBirthday(tt.month, tt.day, tt.year, tt.policy)
+			if m != tt.wantM || d != tt.wantD || ok != tt.wantOK {
+				t.Errorf("ObservedBirthday = (%d, %d, %v), want (%d, %d, %v)", m, d, ok, tt.wantM, tt.wantD, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestNextBirthday(t *testing.T) {
+	tests := []struct {
+		name   string
+		today  time.Time
+		policy string
+		want   time.Time
+	}{
+		{"mar1 in a common year", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), LeapDayMar1, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{"feb28 in a common year", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), LeapDayFeb28, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC)},
+		{"leaponly skips to the next leap year", time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), LeapDayLeapOnly, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"already passed this year", time.Date(2027, 3, 2, 0, 0, 0, 0, time.UTC), LeapDayFeb28, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"today counts", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC), LeapDayLeapOnly, time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NextBirthday(tt.today, 2, 29, tt.policy); !got.Equal(tt.want) {
+				t.Errorf("NextBirthday = %s, want %s", got.Format("2006-01-02"), tt.want.Format("2006-01-02"))
+			}
+		})
+	}
+}